@@ -0,0 +1,140 @@
+package twitch
+
+import (
+	"sync"
+	"time"
+)
+
+// Batcher collects events of type T and delivers them together once the
+// window since the first event of the batch has passed.
+type Batcher[T any] struct {
+	client   EventSubClient
+	clock    Clock
+	id       HandlerID
+	window   time.Duration
+	callback func(events []T)
+
+	mu     sync.Mutex
+	events []T
+	// stop stops the timer of the current batch
+	stop func()
+}
+
+// Batch delivers events of type T in batches, for example to coalesce a flood
+// of cheers and gift subs during a raid into one alert:
+//
+//	twitch.Batch(client, 5*time.Second, func(events []twitch.SubscriptionEvent) {
+//		...
+//	})
+//
+// T can be an event struct, a category like SubscriptionEvent, or any.
+func Batch[T any](client EventSubClient, window time.Duration, callback func(events []T)) *Batcher[T] {
+	b := &Batcher[T]{
+		client:   client,
+		clock:    clockOf(client),
+		window:   window,
+		callback: callback,
+	}
+	b.id = On(client, b.add)
+	return b
+}
+
+func (b *Batcher[T]) add(event T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events = append(b.events, event)
+	if b.stop == nil {
+		b.stop = afterFunc(b.clock, b.window, b.Flush)
+	}
+}
+
+// Flush delivers the current batch right away.
+func (b *Batcher[T]) Flush() {
+	b.mu.Lock()
+	events := b.events
+	b.events = nil
+	if b.stop != nil {
+		b.stop()
+		b.stop = nil
+	}
+	b.mu.Unlock()
+
+	if len(events) > 0 {
+		b.callback(events)
+	}
+}
+
+// Stop stops collecting events and delivers what was collected so far.
+func (b *Batcher[T]) Stop() {
+	b.client.Off(b.id)
+	b.Flush()
+}
+
+// Debouncer delivers the last event of type T once no other arrived for the
+// wait duration.
+type Debouncer[T any] struct {
+	client   EventSubClient
+	clock    Clock
+	id       HandlerID
+	wait     time.Duration
+	callback func(event T)
+
+	mu      sync.Mutex
+	last    T
+	pending bool
+	stop    func()
+}
+
+// Debounce delivers only the last of a burst of events of type T, once the
+// burst has been quiet for the wait duration.
+func Debounce[T any](client EventSubClient, wait time.Duration, callback func(event T)) *Debouncer[T] {
+	d := &Debouncer[T]{
+		client:   client,
+		clock:    clockOf(client),
+		wait:     wait,
+		callback: callback,
+	}
+	d.id = On(client, d.add)
+	return d
+}
+
+func (d *Debouncer[T]) add(event T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.last = event
+	d.pending = true
+	if d.stop != nil {
+		d.stop()
+	}
+	d.stop = afterFunc(d.clock, d.wait, d.fire)
+}
+
+func (d *Debouncer[T]) fire() {
+	d.mu.Lock()
+	event, pending := d.last, d.pending
+	var zero T
+	d.last = zero
+	d.pending = false
+	d.mu.Unlock()
+
+	if pending {
+		d.callback(event)
+	}
+}
+
+// Stop stops listening and drops an event still waiting to be delivered.
+func (d *Debouncer[T]) Stop() {
+	d.client.Off(d.id)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stop != nil {
+		d.stop()
+	}
+	var zero T
+	d.last = zero
+	d.pending = false
+}
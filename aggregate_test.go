@@ -0,0 +1,114 @@
+package twitch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	batches := make(chan []twitch.SubscriptionEvent, 2)
+	batcher := twitch.Batch(client, 20*time.Millisecond, func(events []twitch.SubscriptionEvent) {
+		batches <- events
+	})
+
+	handleEvents(t, client, twitch.SubChannelSubscribe, twitch.SubStreamOnline, twitch.SubChannelSubscriptionGift)
+
+	select {
+	case batch := <-batches:
+		if assert.Len(t, batch, 2) {
+			assert.IsType(t, twitch.EventChannelSubscribe{}, batch[0])
+			assert.IsType(t, twitch.EventChannelSubscriptionGift{}, batch[1])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("batch was not delivered")
+	}
+
+	handleEvents(t, client, twitch.SubChannelSubscriptionEnd)
+	batcher.Stop()
+	assert.Len(t, <-batches, 1)
+
+	handleEvents(t, client, twitch.SubChannelSubscribe)
+	batcher.Flush()
+	assert.Empty(t, batches)
+}
+
+func TestDebounce(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	events := make(chan twitch.StreamEvent, 2)
+	twitch.Debounce(client, 20*time.Millisecond, func(event twitch.StreamEvent) {
+		events <- event
+	})
+
+	handleEvents(t, client, twitch.SubStreamOnline, twitch.SubStreamOffline)
+
+	select {
+	case event := <-events:
+		assert.IsType(t, twitch.EventStreamOffline{}, event)
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	assert.Empty(t, events)
+}
+
+func TestBatchClock(t *testing.T) {
+	t.Parallel()
+
+	clock := clocktest.NewClock(time.Now())
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithClock(clock))
+
+	batches := make(chan []twitch.SubscriptionEvent, 1)
+	twitch.Batch(client, time.Minute, func(events []twitch.SubscriptionEvent) {
+		batches <- events
+	})
+
+	handleEvents(t, client, twitch.SubChannelSubscribe, twitch.SubChannelSubscriptionGift)
+	clock.Advance(59 * time.Second)
+	assert.Empty(t, batches, "the window has not passed yet")
+
+	clock.Advance(time.Second)
+	select {
+	case batch := <-batches:
+		assert.Len(t, batch, 2)
+	case <-time.After(time.Second):
+		t.Fatal("batch was not delivered")
+	}
+}
+
+func TestDebounceClock(t *testing.T) {
+	t.Parallel()
+
+	clock := clocktest.NewClock(time.Now())
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithClock(clock))
+
+	events := make(chan twitch.StreamEvent, 1)
+	twitch.Debounce(client, time.Minute, func(event twitch.StreamEvent) {
+		events <- event
+	})
+
+	handleEvents(t, client, twitch.SubStreamOnline)
+	clock.Advance(30 * time.Second)
+	handleEvents(t, client, twitch.SubStreamOffline)
+	clock.Advance(30 * time.Second)
+	assert.Empty(t, events, "the second event restarted the wait")
+
+	clock.Advance(30 * time.Second)
+	select {
+	case event := <-events:
+		assert.IsType(t, twitch.EventStreamOffline{}, event)
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+}
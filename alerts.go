@@ -0,0 +1,98 @@
+package twitch
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+var ErrNoAlertTemplate = fmt.Errorf("no alert template")
+
+// Alerts renders events into text, like chat announcements or overlay
+// captions, through text/template strings keyed by subscription type. The
+// templates are executed on the event, so "{{.UserName}} just followed!"
+// works for channel.follow.
+type Alerts struct {
+	templates map[EventSubscription]*template.Template
+
+	mu      sync.Mutex
+	onError func(err error)
+}
+
+// NewAlerts parses the templates, returning the first one that could not be
+// parsed as an error.
+func NewAlerts(templates map[EventSubscription]string) (*Alerts, error) {
+	alerts := &Alerts{templates: make(map[EventSubscription]*template.Template, len(templates))}
+	for subType, text := range templates {
+		tmpl, err := template.New(string(subType)).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse alert template for %s: %w", subType, err)
+		}
+		alerts.templates[subType] = tmpl
+	}
+	return alerts, nil
+}
+
+// OnError is called by OnAlert when a template could not be executed.
+func (a *Alerts) OnError(callback func(err error)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.onError = callback
+}
+
+// Has returns whether there is a template for the event's subscription type.
+func (a *Alerts) Has(event any) bool {
+	subType, ok := subscriptionTypeOf(event)
+	if !ok {
+		return false
+	}
+	_, ok = a.templates[subType]
+	return ok
+}
+
+// Render executes the template of the event's subscription type on the event.
+// Events without a template return ErrNoAlertTemplate.
+func (a *Alerts) Render(event any) (string, error) {
+	subType, ok := subscriptionTypeOf(event)
+	if !ok {
+		return "", fmt.Errorf("%w for %T", ErrUnknownSubscriptionType, event)
+	}
+
+	tmpl, ok := a.templates[subType]
+	if !ok {
+		return "", fmt.Errorf("%w for %s", ErrNoAlertTemplate, subType)
+	}
+
+	var text strings.Builder
+	err := tmpl.Execute(&text, event)
+	if err != nil {
+		return "", fmt.Errorf("could not render %s alert: %w", subType, err)
+	}
+	return text.String(), nil
+}
+
+// OnAlert registers a listener that renders every event with a template in
+// alerts and passes the text to callback. Events without a template are
+// skipped and templates that fail are reported to the OnError of alerts.
+func OnAlert(client EventSubClient, alerts *Alerts, callback func(text string)) HandlerID {
+	return client.AddListener(func(message NotificationMessage, event any) {
+		if !alerts.Has(event) {
+			return
+		}
+
+		text, err := alerts.Render(event)
+		if err != nil {
+			alerts.mu.Lock()
+			onError := alerts.onError
+			alerts.mu.Unlock()
+
+			if onError != nil {
+				onError(err)
+			}
+			return
+		}
+		callback(text)
+	})
+}
@@ -0,0 +1,59 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertsRender(t *testing.T) {
+	t.Parallel()
+
+	alerts, err := twitch.NewAlerts(map[twitch.EventSubscription]string{
+		twitch.SubChannelFollow: "{{.UserName}} just followed!",
+		twitch.SubChannelCheer:  "{{if .IsAnonymous}}Someone{{else}}{{.UserName}}{{end}} cheered {{.Bits}} bits",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := alerts.Render(twitch.EventChannelFollow{User: twitch.User{UserName: "Alice"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice just followed!", text)
+
+	text, err = alerts.Render(twitch.EventChannelCheer{Bits: 100, IsAnonymous: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Someone cheered 100 bits", text)
+
+	_, err = alerts.Render(twitch.EventStreamOnline{})
+	assert.ErrorIs(t, err, twitch.ErrNoAlertTemplate)
+
+	_, err = twitch.NewAlerts(map[twitch.EventSubscription]string{twitch.SubChannelFollow: "{{.UserName"})
+	assert.ErrorContains(t, err, "channel.follow")
+}
+
+func TestOnAlert(t *testing.T) {
+	t.Parallel()
+
+	alerts, err := twitch.NewAlerts(map[twitch.EventSubscription]string{
+		twitch.SubChannelFollow: "{{.UserName}} just followed!",
+		twitch.SubStreamOnline:  "{{.Missing}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []error
+	alerts.OnError(func(err error) { errs = append(errs, err) })
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	// The test events are version 1, which was removed for some types
+	client.OnDeprecation(func(deprecation twitch.Deprecation) {})
+	var texts []string
+	twitch.OnAlert(client, alerts, func(text string) { texts = append(texts, text) })
+
+	handleEvents(t, client, twitch.SubChannelFollow, twitch.SubStreamOffline, twitch.SubStreamOnline)
+	assert.Equal(t, []string{"Cool_User just followed!"}, texts)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,308 @@
+// Package archive keeps every notification a client reads in SQLite, as an
+// audit log and a source for replays without running other infrastructure.
+// Store implements twitch.FrameRecorder and takes a *sql.DB opened with any
+// SQLite driver, like modernc.org/sqlite or github.com/mattn/go-sqlite3:
+//
+//	db, _ := sql.Open("sqlite", "events.db")
+//	store, err := archive.New(ctx, db)
+//	client := twitch.NewClient(twitch.WithFrameRecorder(store))
+//
+// Notifications that could not be decoded are kept too, with the error in
+// DecodeError, so they can be replayed after upgrading the library.
+//
+// Record only queues the frame so the read loop doesn't wait for the
+// database. The frames are written in order by a separate goroutine, failed
+// writes go to OnError, and Flush waits for the queue to be written.
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS notifications (
+	message_id TEXT PRIMARY KEY,
+	message_timestamp INTEGER NOT NULL,
+	received INTEGER NOT NULL,
+	subscription_type TEXT NOT NULL,
+	subscription_version TEXT NOT NULL,
+	broadcaster_user_id TEXT NOT NULL,
+	data TEXT NOT NULL,
+	decode_error TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notifications_type ON notifications (subscription_type, message_timestamp);
+CREATE INDEX IF NOT EXISTS notifications_broadcaster ON notifications (broadcaster_user_id, message_timestamp);`
+
+const columns = "message_id, message_timestamp, received, subscription_type, subscription_version, broadcaster_user_id, data, decode_error"
+
+// Entry is an archived notification. Data is the raw message, which
+// HandleMessage can replay.
+type Entry struct {
+	MessageID         string
+	Timestamp         time.Time
+	Received          time.Time
+	Type              twitch.EventSubscription
+	Version           string
+	BroadcasterUserID string
+	Data              string
+	// DecodeError is empty for notifications that decoded.
+	DecodeError string
+}
+
+// Decode decodes the archived message.
+func (e Entry) Decode() (twitch.NotificationMessage, any, error) {
+	message, event, err := twitch.DecodeMessage([]byte(e.Data))
+	if err != nil {
+		return twitch.NotificationMessage{}, nil, err
+	}
+	notification, _ := message.(twitch.NotificationMessage)
+	return notification, event, nil
+}
+
+// Query selects entries. Empty fields match everything and Until is
+// exclusive. Limit caps the number of entries, which are returned oldest
+// first.
+type Query struct {
+	Type              twitch.EventSubscription
+	BroadcasterUserID string
+	Since             time.Time
+	Until             time.Time
+	Limit             int
+}
+
+// queueSize is how many frames wait for the database before Record blocks.
+const queueSize = 1024
+
+type queuedFrame struct {
+	received time.Time
+	frame    []byte
+}
+
+type Store struct {
+	db      *sql.DB
+	onError func(err error)
+
+	mu      sync.Mutex
+	notFull *sync.Cond
+	queue   []queuedFrame
+	running bool
+	pending sync.WaitGroup
+}
+
+var _ twitch.FrameRecorder = (*Store)(nil)
+
+// New creates the notifications table if it does not exist yet.
+func New(ctx context.Context, db *sql.DB) (*Store, error) {
+	_, err := db.ExecContext(ctx, schema)
+	if err != nil {
+		return nil, fmt.Errorf("could not create archive table: %w", err)
+	}
+	s := &Store{
+		db:      db,
+		onError: func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	}
+	s.notFull = sync.NewCond(&s.mu)
+	return s, nil
+}
+
+func (s *Store) OnError(callback func(err error)) {
+	s.onError = callback
+}
+
+// Record queues a frame to be archived. Notification frames are archived and
+// every other message is ignored. Redelivered notifications are only kept
+// once.
+func (s *Store) Record(received time.Time, frame []byte) error {
+	queued := queuedFrame{received: received, frame: append([]byte(nil), frame...)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.queue) >= queueSize {
+		s.notFull.Wait()
+	}
+	s.queue = append(s.queue, queued)
+	s.pending.Add(1)
+
+	// The writer exits once the queue is empty, so the store needs no closing
+	if !s.running {
+		s.running = true
+		go s.write()
+	}
+	return nil
+}
+
+// Flush waits until every frame recorded so far is written.
+func (s *Store) Flush() {
+	s.pending.Wait()
+}
+
+func (s *Store) write() {
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.running = false
+			s.mu.Unlock()
+			return
+		}
+
+		queued := s.queue[0]
+		s.queue[0] = queuedFrame{}
+		s.queue = s.queue[1:]
+		s.notFull.Signal()
+		s.mu.Unlock()
+
+		err := s.archive(queued.received, queued.frame)
+		if err != nil {
+			s.onError(err)
+		}
+		s.pending.Done()
+	}
+}
+
+func (s *Store) archive(received time.Time, frame []byte) error {
+	var envelope struct {
+		Metadata twitch.MessageMetadata `json:"metadata"`
+		Payload  struct {
+			Subscription twitch.PayloadSubscription `json:"subscription"`
+		} `json:"payload"`
+	}
+	err := json.Unmarshal(frame, &envelope)
+	if err != nil || envelope.Metadata.MessageType != "notification" {
+		return nil
+	}
+
+	entry := Entry{
+		MessageID: envelope.Metadata.MessageID,
+		Timestamp: envelope.Metadata.MessageTimestamp,
+		Received:  received,
+		Type:      envelope.Payload.Subscription.Type,
+		Version:   envelope.Payload.Subscription.Version,
+		Data:      string(frame),
+	}
+
+	message, event, err := twitch.DecodeMessage(frame)
+	if err != nil {
+		entry.DecodeError = err.Error()
+	} else if notification, ok := message.(twitch.NotificationMessage); ok {
+		entry.BroadcasterUserID = twitch.BroadcasterUserID(notification, event)
+	}
+	if entry.BroadcasterUserID == "" {
+		entry.BroadcasterUserID = envelope.Payload.Subscription.Condition["broadcaster_user_id"]
+	}
+
+	return s.Insert(context.Background(), entry)
+}
+
+// Insert archives an entry, ignoring entries whose message ID is archived
+// already.
+func (s *Store) Insert(ctx context.Context, entry Entry) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR IGNORE INTO notifications ("+columns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		entry.MessageID,
+		entry.Timestamp.UnixNano(),
+		entry.Received.UnixNano(),
+		string(entry.Type),
+		entry.Version,
+		entry.BroadcasterUserID,
+		entry.Data,
+		entry.DecodeError,
+	)
+	if err != nil {
+		return fmt.Errorf("could not archive %s: %w", entry.MessageID, err)
+	}
+	return nil
+}
+
+// Find returns the entries matching the query by message timestamp.
+func (s *Store) Find(ctx context.Context, query Query) ([]Entry, error) {
+	var where []string
+	var args []any
+	if query.Type != "" {
+		where = append(where, "subscription_type = ?")
+		args = append(args, string(query.Type))
+	}
+	if query.BroadcasterUserID != "" {
+		where = append(where, "broadcaster_user_id = ?")
+		args = append(args, query.BroadcasterUserID)
+	}
+	if !query.Since.IsZero() {
+		where = append(where, "message_timestamp >= ?")
+		args = append(args, query.Since.UnixNano())
+	}
+	if !query.Until.IsZero() {
+		where = append(where, "message_timestamp < ?")
+		args = append(args, query.Until.UnixNano())
+	}
+
+	statement := "SELECT " + columns + " FROM notifications"
+	if len(where) > 0 {
+		statement += " WHERE " + strings.Join(where, " AND ")
+	}
+	statement += " ORDER BY message_timestamp, received"
+	if query.Limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not query archive: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var entry Entry
+		var subType string
+		var timestamp, received int64
+		err := rows.Scan(&entry.MessageID, &timestamp, &received, &subType, &entry.Version, &entry.BroadcasterUserID, &entry.Data, &entry.DecodeError)
+		if err != nil {
+			return entries, fmt.Errorf("could not read archive entry: %w", err)
+		}
+		entry.Type = twitch.EventSubscription(subType)
+		entry.Timestamp = time.Unix(0, timestamp).UTC()
+		entry.Received = time.Unix(0, received).UTC()
+		entries = append(entries, entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return entries, fmt.Errorf("could not read archive: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *Store) ByType(ctx context.Context, subType twitch.EventSubscription) ([]Entry, error) {
+	return s.Find(ctx, Query{Type: subType})
+}
+
+func (s *Store) ByBroadcaster(ctx context.Context, broadcasterUserID string) ([]Entry, error) {
+	return s.Find(ctx, Query{BroadcasterUserID: broadcasterUserID})
+}
+
+// Between returns the entries from since until before until.
+func (s *Store) Between(ctx context.Context, since, until time.Time) ([]Entry, error) {
+	return s.Find(ctx, Query{Since: since, Until: until})
+}
+
+// Replay hands the entries to the client again and returns the ones it could
+// not handle.
+func Replay(client *twitch.Client, entries []Entry) []Entry {
+	var failed []Entry
+	for _, entry := range entries {
+		err := client.HandleMessage([]byte(entry.Data))
+		if err != nil {
+			entry.DecodeError = err.Error()
+			failed = append(failed, entry)
+		}
+	}
+	return failed
+}
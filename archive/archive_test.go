@@ -0,0 +1,142 @@
+package archive_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/archive"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStore(t *testing.T) *archive.Store {
+	db := openDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	store, err := archive.New(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func notification(t *testing.T, event any, timestamp time.Time) []byte {
+	message, err := twitch.NewNotification(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message.Metadata.MessageTimestamp = timestamp
+	message.Payload.Subscription.Condition = map[string]string{"broadcaster_user_id": twitch.BroadcasterUserID(message, event)}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestStore(t *testing.T) {
+	store := newStore(t)
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	follow := notification(t, twitch.EventChannelFollow{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1"}}, start)
+	online := notification(t, twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "2"}}, start.Add(time.Minute))
+	cheer := notification(t, twitch.EventChannelCheer{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1"}}, start.Add(2*time.Minute))
+
+	for _, frame := range [][]byte{follow, online, cheer, follow} {
+		assert.NoError(t, store.Record(start, frame))
+	}
+	assert.NoError(t, store.Record(start, []byte(`{"metadata":{"message_type":"session_keepalive"}}`)))
+	store.Flush()
+
+	entries, err := store.Find(ctx, archive.Query{})
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, twitch.SubChannelFollow, entries[0].Type)
+		assert.Equal(t, "1", entries[0].BroadcasterUserID)
+		assert.Equal(t, start, entries[0].Timestamp)
+		assert.Empty(t, entries[0].DecodeError)
+
+		_, event, err := entries[0].Decode()
+		assert.NoError(t, err)
+		assert.IsType(t, twitch.EventChannelFollow{}, event)
+	}
+
+	entries, err = store.ByType(ctx, twitch.SubStreamOnline)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	entries, err = store.ByBroadcaster(ctx, "1")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, twitch.SubChannelFollow, entries[0].Type)
+		assert.Equal(t, twitch.SubChannelCheer, entries[1].Type)
+	}
+
+	entries, err = store.Between(ctx, start.Add(time.Minute), start.Add(2*time.Minute))
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, twitch.SubStreamOnline, entries[0].Type)
+	}
+
+	entries, err = store.Find(ctx, archive.Query{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestStoreDecodeError(t *testing.T) {
+	store := newStore(t)
+
+	frame := `{"metadata":{"message_id":"a","message_type":"notification","message_timestamp":"2023-01-01T00:00:00Z"},` +
+		`"payload":{"subscription":{"type":"channel.unknown","version":"1","condition":{"broadcaster_user_id":"1337"}},"event":{}}}`
+	assert.NoError(t, store.Record(time.Now(), []byte(frame)))
+	store.Flush()
+
+	entries, err := store.ByType(context.Background(), "channel.unknown")
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.NotEmpty(t, entries[0].DecodeError)
+		assert.Equal(t, "1337", entries[0].BroadcasterUserID)
+		assert.Equal(t, frame, entries[0].Data)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	store := newStore(t)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Record(start, notification(t, twitch.EventChannelFollow{}, start)))
+	store.Flush()
+
+	entries, err := store.Find(context.Background(), archive.Query{})
+	assert.NoError(t, err)
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var follows int
+	client.OnEventChannelFollow(func(event twitch.EventChannelFollow) { follows++ })
+
+	assert.Empty(t, archive.Replay(client, entries))
+	assert.Equal(t, 1, follows)
+}
+
+func TestStoreWriteError(t *testing.T) {
+	db := openDB(t)
+	store, err := archive.New(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	var errs []error
+	store.OnError(func(err error) { errs = append(errs, err) })
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Record(start, notification(t, twitch.EventChannelFollow{}, start)), "writes happen after Record returns")
+	store.Flush()
+
+	if assert.Len(t, errs, 1) {
+		assert.ErrorContains(t, errs[0], "could not archive")
+	}
+}
@@ -0,0 +1,161 @@
+//go:build !sqlite
+
+package archive_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// openDB opens a memoryDriver database. The sqlite build tag runs the tests
+// against SQLite instead.
+func openDB(t *testing.T) *sql.DB {
+	databasesMu.Lock()
+	databases[t.Name()] = &memoryDriver{rows: map[string][]driver.Value{}}
+	databasesMu.Unlock()
+
+	db, err := sql.Open("archive-memory", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// memoryDriver understands just the statements the store runs, so the tests
+// don't need cgo or a SQLite module.
+type memoryDriver struct {
+	mu   sync.Mutex
+	rows map[string][]driver.Value
+}
+
+var (
+	databases   = map[string]*memoryDriver{}
+	databasesMu sync.Mutex
+)
+
+func init() {
+	sql.Register("archive-memory", driverFunc(func(name string) (driver.Conn, error) {
+		databasesMu.Lock()
+		defer databasesMu.Unlock()
+
+		db, ok := databases[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown database %s", name)
+		}
+		return db, nil
+	}))
+}
+
+type driverFunc func(name string) (driver.Conn, error)
+
+func (f driverFunc) Open(name string) (driver.Conn, error) { return f(name) }
+
+func (d *memoryDriver) Prepare(query string) (driver.Stmt, error) {
+	return &memoryStmt{db: d, query: query}, nil
+}
+func (d *memoryDriver) Close() error              { return nil }
+func (d *memoryDriver) Begin() (driver.Tx, error) { return nil, fmt.Errorf("no transactions") }
+
+type memoryStmt struct {
+	db    *memoryDriver
+	query string
+}
+
+func (s *memoryStmt) Close() error  { return nil }
+func (s *memoryStmt) NumInput() int { return -1 }
+
+func (s *memoryStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if strings.HasPrefix(s.query, "INSERT OR IGNORE") {
+		id := args[0].(string)
+		if _, ok := s.db.rows[id]; !ok {
+			s.db.rows[id] = args
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+var whereColumns = map[string]int{
+	"subscription_type":   3,
+	"broadcaster_user_id": 5,
+	"message_timestamp":   1,
+}
+
+func (s *memoryStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	query := s.query
+	limit := -1
+	if strings.HasSuffix(query, " LIMIT ?") {
+		limit = int(args[len(args)-1].(int64))
+		args = args[:len(args)-1]
+	}
+
+	var clauses []string
+	if i := strings.Index(query, " WHERE "); i >= 0 {
+		where := query[i+len(" WHERE "):]
+		where = where[:strings.Index(where, " ORDER BY")]
+		clauses = strings.Split(where, " AND ")
+	}
+
+	var rows [][]driver.Value
+	for _, row := range s.db.rows {
+		if matches(row, clauses, args) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][1].(int64) < rows[j][1].(int64) })
+	if limit >= 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return &memoryRows{rows: rows}, nil
+}
+
+func matches(row []driver.Value, clauses []string, args []driver.Value) bool {
+	for i, clause := range clauses {
+		fields := strings.Fields(clause)
+		value := row[whereColumns[fields[0]]]
+		switch fields[1] {
+		case "=":
+			if value != args[i] {
+				return false
+			}
+		case ">=":
+			if value.(int64) < args[i].(int64) {
+				return false
+			}
+		case "<":
+			if value.(int64) >= args[i].(int64) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type memoryRows struct {
+	rows [][]driver.Value
+}
+
+func (r *memoryRows) Columns() []string {
+	return []string{"message_id", "message_timestamp", "received", "subscription_type", "subscription_version", "broadcaster_user_id", "data", "decode_error"}
+}
+func (r *memoryRows) Close() error { return nil }
+
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
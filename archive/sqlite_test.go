@@ -0,0 +1,21 @@
+//go:build sqlite
+
+package archive_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openDB opens a SQLite database file, which needs cgo. Run these tests with
+// go test -tags sqlite ./archive.
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "archive.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
@@ -0,0 +1,189 @@
+// Package backfill queries Helix for events that were missed during a
+// twitch.Gap and delivers them through the client's normal handlers:
+//
+//	backfiller := backfill.Backfiller{Client: client}
+//	client.OnGap(func(gap twitch.Gap) {
+//		_, err := backfiller.Backfill(ctx, gap)
+//		...
+//	})
+//
+// Supported are stream.online, channel.follow, and the begin, lock, and end
+// events of polls and predictions. Helix has no timestamps for channel
+// subscriptions, so those cannot be backfilled. Synthesized notifications have
+// message IDs derived from the event, so with a twitch.SessionStore repeated
+// backfills are not delivered twice.
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const helixUrl = "https://api.twitch.tv/helix"
+
+var (
+	ErrUnsupported   = fmt.Errorf("subscription type cannot be backfilled")
+	ErrNoBroadcaster = fmt.Errorf("subscription has no broadcaster_user_id condition")
+)
+
+// Backfiller synthesizes missed events into Client. The credentials default
+// to the ones of the gap's subscription request.
+type Backfiller struct {
+	Client      *twitch.Client
+	ClientID    string
+	AccessToken string
+	TokenSource twitch.TokenSource
+
+	// Url is the Helix base url, which defaults to https://api.twitch.tv/helix
+	Url string
+}
+
+// Backfill delivers the events of the gap's subscription type that happened
+// within the gap and returns how many were delivered.
+func (b Backfiller) Backfill(ctx context.Context, gap twitch.Gap) (int, error) {
+	broadcasterID := gap.Subscription.Condition["broadcaster_user_id"]
+	if broadcasterID == "" {
+		return 0, ErrNoBroadcaster
+	}
+
+	var notifications []notification
+	var err error
+	switch gap.Subscription.Event {
+	case twitch.SubStreamOnline:
+		notifications, err = b.streams(ctx, gap, broadcasterID)
+	case twitch.SubChannelFollow:
+		notifications, err = b.followers(ctx, gap, broadcasterID)
+	case twitch.SubChannelPollBegin, twitch.SubChannelPollEnd:
+		notifications, err = b.polls(ctx, gap, broadcasterID)
+	case twitch.SubChannelPredictionBegin, twitch.SubChannelPredictionLock, twitch.SubChannelPredictionEnd:
+		notifications, err = b.predictions(ctx, gap, broadcasterID)
+	default:
+		return 0, fmt.Errorf("%s: %w", gap.Subscription.Event, ErrUnsupported)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, n := range notifications {
+		if n.Type != gap.Subscription.Event || !within(gap, n.Timestamp) {
+			continue
+		}
+
+		data, err := n.message(gap.Subscription)
+		if err != nil {
+			return count, err
+		}
+
+		err = b.Client.HandleMessage(data)
+		if err != nil {
+			return count, fmt.Errorf("could not handle backfilled %s: %w", n.Type, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func within(gap twitch.Gap, t time.Time) bool {
+	return !t.Before(gap.From) && !t.After(gap.To)
+}
+
+type notification struct {
+	ID        string
+	Type      twitch.EventSubscription
+	Timestamp time.Time
+	Event     any
+}
+
+func (n notification) message(request twitch.SubscribeRequest) ([]byte, error) {
+	event, err := json.Marshal(n.Event)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal backfilled %s: %w", n.Type, err)
+	}
+	raw := json.RawMessage(event)
+
+	var message twitch.NotificationMessage
+	message.Metadata = twitch.MessageMetadata{
+		MessageID:        fmt.Sprintf("backfill-%s-%s", n.Type, n.ID),
+		MessageType:      "notification",
+		MessageTimestamp: n.Timestamp,
+	}
+	message.Payload.Subscription.Type = n.Type
+	message.Payload.Subscription.Condition = request.Condition
+	message.Payload.Subscription.Status = twitch.SubscriptionStatusEnabled
+	message.Payload.Event = &raw
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal backfilled notification: %w", err)
+	}
+	return data, nil
+}
+
+type pagination struct {
+	Cursor string `json:"cursor"`
+}
+
+// get requests a Helix endpoint and unmarshals the response into v.
+func (b Backfiller) get(ctx context.Context, gap twitch.Gap, endpoint string, query url.Values, v any) error {
+	base := b.Url
+	if base == "" {
+		base = helixUrl
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s?%s", strings.TrimSuffix(base, "/"), endpoint, query.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("could not create new request: %w", err)
+	}
+
+	clientID, accessToken, err := b.credentials(gap.Subscription)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Client-Id", clientID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not get %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not get %s: %s: %s", endpoint, resp.Status, string(body))
+	}
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal %s response: %w", endpoint, err)
+	}
+	return nil
+}
+
+func (b Backfiller) credentials(request twitch.SubscribeRequest) (string, string, error) {
+	clientID, accessToken, source := b.ClientID, b.AccessToken, b.TokenSource
+	if clientID == "" {
+		clientID = request.ClientID
+	}
+	if accessToken == "" && source == nil {
+		accessToken, source = request.AccessToken, request.TokenSource
+	}
+
+	if source != nil {
+		token, err := source.Token()
+		if err != nil {
+			return "", "", fmt.Errorf("could not get access token: %w", err)
+		}
+		accessToken = token.AccessToken
+	}
+	return clientID, accessToken, nil
+}
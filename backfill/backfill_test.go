@@ -0,0 +1,163 @@
+package backfill_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/backfill"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	from = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	to   = from.Add(time.Hour)
+)
+
+func newHelix(t *testing.T) *httptest.Server {
+	responses := map[string]any{
+		"/streams": map[string]any{"data": []any{map[string]any{
+			"id": "stream", "user_id": "1337", "user_login": "alice", "user_name": "Alice",
+			"type": "live", "started_at": from.Add(time.Minute),
+		}}},
+		"/channels/followers": map[string]any{"data": []any{
+			map[string]any{"user_id": "2", "user_login": "bob", "followed_at": to.Add(time.Minute)},
+			map[string]any{"user_id": "3", "user_login": "carol", "followed_at": from.Add(2 * time.Minute)},
+			map[string]any{"user_id": "4", "user_login": "dave", "followed_at": from.Add(time.Minute)},
+			map[string]any{"user_id": "5", "user_login": "erin", "followed_at": from.Add(-time.Minute)},
+		}, "pagination": map[string]any{"cursor": "next"}},
+		"/predictions": map[string]any{"data": []any{map[string]any{
+			"id": "rematch", "broadcaster_id": "1337", "title": "Win again?",
+			"status": "RESOLVED", "winning_outcome_id": "no", "prediction_window": 60,
+			"created_at": from.Add(10 * time.Minute), "locked_at": from.Add(11 * time.Minute), "ended_at": from.Add(20 * time.Minute),
+		}}, "pagination": map[string]any{"cursor": "next"}},
+		"/predictions?after=next": map[string]any{"data": []any{
+			map[string]any{
+				"id": "prediction", "broadcaster_id": "1337", "title": "Win?",
+				"status": "RESOLVED", "winning_outcome_id": "yes", "prediction_window": 60,
+				"created_at": from.Add(-time.Minute), "locked_at": from.Add(time.Minute), "ended_at": from.Add(2 * time.Minute),
+			},
+			map[string]any{
+				"id": "warmup", "broadcaster_id": "1337", "title": "Warm up?",
+				"status": "CANCELED", "prediction_window": 60,
+				"created_at": from.Add(-time.Hour), "ended_at": from.Add(-time.Minute),
+			},
+		}, "pagination": map[string]any{"cursor": "more"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "client", r.Header.Get("Client-Id"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Path == "/channels/followers" && r.URL.Query().Get("after") != "" {
+			t.Error("followers older than the gap were paginated")
+		}
+
+		key := r.URL.Path
+		if after := r.URL.Query().Get("after"); after != "" {
+			key += "?after=" + after
+		}
+		response, ok := responses[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newGap(event twitch.EventSubscription) twitch.Gap {
+	return twitch.Gap{
+		Subscription: twitch.SubscribeRequest{
+			Event:       event,
+			Condition:   map[string]string{"broadcaster_user_id": "1337"},
+			ClientID:    "client",
+			AccessToken: "token",
+		},
+		From: from,
+		To:   to,
+	}
+}
+
+func TestBackfillStreamOnline(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var events []twitch.EventStreamOnline
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		events = append(events, event)
+	})
+
+	backfiller := backfill.Backfiller{Client: client, Url: newHelix(t).URL}
+	count, err := backfiller.Backfill(context.Background(), newGap(twitch.SubStreamOnline))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "alice", events[0].BroadcasterUserLogin)
+		assert.Equal(t, twitch.StreamTypeLive, events[0].Type)
+	}
+}
+
+func TestBackfillFollowers(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var logins []string
+	client.OnEventChannelFollow(func(event twitch.EventChannelFollow) {
+		logins = append(logins, event.UserLogin)
+	})
+
+	backfiller := backfill.Backfiller{Client: client, Url: newHelix(t).URL}
+	count, err := backfiller.Backfill(context.Background(), newGap(twitch.SubChannelFollow))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"dave", "carol"}, logins)
+}
+
+func TestBackfillPredictions(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var begun []string
+	client.OnEventChannelPredictionBegin(func(event twitch.EventChannelPredictionBegin) {
+		begun = append(begun, event.ID)
+	})
+	var ended []twitch.EventChannelPredictionEnd
+	client.OnEventChannelPredictionEnd(func(event twitch.EventChannelPredictionEnd) {
+		ended = append(ended, event)
+	})
+
+	backfiller := backfill.Backfiller{Client: client, Url: newHelix(t).URL}
+	count, err := backfiller.Backfill(context.Background(), newGap(twitch.SubChannelPredictionBegin))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count, "first prediction began before the gap")
+	assert.Equal(t, []string{"rematch"}, begun)
+
+	count, err = backfiller.Backfill(context.Background(), newGap(twitch.SubChannelPredictionEnd))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	if assert.Len(t, ended, 2) {
+		assert.Equal(t, twitch.PredictionStatusResolved, ended[0].Status)
+		assert.Equal(t, "yes", ended[0].WinningOutcomeID)
+		assert.Equal(t, "no", ended[1].WinningOutcomeID)
+	}
+}
+
+func TestBackfillErrors(t *testing.T) {
+	t.Parallel()
+
+	backfiller := backfill.Backfiller{Client: twitch.NewClient()}
+
+	_, err := backfiller.Backfill(context.Background(), newGap(twitch.SubChannelSubscribe))
+	assert.ErrorIs(t, err, backfill.ErrUnsupported)
+
+	gap := newGap(twitch.SubStreamOnline)
+	gap.Subscription.Condition = nil
+	_, err = backfiller.Backfill(context.Background(), gap)
+	assert.ErrorIs(t, err, backfill.ErrNoBroadcaster)
+}
@@ -0,0 +1,277 @@
+package backfill
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+func (b Backfiller) streams(ctx context.Context, gap twitch.Gap, broadcasterID string) ([]notification, error) {
+	var response struct {
+		Data []struct {
+			ID        string            `json:"id"`
+			UserID    string            `json:"user_id"`
+			UserLogin string            `json:"user_login"`
+			UserName  string            `json:"user_name"`
+			Type      twitch.StreamType `json:"type"`
+			StartedAt time.Time         `json:"started_at"`
+		} `json:"data"`
+	}
+	err := b.get(ctx, gap, "streams", url.Values{"user_id": {broadcasterID}}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []notification
+	for _, stream := range response.Data {
+		notifications = append(notifications, notification{
+			ID:        stream.ID,
+			Type:      twitch.SubStreamOnline,
+			Timestamp: stream.StartedAt,
+			Event: twitch.EventStreamOnline{
+				Broadcaster: twitch.Broadcaster{
+					BroadcasterUserId:    stream.UserID,
+					BroadcasterUserLogin: stream.UserLogin,
+					BroadcasterUserName:  stream.UserName,
+				},
+				Id:        stream.ID,
+				Type:      stream.Type,
+				StartedAt: stream.StartedAt,
+			},
+		})
+	}
+	return notifications, nil
+}
+
+func (b Backfiller) followers(ctx context.Context, gap twitch.Gap, broadcasterID string) ([]notification, error) {
+	var notifications []notification
+	cursor := ""
+	for {
+		query := url.Values{"broadcaster_id": {broadcasterID}, "first": {"100"}}
+		if cursor != "" {
+			query.Set("after", cursor)
+		}
+
+		var response struct {
+			Data []struct {
+				twitch.User
+				FollowedAt time.Time `json:"followed_at"`
+			} `json:"data"`
+			Pagination pagination `json:"pagination"`
+		}
+		err := b.get(ctx, gap, "channels/followers", query, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		// Followers are sorted newest first
+		older := false
+		for _, follower := range response.Data {
+			if follower.FollowedAt.Before(gap.From) {
+				older = true
+				break
+			}
+
+			notifications = append(notifications, notification{
+				ID:        follower.UserID,
+				Type:      twitch.SubChannelFollow,
+				Timestamp: follower.FollowedAt,
+				Event: twitch.EventChannelFollow{
+					User:        follower.User,
+					Broadcaster: twitch.Broadcaster{BroadcasterUserId: broadcasterID},
+					FollowedAt:  follower.FollowedAt,
+				},
+			})
+		}
+
+		cursor = response.Pagination.Cursor
+		if older || cursor == "" {
+			return reverse(notifications), nil
+		}
+	}
+}
+
+type helixBroadcaster struct {
+	BroadcasterID    string `json:"broadcaster_id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	BroadcasterName  string `json:"broadcaster_name"`
+}
+
+func (h helixBroadcaster) broadcaster() twitch.Broadcaster {
+	return twitch.Broadcaster{
+		BroadcasterUserId:    h.BroadcasterID,
+		BroadcasterUserLogin: h.BroadcasterLogin,
+		BroadcasterUserName:  h.BroadcasterName,
+	}
+}
+
+type helixPoll struct {
+	helixBroadcaster
+	ID                         string              `json:"id"`
+	Title                      string              `json:"title"`
+	Choices                    []twitch.PollChoice `json:"choices"`
+	BitsVotingEnabled          bool                `json:"bits_voting_enabled"`
+	BitsPerVote                int                 `json:"bits_per_vote"`
+	ChannelPointsVotingEnabled bool                `json:"channel_points_voting_enabled"`
+	ChannelPointsPerVote       int                 `json:"channel_points_per_vote"`
+	Status                     string              `json:"status"`
+	Duration                   int                 `json:"duration"`
+	StartedAt                  time.Time           `json:"started_at"`
+	EndedAt                    *time.Time          `json:"ended_at"`
+}
+
+func (b Backfiller) polls(ctx context.Context, gap twitch.Gap, broadcasterID string) ([]notification, error) {
+	polls, err := list(ctx, b, gap, "polls", broadcasterID, 20, func(poll helixPoll) *time.Time { return poll.EndedAt })
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []notification
+	for _, poll := range polls {
+		begin := twitch.EventChannelPollBegin{
+			Broadcaster:         poll.broadcaster(),
+			ID:                  poll.ID,
+			Title:               poll.Title,
+			Choices:             poll.Choices,
+			BitsVoting:          twitch.PollVoting{IsEnabled: poll.BitsVotingEnabled, AmountPerVote: poll.BitsPerVote},
+			ChannelPointsVoting: twitch.PollVoting{IsEnabled: poll.ChannelPointsVotingEnabled, AmountPerVote: poll.ChannelPointsPerVote},
+			StartedAt:           poll.StartedAt,
+			EndsAt:              poll.StartedAt.Add(time.Duration(poll.Duration) * time.Second),
+		}
+		notifications = append(notifications, notification{
+			ID:        poll.ID,
+			Type:      twitch.SubChannelPollBegin,
+			Timestamp: poll.StartedAt,
+			Event:     begin,
+		})
+
+		if poll.EndedAt != nil {
+			notifications = append(notifications, notification{
+				ID:        poll.ID,
+				Type:      twitch.SubChannelPollEnd,
+				Timestamp: *poll.EndedAt,
+				Event: twitch.EventChannelPollEnd{
+					EventChannelPollBegin: begin,
+					Status:                twitch.PollStatus(strings.ToLower(poll.Status)),
+				},
+			})
+		}
+	}
+	return notifications, nil
+}
+
+type helixPrediction struct {
+	helixBroadcaster
+	ID               string                     `json:"id"`
+	Title            string                     `json:"title"`
+	WinningOutcomeID string                     `json:"winning_outcome_id"`
+	Outcomes         []twitch.PredictionOutcome `json:"outcomes"`
+	PredictionWindow int                        `json:"prediction_window"`
+	Status           string                     `json:"status"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	EndedAt          *time.Time                 `json:"ended_at"`
+	LockedAt         *time.Time                 `json:"locked_at"`
+}
+
+func (b Backfiller) predictions(ctx context.Context, gap twitch.Gap, broadcasterID string) ([]notification, error) {
+	predictions, err := list(ctx, b, gap, "predictions", broadcasterID, 25, func(prediction helixPrediction) *time.Time { return prediction.EndedAt })
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []notification
+	for _, prediction := range predictions {
+		begin := twitch.EventChannelPredictionBegin{
+			Broadcaster: prediction.broadcaster(),
+			ID:          prediction.ID,
+			Title:       prediction.Title,
+			Outcomes:    prediction.Outcomes,
+			StartedAt:   prediction.CreatedAt,
+			LocksAt:     prediction.CreatedAt.Add(time.Duration(prediction.PredictionWindow) * time.Second),
+		}
+		notifications = append(notifications, notification{
+			ID:        prediction.ID,
+			Type:      twitch.SubChannelPredictionBegin,
+			Timestamp: prediction.CreatedAt,
+			Event:     begin,
+		})
+
+		if prediction.LockedAt != nil {
+			notifications = append(notifications, notification{
+				ID:        prediction.ID,
+				Type:      twitch.SubChannelPredictionLock,
+				Timestamp: *prediction.LockedAt,
+				Event:     twitch.EventChannelPredictionLock(begin),
+			})
+		}
+
+		if prediction.EndedAt != nil {
+			notifications = append(notifications, notification{
+				ID:        prediction.ID,
+				Type:      twitch.SubChannelPredictionEnd,
+				Timestamp: *prediction.EndedAt,
+				Event: twitch.EventChannelPredictionEnd{
+					Broadcaster:      begin.Broadcaster,
+					ID:               prediction.ID,
+					Title:            prediction.Title,
+					WinningOutcomeID: prediction.WinningOutcomeID,
+					Outcomes:         prediction.Outcomes,
+					Status:           twitch.PredictionStatus(strings.ToLower(prediction.Status)),
+					StartedAt:        prediction.CreatedAt,
+					EndedAt:          *prediction.EndedAt,
+				},
+			})
+		}
+	}
+	return notifications, nil
+}
+
+// list pages through the polls or predictions of a broadcaster, oldest
+// first. A channel runs one of them at a time, so once one ended before the
+// gap the older ones did too and the rest of the pages are skipped.
+func list[T any](ctx context.Context, b Backfiller, gap twitch.Gap, endpoint, broadcasterID string, first int, endedAt func(item T) *time.Time) ([]T, error) {
+	var items []T
+	cursor := ""
+	for {
+		query := url.Values{"broadcaster_id": {broadcasterID}, "first": {strconv.Itoa(first)}}
+		if cursor != "" {
+			query.Set("after", cursor)
+		}
+
+		var response struct {
+			Data       []T        `json:"data"`
+			Pagination pagination `json:"pagination"`
+		}
+		err := b.get(ctx, gap, endpoint, query, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		older := false
+		for _, item := range response.Data {
+			if ended := endedAt(item); ended != nil && ended.Before(gap.From) {
+				older = true
+				break
+			}
+			items = append(items, item)
+		}
+
+		cursor = response.Pagination.Cursor
+		if older || cursor == "" {
+			return reverse(items), nil
+		}
+	}
+}
+
+// reverse returns the items oldest first, as Helix lists them newest first.
+func reverse[T any](items []T) []T {
+	reversed := make([]T, len(items))
+	for i, item := range items {
+		reversed[len(items)-1-i] = item
+	}
+	return reversed
+}
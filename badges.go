@@ -0,0 +1,59 @@
+package twitch
+
+import "strconv"
+
+// Badge is a chat badge of a user. Info holds the subscribed months for
+// subscriber badges and is empty otherwise.
+type Badge struct {
+	SetID string `json:"set_id"`
+	ID    string `json:"id"`
+	Info  string `json:"info"`
+}
+
+type Badges []Badge
+
+// Get returns the badge of the set, or nil if the user does not have one.
+func (b Badges) Get(setID string) *Badge {
+	for i := range b {
+		if b[i].SetID == setID {
+			return &b[i]
+		}
+	}
+	return nil
+}
+
+func (b Badges) Has(setID string) bool {
+	return b.Get(setID) != nil
+}
+
+func (b Badges) IsBroadcaster() bool {
+	return b.Has("broadcaster")
+}
+
+func (b Badges) IsModerator() bool {
+	return b.Has("moderator")
+}
+
+func (b Badges) IsVIP() bool {
+	return b.Has("vip")
+}
+
+// IsSubscriber reports whether the user has a subscriber or founder badge.
+func (b Badges) IsSubscriber() bool {
+	return b.Has("subscriber") || b.Has("founder")
+}
+
+// SubscriberMonths returns the months from the info of the subscriber badge,
+// or 0 when the user has none.
+func (b Badges) SubscriberMonths() int {
+	badge := b.Get("subscriber")
+	if badge == nil {
+		badge = b.Get("founder")
+	}
+	if badge == nil {
+		return 0
+	}
+
+	months, _ := strconv.Atoi(badge.Info)
+	return months
+}
@@ -0,0 +1,30 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBadges(t *testing.T) {
+	t.Parallel()
+
+	var badges twitch.Badges
+	err := json.Unmarshal([]byte(`[{"set_id":"moderator","id":"1","info":""},{"set_id":"subscriber","id":"12","info":"16"}]`), &badges)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, badges.IsModerator())
+	assert.True(t, badges.IsSubscriber())
+	assert.False(t, badges.IsBroadcaster())
+	assert.False(t, badges.IsVIP())
+	assert.Equal(t, 16, badges.SubscriberMonths())
+	assert.Equal(t, &twitch.Badge{SetID: "moderator", ID: "1"}, badges.Get("moderator"))
+	assert.Nil(t, badges.Get("vip"))
+
+	assert.Equal(t, 0, twitch.Badges{{SetID: "broadcaster", ID: "1"}}.SubscriberMonths())
+	assert.True(t, twitch.Badges{{SetID: "broadcaster", ID: "1"}}.IsBroadcaster())
+}
@@ -0,0 +1,149 @@
+// Package bench replays recorded EventSub message streams into a client at a
+// configurable rate. It is used by the benchmarks in this repository and can
+// be used to size dispatch queues and worker pools for real workloads.
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/recording"
+)
+
+// Stream is a recorded sequence of raw websocket messages.
+type Stream [][]byte
+
+// ReadStream reads a stream stored as one json message per line.
+func ReadStream(r io.Reader) (Stream, error) {
+	var stream Stream
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		stream = append(stream, append([]byte(nil), line...))
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not read stream: %w", err)
+	}
+	return stream, nil
+}
+
+// Notification builds a raw notification message for the event payload of
+// the default version of the subscription type with twitch.NewNotification.
+// The payload is kept as it is instead of being encoded again.
+func Notification(eventType twitch.EventSubscription, event json.RawMessage) ([]byte, error) {
+	decoded, err := twitch.DecodeEvent(eventType, "", event)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := twitch.NewNotification(decoded)
+	if err != nil {
+		return nil, err
+	}
+	if message.Payload.Events != nil {
+		message.Payload.Events = &event
+	} else {
+		message.Payload.Event = &event
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal %s notification: %w", eventType, err)
+	}
+	return data, nil
+}
+
+// Result sums up a replay.
+type Result struct {
+	Messages int
+	Errors   int
+	Duration time.Duration
+}
+
+// Rate returns the achieved messages per second.
+func (r Result) Rate() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Messages) / r.Duration.Seconds()
+}
+
+// Replayer feeds streams into a client.
+type Replayer struct {
+	Client *twitch.Client
+	// Rate is the number of messages per second, zero replays as fast as possible
+	Rate float64
+	// Loops is how many times the stream is replayed, defaulting to once
+	Loops int
+	// Clock paces the replay and defaults to the system clock
+	Clock twitch.Clock
+	// OnError is called for every message the client could not handle
+	OnError func(err error)
+}
+
+// Replay sends every message of the stream to the client, pacing them to the
+// configured rate, until the stream ends or the context is done. The
+// messages are replayed as a recording.Replayer recording with one frame
+// every 1/Rate seconds.
+func (r Replayer) Replay(ctx context.Context, stream Stream) (Result, error) {
+	loops := r.Loops
+	if loops < 1 {
+		loops = 1
+	}
+
+	var interval time.Duration
+	speed := 0.0
+	if r.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / r.Rate)
+		speed = 1
+	}
+
+	frames := make([]recording.Frame, 0, len(stream)*loops)
+	var received time.Time
+	for loop := 0; loop < loops; loop++ {
+		for _, data := range stream {
+			frames = append(frames, recording.Frame{Received: received, Data: data})
+			received = received.Add(interval)
+		}
+	}
+
+	var result Result
+	replayer := recording.Replayer{
+		Client: r.Client,
+		Speed:  speed,
+		Clock:  r.Clock,
+		OnError: func(err error) {
+			result.Errors++
+			if r.OnError != nil {
+				r.OnError(err)
+			}
+		},
+	}
+
+	start := r.now()
+	messages, err := replayer.ReplayFrames(ctx, frames)
+	result.Messages = messages
+	result.Duration = r.now().Sub(start)
+	return result, err
+}
+
+func (r Replayer) now() time.Time {
+	if r.Clock != nil {
+		return r.Clock.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,147 @@
+package bench_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/bench"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadEvents(t testing.TB) map[twitch.EventSubscription]json.RawMessage {
+	data, err := os.ReadFile("../testEvents.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events map[twitch.EventSubscription]json.RawMessage
+	err = json.Unmarshal(data, &events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only keep the events of known subscriptions, not the variations
+	for eventType := range events {
+		if eventType == "unknown" || strings.Contains(string(eventType), "-") {
+			delete(events, eventType)
+		}
+	}
+	return events
+}
+
+func notification(t testing.TB, eventType twitch.EventSubscription, event json.RawMessage) []byte {
+	data, err := bench.Notification(eventType, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestReadStream(t *testing.T) {
+	stream, err := bench.ReadStream(strings.NewReader("{\"a\":1}\n\n{\"b\":2}\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, bench.Stream{[]byte(`{"a":1}`), []byte(`{"b":2}`)}, stream)
+}
+
+func TestReplay(t *testing.T) {
+	events := loadEvents(t)
+
+	client := twitch.NewClient()
+	received := make(chan twitch.EventStreamOnline, 4)
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		received <- event
+	})
+
+	stream := bench.Stream{
+		notification(t, twitch.SubStreamOnline, events[twitch.SubStreamOnline]),
+		[]byte(`{}`),
+	}
+
+	result, err := bench.Replayer{Client: client, Rate: 100, Loops: 2}.Replay(context.Background(), stream)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Messages)
+	assert.Equal(t, 2, result.Errors)
+	// Four messages at 100 per second are spread over at least 30ms
+	assert.GreaterOrEqual(t, result.Duration, 30*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-received:
+			assert.Equal(t, "1337", event.BroadcasterUserId)
+		case <-time.After(time.Second):
+			t.Fatal("event was not dispatched")
+		}
+	}
+}
+
+func TestReplayCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := bench.Replayer{Client: twitch.NewClient()}.Replay(ctx, bench.Stream{[]byte(`{}`)})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, result.Messages)
+}
+
+func benchmarkEvents(b *testing.B, newClient func() *twitch.Client) {
+	events := loadEvents(b)
+
+	eventTypes := make([]string, 0, len(events))
+	for eventType := range events {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	sort.Strings(eventTypes)
+
+	for _, eventType := range eventTypes {
+		data := notification(b, twitch.EventSubscription(eventType), events[twitch.EventSubscription(eventType)])
+
+		b.Run(eventType, func(b *testing.B) {
+			client := newClient()
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				err := client.HandleMessage(data)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecode measures reading and decoding notifications with no
+// callbacks registered.
+func BenchmarkDecode(b *testing.B) {
+	benchmarkEvents(b, func() *twitch.Client {
+		return twitch.NewClient()
+	})
+}
+
+// BenchmarkDispatch measures decoding notifications and running a listener
+// for each one through a dispatch queue.
+func BenchmarkDispatch(b *testing.B) {
+	benchmarkEvents(b, func() *twitch.Client {
+		client := twitch.NewClient(twitch.WithDispatchQueue(1024, twitch.BackpressureBlock))
+		client.AddListener(func(message twitch.NotificationMessage, event any) {})
+		return client
+	})
+}
+
+func TestNotificationDefaultVersion(t *testing.T) {
+	events := loadEvents(t)
+
+	data := notification(t, twitch.SubChannelUpdate, events[twitch.SubChannelUpdate])
+	message, event, err := twitch.DecodeMessage(data)
+	assert.NoError(t, err)
+	assert.IsType(t, twitch.EventChannelUpdate{}, event)
+	notification := message.(twitch.NotificationMessage)
+	assert.Equal(t, twitch.SubscriptionVersions(twitch.SubChannelUpdate)[0], notification.Payload.Subscription.Version)
+	assert.JSONEq(t, string(events[twitch.SubChannelUpdate]), string(*notification.Payload.Event))
+}
@@ -0,0 +1,61 @@
+package twitch
+
+import "sync"
+
+// BroadcasterScope registers handlers that only fire for events belonging to
+// a single broadcaster.
+type BroadcasterScope struct {
+	broadcasterID string
+	handlers      map[EventSubscription]func(event any)
+	// handlersMu guards handlers, which are set while events are delivered
+	handlersMu sync.RWMutex
+}
+
+// ForBroadcaster returns a scope whose handlers only receive events where the
+// broadcaster_user_id matches broadcasterID.
+func (c *Client) ForBroadcaster(broadcasterID string) *BroadcasterScope {
+	s := &BroadcasterScope{
+		broadcasterID: broadcasterID,
+		handlers:      map[EventSubscription]func(event any){},
+	}
+	c.AddListener(s.handleEvent)
+	return s
+}
+
+func (s *BroadcasterScope) setHandler(subscription EventSubscription, handler func(event any)) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+
+	s.handlers[subscription] = handler
+}
+
+func (s *BroadcasterScope) handleEvent(message NotificationMessage, event any) {
+	subscription := message.Payload.Subscription
+	s.handlersMu.RLock()
+	handler, ok := s.handlers[subscription.Type]
+	s.handlersMu.RUnlock()
+	if !ok {
+		return
+	}
+
+	if BroadcasterUserID(message, event) != s.broadcasterID {
+		return
+	}
+
+	if metadata, ok := subVersions[subscription.Type][subscription.Version]; ok {
+		event = metadata.Upgrade(event)
+	}
+	handler(event)
+}
+
+// BroadcasterUserID returns the broadcaster a decoded event belongs to, falling
+// back to the subscription condition for events without a broadcaster field.
+func BroadcasterUserID(message NotificationMessage, event any) string {
+	switch event := event.(type) {
+	case interface{ broadcasterUserID() string }:
+		return event.broadcasterUserID()
+	case EventStreamOffline:
+		return event.BroadcasterUserId
+	}
+	return conditionBroadcasterID(message.Payload.Subscription.Condition)
+}
@@ -0,0 +1,63 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+		client.ForBroadcaster("42").OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+			t.Error("handler for another broadcaster fired")
+		})
+		client.ForBroadcaster("1337").OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+			close(ch)
+		})
+	}, twitch.SubStreamOnline)
+}
+
+func TestForBroadcasterVersion(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var updates []twitch.EventChannelUpdate
+	client.ForBroadcaster("1337").OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		updates = append(updates, event)
+	})
+
+	broadcaster := twitch.Broadcaster{BroadcasterUserId: "1337"}
+	data, err := twitch.EncodeNotification(twitch.EventChannelUpdateV1{Broadcaster: broadcaster, Title: "chill", IsMature: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	assert.Equal(t, []twitch.EventChannelUpdate{{Broadcaster: broadcaster, Title: "chill"}}, updates)
+}
+
+func TestForBroadcasterConcurrentHandlers(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	scope := client.ForBroadcaster("1337")
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			scope.OnEventStreamOnline(func(event twitch.EventStreamOnline) {})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, client.HandleMessage(data))
+	}
+	<-done
+}
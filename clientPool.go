@@ -0,0 +1,239 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	maxWebsocketSubscriptions = 300
+	maxWebsocketConnections   = 3
+)
+
+var (
+	ErrPoolFull         = fmt.Errorf("all pooled clients are at their subscription limit")
+	ErrSubscriptionLost = fmt.Errorf("pooled subscription lost")
+	ErrPoolClosed       = fmt.Errorf("client pool is closed")
+)
+
+// ClientPool spreads subscriptions over multiple websocket sessions. A new
+// session is opened when every existing one has reached MaxSubscriptions,
+// and each subscription is created on the least loaded session.
+//
+// When a session is lost, its subscriptions are moved to the other sessions,
+// opening a new one if needed. Subscriptions that could not be moved are
+// reported to the OnError of the lost client as ErrSubscriptionLost.
+type ClientPool struct {
+	Address          string
+	SubscriptionUrl  string
+	MaxSubscriptions int
+	MaxClients       int
+
+	setup   func(client *Client)
+	clients []*Client
+	// pending counts the subscriptions being created on each client
+	pending map[*Client]int
+	closed  bool
+	mu      sync.Mutex
+	// connectMu lets one caller at a time open a client, while the others
+	// wait for it and check again
+	connectMu sync.Mutex
+}
+
+// NewClientPool creates a pool that calls setup on every client it opens, so
+// the same handlers receive events from all sessions.
+func NewClientPool(setup func(client *Client)) *ClientPool {
+	return NewClientPoolWithUrl(twitchWebsocketUrl, setup)
+}
+
+func NewClientPoolWithUrl(url string, setup func(client *Client)) *ClientPool {
+	return &ClientPool{
+		Address:          url,
+		SubscriptionUrl:  twitchEventSubUrl,
+		MaxSubscriptions: maxWebsocketSubscriptions,
+		MaxClients:       maxWebsocketConnections,
+		setup:            setup,
+		pending:          map[*Client]int{},
+	}
+}
+
+func (p *ClientPool) Clients() []*Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clients := make([]*Client, len(p.clients))
+	copy(clients, p.clients)
+	return clients
+}
+
+func (p *ClientPool) Subscribe(request SubscribeRequest) (SubscribeResponse, error) {
+	return p.SubscribeWithContext(context.Background(), request)
+}
+
+func (p *ClientPool) SubscribeWithContext(ctx context.Context, request SubscribeRequest) (SubscribeResponse, error) {
+	client, err := p.reserve(ctx)
+	if err != nil {
+		return SubscribeResponse{}, err
+	}
+	defer p.release(client)
+
+	return client.SubscribeWithContext(ctx, request)
+}
+
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, client := range p.Clients() {
+		err := client.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserve returns the least loaded client with room for a subscription,
+// opening a new one if every client is full, and counts the subscription as
+// pending on it until release. The pool is not locked while connecting.
+func (p *ClientPool) reserve(ctx context.Context) (*Client, error) {
+	for {
+		p.mu.Lock()
+		if client := p.leastLoaded(); client != nil {
+			p.pending[client]++
+			p.mu.Unlock()
+			return client, nil
+		}
+		if len(p.clients) >= p.MaxClients {
+			p.mu.Unlock()
+			return nil, ErrPoolFull
+		}
+		p.mu.Unlock()
+
+		p.connectMu.Lock()
+		p.mu.Lock()
+		full := p.leastLoaded() == nil && len(p.clients) < p.MaxClients
+		p.mu.Unlock()
+		if full {
+			client, err := p.connect(ctx)
+			if err != nil {
+				p.connectMu.Unlock()
+				return nil, err
+			}
+
+			p.mu.Lock()
+			p.clients = append(p.clients, client)
+			p.mu.Unlock()
+		}
+		p.connectMu.Unlock()
+	}
+}
+
+func (p *ClientPool) release(client *Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending[client]--
+	if p.pending[client] <= 0 {
+		delete(p.pending, client)
+	}
+}
+
+func (p *ClientPool) leastLoaded() *Client {
+	var (
+		client *Client
+		load   int
+	)
+	for _, c := range p.clients {
+		count := c.subscriptionCount() + p.pending[c]
+		if count < p.MaxSubscriptions && (client == nil || count < load) {
+			client, load = c, count
+		}
+	}
+	return client
+}
+
+func (p *ClientPool) connect(ctx context.Context) (*Client, error) {
+	client := NewClientWithUrl(p.Address)
+	client.SubscriptionUrl = p.SubscriptionUrl
+	if p.setup != nil {
+		p.setup(client)
+	}
+
+	welcomed := make(chan struct{})
+	var once sync.Once
+	onWelcome := client.onWelcome
+	client.OnWelcome(func(message WelcomeMessage) {
+		once.Do(func() { close(welcomed) })
+		if onWelcome != nil {
+			onWelcome(message)
+		}
+	})
+
+	connectCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		defer cancel()
+		err := client.ConnectWithContext(connectCtx)
+		lost := err != nil && !errors.Is(err, ErrClosedByUser) && !errors.Is(err, context.Canceled)
+		if lost {
+			client.onError(fmt.Errorf("pooled client stopped: %w", err))
+		}
+		done <- err
+		p.remove(client)
+		if lost {
+			p.moveSubscriptions(client)
+		}
+	}()
+
+	select {
+	case <-welcomed:
+		return client, nil
+	case err := <-done:
+		if err == nil {
+			err = ErrConnClosed
+		}
+		return nil, fmt.Errorf("could not connect pooled client: %w", err)
+	case <-ctx.Done():
+		cancel()
+		return nil, ctx.Err()
+	}
+}
+
+func (p *ClientPool) remove(client *Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, c := range p.clients {
+		if c == client {
+			p.clients = append(p.clients[:i], p.clients[i+1:]...)
+			return
+		}
+	}
+}
+
+// moveSubscriptions creates the subscriptions of a client that lost its
+// connection on the other clients.
+func (p *ClientPool) moveSubscriptions(client *Client) {
+	client.subscriptionsMu.Lock()
+	subscriptions := make([]SubscribeRequest, len(client.subscriptions))
+	copy(subscriptions, client.subscriptions)
+	client.subscriptionsMu.Unlock()
+
+	for _, request := range subscriptions {
+		p.mu.Lock()
+		closed := p.closed
+		p.mu.Unlock()
+
+		err := ErrPoolClosed
+		if !closed {
+			_, err = p.Subscribe(request)
+		}
+		if err != nil {
+			client.onError(fmt.Errorf("%w: %s %v: %v", ErrSubscriptionLost, request.Event, request.Condition, err))
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+)
+
+func TestClientPool(t *testing.T) {
+	t.Parallel()
+
+	server, err := newTestServer(func() ([][]byte, bool, error) {
+		return nil, true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var setupCount int
+	pool := twitch.NewClientPoolWithUrl(fmt.Sprintf("http://%s/ws", server.Address), func(client *twitch.Client) {
+		setupCount++
+		client.OnError(func(err error) {
+			t.Errorf("client registered an error: %v", err)
+		})
+	})
+	pool.SubscriptionUrl = fmt.Sprintf("http://%s/subscriptions", server.Address)
+	pool.MaxSubscriptions = 1
+	pool.MaxClients = 2
+	defer pool.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = pool.Subscribe(twitch.SubscribeRequest{Event: twitch.SubStreamOnline, Condition: testCondition})
+		assert.NoError(t, err)
+	}
+
+	_, err = pool.Subscribe(twitch.SubscribeRequest{Event: twitch.SubStreamOnline, Condition: testCondition})
+	assert.ErrorIs(t, err, twitch.ErrPoolFull)
+	assert.Len(t, pool.Clients(), 2)
+	assert.Equal(t, 2, setupCount)
+}
+
+func TestClientPoolMovesLostSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		connections   atomic.Int32
+		subscriptions atomic.Int32
+	)
+	drop := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		welcome := twitch.WelcomeMessage{Metadata: newMetadata("session_welcome")}
+		welcome.Payload.Session.ID = fmt.Sprintf("session%d", connections.Add(1))
+		data, _ := json.Marshal(welcome)
+		conn.Write(r.Context(), websocket.MessageText, data)
+
+		if welcome.Payload.Session.ID == "session1" {
+			<-drop
+			conn.Close(websocket.StatusInternalError, "dropped")
+			return
+		}
+		conn.Read(r.Context())
+	})
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		subscriptions.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("{}"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	errs := make(chan error, 10)
+	pool := twitch.NewClientPoolWithUrl(server.URL+"/ws", func(client *twitch.Client) {
+		client.OnError(func(err error) { errs <- err })
+	})
+	pool.SubscriptionUrl = server.URL + "/subscriptions"
+	defer pool.Close()
+
+	_, err := pool.Subscribe(twitch.SubscribeRequest{Event: twitch.SubStreamOnline, Condition: testCondition})
+	assert.NoError(t, err)
+	first := pool.Clients()[0]
+
+	close(drop)
+
+	assert.Eventually(t, func() bool {
+		return subscriptions.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	clients := pool.Clients()
+	if assert.Len(t, clients, 1) {
+		assert.NotSame(t, first, clients[0])
+		assert.Equal(t, "session2", clients[0].SessionID())
+	}
+
+	close(errs)
+	for err := range errs {
+		assert.NotErrorIs(t, err, twitch.ErrSubscriptionLost)
+	}
+}
+
+func TestClientPoolReportsLostSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	drop := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		if connections.Add(1) > 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		welcome := twitch.WelcomeMessage{Metadata: newMetadata("session_welcome")}
+		data, _ := json.Marshal(welcome)
+		conn.Write(r.Context(), websocket.MessageText, data)
+
+		<-drop
+		conn.Close(websocket.StatusInternalError, "dropped")
+	})
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("{}"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	lost := make(chan error, 1)
+	pool := twitch.NewClientPoolWithUrl(server.URL+"/ws", func(client *twitch.Client) {
+		client.OnError(func(err error) {
+			if errors.Is(err, twitch.ErrSubscriptionLost) {
+				lost <- err
+			}
+		})
+	})
+	pool.SubscriptionUrl = server.URL + "/subscriptions"
+	pool.MaxClients = 1
+	defer pool.Close()
+
+	_, err := pool.Subscribe(twitch.SubscribeRequest{Event: twitch.SubStreamOnline, Condition: testCondition})
+	assert.NoError(t, err)
+
+	// The server refuses every later session, so the subscription has
+	// nowhere to go
+	close(drop)
+
+	select {
+	case err := <-lost:
+		assert.ErrorIs(t, err, twitch.ErrSubscriptionLost)
+	case <-time.After(time.Second):
+		t.Fatal("lost subscription was not reported")
+	}
+}
@@ -0,0 +1,106 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for keepalive checks, timeouts, and backoff.
+// The clocktest package has a fake clock that tests can advance by hand.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// clockOf returns the clock of a *Client and the system clock for other
+// clients.
+func clockOf(client EventSubClient) Clock {
+	if c, ok := client.(*Client); ok && c.clock != nil {
+		return c.clock
+	}
+	return realClock{}
+}
+
+// afterFunc is time.AfterFunc driven by the clock. It returns a function
+// that stops the timer.
+func afterFunc(clock Clock, d time.Duration, f func()) func() {
+	timer := clock.NewTimer(d)
+	stopped := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		select {
+		case <-timer.C():
+			f()
+		case <-stopped:
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			timer.Stop()
+			close(stopped)
+		})
+	}
+}
+
+// withClockTimeout is context.WithTimeout driven by the clock.
+func withClockTimeout(ctx context.Context, clock Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	timer := clock.NewTimer(timeout)
+
+	go func() {
+		select {
+		case <-timer.C():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		timer.Stop()
+		cancel()
+	}
+}
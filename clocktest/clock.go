@@ -0,0 +1,160 @@
+// Package clocktest has a fake twitch.Clock for tests. Time only moves when
+// Advance is called, so keepalive checks, timeouts, and backoff can be
+// driven deterministically instead of sleeping.
+//
+//	clock := clocktest.NewClock(time.Now())
+//	client := twitch.NewClient(twitch.WithClock(clock))
+//	...
+//	clock.BlockUntil(1)
+//	clock.Advance(time.Minute)
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+var _ twitch.Clock = (*Clock)(nil)
+
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward and fires every timer and ticker that
+// became due. Like the time package, a ticker that fell behind only fires once.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.when.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+
+		w.fire(c.now)
+		if w.period > 0 {
+			for !w.when.After(c.now) {
+				w.when = w.when.Add(w.period)
+			}
+			waiters = append(waiters, w)
+		}
+	}
+	c.waiters = waiters
+	c.cond.Broadcast()
+}
+
+// Waiters returns the number of timers and tickers that have not fired or
+// been stopped yet.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n timers and tickers are waiting, so a test
+// knows the code under test is ready before it advances the clock.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *Clock) NewTimer(d time.Duration) twitch.Timer {
+	return c.add(d, 0)
+}
+
+func (c *Clock) NewTicker(d time.Duration) twitch.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	return ticker{c.add(d, d)}
+}
+
+func (c *Clock) add(d, period time.Duration) *waiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &waiter{
+		clock:  c,
+		when:   c.now.Add(d),
+		period: period,
+		ch:     make(chan time.Time, 1),
+	}
+
+	if d <= 0 && period == 0 {
+		w.fire(c.now)
+		return w
+	}
+
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+	return w
+}
+
+func (c *Clock) remove(w *waiter) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, waiting := range c.waiters {
+		if waiting == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// waiter is the fake Timer and, wrapped in ticker, the fake Ticker.
+type waiter struct {
+	clock  *Clock
+	when   time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+func (w *waiter) fire(now time.Time) {
+	select {
+	case w.ch <- now:
+	default:
+	}
+}
+
+func (w *waiter) C() <-chan time.Time {
+	return w.ch
+}
+
+func (w *waiter) Stop() bool {
+	return w.clock.remove(w)
+}
+
+type ticker struct {
+	*waiter
+}
+
+func (t ticker) Stop() {
+	t.waiter.Stop()
+}
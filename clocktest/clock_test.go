@@ -0,0 +1,89 @@
+package clocktest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fired(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestTimer(t *testing.T) {
+	clock := clocktest.NewClock(start)
+
+	timer := clock.NewTimer(time.Second)
+	assert.Equal(t, 1, clock.Waiters())
+
+	clock.Advance(999 * time.Millisecond)
+	assert.False(t, fired(timer.C()))
+
+	clock.Advance(time.Millisecond)
+	assert.True(t, fired(timer.C()))
+	assert.Equal(t, start.Add(time.Second), clock.Now())
+	assert.Equal(t, 0, clock.Waiters())
+	assert.False(t, timer.Stop())
+}
+
+func TestTimerStop(t *testing.T) {
+	clock := clocktest.NewClock(start)
+
+	timer := clock.NewTimer(time.Second)
+	assert.True(t, timer.Stop())
+
+	clock.Advance(time.Minute)
+	assert.False(t, fired(timer.C()))
+}
+
+func TestTimerZero(t *testing.T) {
+	clock := clocktest.NewClock(start)
+	assert.True(t, fired(clock.NewTimer(0).C()))
+}
+
+func TestTicker(t *testing.T) {
+	clock := clocktest.NewClock(start)
+
+	ticker := clock.NewTicker(time.Second)
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		assert.True(t, fired(ticker.C()))
+	}
+
+	// Falling behind only fires once
+	clock.Advance(5 * time.Second)
+	assert.True(t, fired(ticker.C()))
+	assert.False(t, fired(ticker.C()))
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	assert.False(t, fired(ticker.C()))
+}
+
+func TestBlockUntil(t *testing.T) {
+	clock := clocktest.NewClock(start)
+
+	done := make(chan struct{})
+	go func() {
+		clock.BlockUntil(2)
+		close(done)
+	}()
+
+	clock.NewTimer(time.Second)
+	clock.NewTicker(time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("BlockUntil did not return")
+	}
+}
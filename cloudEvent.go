@@ -0,0 +1,56 @@
+package twitch
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	cloudEventSource      = "twitch:eventsub"
+)
+
+// CloudEvent is a CloudEvents 1.0 envelope around a decoded event, using the
+// JSON event format.
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	DataContentType string    `json:"datacontenttype"`
+	Time            time.Time `json:"time"`
+	Data            any       `json:"data"`
+
+	// Extension attributes
+	SubscriptionID      string `json:"twitchsubscriptionid,omitempty"`
+	SubscriptionVersion string `json:"twitchsubscriptionversion,omitempty"`
+}
+
+// NewCloudEvent wraps event in a CloudEvent. The type is the subscription
+// type, the source identifies the broadcaster as twitch:eventsub:<id>, and the
+// id and time come from the message metadata.
+func NewCloudEvent(message NotificationMessage, event any) CloudEvent {
+	source := cloudEventSource
+	if broadcasterID := BroadcasterUserID(message, event); broadcasterID != "" {
+		source += ":" + broadcasterID
+	}
+
+	subscription := message.Payload.Subscription
+	return CloudEvent{
+		SpecVersion:         cloudEventSpecVersion,
+		ID:                  message.Metadata.MessageID,
+		Source:              source,
+		Type:                string(subscription.Type),
+		DataContentType:     "application/json",
+		Time:                message.Metadata.MessageTimestamp,
+		Data:                event,
+		SubscriptionID:      subscription.ID,
+		SubscriptionVersion: subscription.Version,
+	}
+}
+
+// CloudEventPayload can be used as a PublisherBridge payload to publish
+// CloudEvents instead of raw notifications.
+func CloudEventPayload(message NotificationMessage, event any) ([]byte, error) {
+	return json.Marshal(NewCloudEvent(message, event))
+}
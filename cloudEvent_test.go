@@ -0,0 +1,42 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudEvent(t *testing.T) {
+	message := twitch.NotificationMessage{Metadata: newMetadata("notification")}
+	message.Payload.Subscription.Type = twitch.SubStreamOnline
+	message.Payload.Subscription.Version = "1"
+
+	event := twitch.EventStreamOnline{
+		Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"},
+		Type:        "live",
+	}
+
+	payload, err := twitch.CloudEventPayload(message, event)
+	assert.NoError(t, err)
+
+	var cloudEvent struct {
+		SpecVersion string                   `json:"specversion"`
+		ID          string                   `json:"id"`
+		Source      string                   `json:"source"`
+		Type        string                   `json:"type"`
+		Time        time.Time                `json:"time"`
+		Data        twitch.EventStreamOnline `json:"data"`
+	}
+	err = json.Unmarshal(payload, &cloudEvent)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0", cloudEvent.SpecVersion)
+	assert.Equal(t, message.Metadata.MessageID, cloudEvent.ID)
+	assert.Equal(t, "twitch:eventsub:1337", cloudEvent.Source)
+	assert.Equal(t, "stream.online", cloudEvent.Type)
+	assert.True(t, message.Metadata.MessageTimestamp.Equal(cloudEvent.Time))
+	assert.Equal(t, event, cloudEvent.Data)
+}
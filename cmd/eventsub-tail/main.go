@@ -0,0 +1,155 @@
+// Command eventsub-tail subscribes to EventSub events of a broadcaster and
+// prints them as they arrive.
+//
+//	eventsub-tail -client-id <id> -token <user token> -broadcaster <login> -events stream.online,channel.follow
+//
+// The client ID and token can also be set with TWITCH_CLIENT_ID and
+// TWITCH_TOKEN. Events are printed as one line summaries, or as JSON lines
+// with -format json.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const twitchUsersUrl = "https://api.twitch.tv/helix/users"
+
+func main() {
+	clientID := flag.String("client-id", os.Getenv("TWITCH_CLIENT_ID"), "twitch application client ID")
+	token := flag.String("token", os.Getenv("TWITCH_TOKEN"), "user access token")
+	broadcaster := flag.String("broadcaster", "", "login of the broadcaster to tail")
+	events := flag.String("events", "stream.online,stream.offline,channel.update", "comma separated subscription types, or all")
+	format := flag.String("format", "text", "output format, text or json")
+	flag.Parse()
+
+	if *clientID == "" || *token == "" || *broadcaster == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, *clientID, *token, *broadcaster, parseEvents(*events), *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
+}
+
+func parseEvents(events string) []twitch.EventSubscription {
+	if events == "all" {
+		return twitch.SubscriptionTypes()
+	}
+
+	var subTypes []twitch.EventSubscription
+	for _, event := range strings.Split(events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			subTypes = append(subTypes, twitch.EventSubscription(event))
+		}
+	}
+	return subTypes
+}
+
+func run(ctx context.Context, clientID, token, login string, events []twitch.EventSubscription, format string) error {
+	info, err := twitch.ValidateScopes(ctx, token, events)
+	if err != nil {
+		return err
+	}
+
+	broadcasterID, err := lookupUser(ctx, clientID, token, login)
+	if err != nil {
+		return err
+	}
+
+	condition := twitch.Condition{
+		BroadcasterUserID:   broadcasterID,
+		ModeratorUserID:     info.UserID,
+		UserID:              broadcasterID,
+		ToBroadcasterUserID: broadcasterID,
+		ClientID:            clientID,
+	}.Map()
+
+	client := twitch.NewClient()
+	client.OnError(func(err error) {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		for _, event := range events {
+			_, err := client.Subscribe(twitch.SubscribeRequest{
+				ClientID:    clientID,
+				AccessToken: token,
+				Event:       event,
+				Condition:   condition,
+			})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "ERROR:", err)
+			}
+		}
+	})
+
+	switch format {
+	case "text":
+		client.AddListener(func(message twitch.NotificationMessage, event any) {
+			fmt.Printf("%s %v\n", message.Metadata.MessageTimestamp.Format("15:04:05"), event)
+		})
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		client.OnRawEvent(func(event string, metadata twitch.MessageMetadata, subscription twitch.PayloadSubscription) {
+			encoder.Encode(struct {
+				Type      twitch.EventSubscription `json:"type"`
+				Timestamp string                   `json:"timestamp"`
+				Event     json.RawMessage          `json:"event"`
+			}{subscription.Type, metadata.MessageTimestamp.Format("2006-01-02T15:04:05.000Z07:00"), json.RawMessage(event)})
+		})
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	return client.Run(ctx)
+}
+
+func lookupUser(ctx context.Context, clientID, token, login string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, twitchUsersUrl+"?login="+url.QueryEscape(login), nil)
+	if err != nil {
+		return "", fmt.Errorf("could not create new request: %w", err)
+	}
+	req.Header.Set("Client-Id", clientID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not look up %s: %w", login, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not look up %s: %s: %s", login, resp.Status, string(body))
+	}
+
+	var users struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	err = json.Unmarshal(body, &users)
+	if err != nil {
+		return "", fmt.Errorf("could not unmarshal users response: %w", err)
+	}
+	if len(users.Data) == 0 {
+		return "", fmt.Errorf("no user with login %s", login)
+	}
+	return users.Data[0].ID, nil
+}
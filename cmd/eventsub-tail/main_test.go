@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEvents(t *testing.T) {
+	assert.Equal(t, []twitch.EventSubscription{twitch.SubStreamOnline, twitch.SubChannelFollow}, parseEvents("stream.online, channel.follow,"))
+	assert.Equal(t, twitch.SubscriptionTypes(), parseEvents("all"))
+}
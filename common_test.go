@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,6 +27,10 @@ var testEvents []byte
 type messageDataGenerator func() ([][]byte, bool, error)
 
 func getTestEventData(eventType twitch.EventSubscription, suffixes ...string) messageDataGenerator {
+	return getTestEventDataWithCondition(eventType, map[string]string{}, suffixes...)
+}
+
+func getTestEventDataWithCondition(eventType twitch.EventSubscription, condition map[string]string, suffixes ...string) messageDataGenerator {
 	return func() ([][]byte, bool, error) {
 		var events map[string]json.RawMessage
 		if err := json.Unmarshal(testEvents, &events); err != nil {
@@ -37,35 +43,31 @@ func getTestEventData(eventType twitch.EventSubscription, suffixes ...string) me
 			return nil, false, fmt.Errorf("could not find %s in testEvents", key)
 		}
 
-		data, err := json.Marshal(twitch.NotificationMessage{
-			Metadata: newMetadata("notification"),
-			Payload: struct {
-				Subscription twitch.PayloadSubscription "json:\"subscription\""
-				Event        *json.RawMessage           "json:\"event\""
-			}{
-				Event: &eventData,
-				Subscription: twitch.PayloadSubscription{
-					SubscriptionRequest: twitch.SubscriptionRequest{
-						Type:      eventType,
-						Version:   "1",
-						Condition: map[string]string{},
-						Transport: twitch.SubscriptionTransport{
-							Method:    "websocket",
-							SessionID: "",
-						},
-					},
-					Status:   "enabled",
-					Cost:     1,
-					CreateAt: time.Now(),
+		message := twitch.NotificationMessage{Metadata: newMetadata("notification")}
+		message.Payload.Event = &eventData
+		message.Payload.Subscription = twitch.PayloadSubscription{
+			SubscriptionRequest: twitch.SubscriptionRequest{
+				Type:      eventType,
+				Version:   "1",
+				Condition: condition,
+				Transport: twitch.SubscriptionTransport{
+					Method:    "websocket",
+					SessionID: "",
 				},
 			},
-		})
+			Status:   "enabled",
+			Cost:     1,
+			CreateAt: time.Now(),
+		}
+
+		data, err := json.Marshal(message)
 		return [][]byte{data}, true, err
 	}
 }
 
 type TestServer struct {
 	Address            string
+	mu                 *sync.Mutex
 	conn               *websocket.Conn
 	sendInSubscription bool
 	data               [][]byte
@@ -90,6 +92,7 @@ func newTestServer(gen messageDataGenerator) (TestServer, error) {
 
 	server := TestServer{
 		Address:            listener.Addr().String(),
+		mu:                 &sync.Mutex{},
 		sendInSubscription: sendInSubscription,
 		data:               data,
 	}
@@ -105,25 +108,29 @@ func newTestServer(gen messageDataGenerator) (TestServer, error) {
 }
 
 func (s *TestServer) handleWebsocket(w http.ResponseWriter, r *http.Request) {
-	var err error
-	s.conn, err = websocket.Accept(w, r, nil)
+	conn, err := websocket.Accept(w, r, nil)
 	if err != nil {
 		panic(err)
 	}
 
-	err = s.sendWelcome(r.Context())
+	// Subscriptions are answered on the latest connection
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	err = s.sendWelcome(r.Context(), conn)
 	if err != nil {
 		panic(err)
 	}
 
 	if !s.sendInSubscription {
 		for _, data := range s.data {
-			s.conn.Write(r.Context(), websocket.MessageText, data)
+			conn.Write(r.Context(), websocket.MessageText, data)
 		}
 	}
 
 	// Read so it can close
-	s.conn.Read(r.Context())
+	conn.Read(r.Context())
 }
 
 func (s *TestServer) handleSubscription(w http.ResponseWriter, r *http.Request) {
@@ -143,15 +150,19 @@ func (s *TestServer) handleSubscription(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusAccepted)
 	w.Write(response)
 
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+
 	for _, data := range s.data {
-		err = s.conn.Write(r.Context(), websocket.MessageText, data)
+		err = conn.Write(r.Context(), websocket.MessageText, data)
 		if err != nil {
 			panic(err)
 		}
 	}
 }
 
-func (s *TestServer) sendWelcome(ctx context.Context) error {
+func (s *TestServer) sendWelcome(ctx context.Context, conn *websocket.Conn) error {
 	welcome := twitch.WelcomeMessage{
 		Metadata: newMetadata("session_welcome"),
 		Payload: struct {
@@ -172,7 +183,7 @@ func (s *TestServer) sendWelcome(ctx context.Context) error {
 		return fmt.Errorf("could not marshal welcome message: %w", err)
 	}
 
-	return s.conn.Write(ctx, websocket.MessageText, data)
+	return conn.Write(ctx, websocket.MessageText, data)
 }
 
 func newMetadata(msgType string) twitch.MessageMetadata {
@@ -194,10 +205,23 @@ func newClient(t *testing.T, gen messageDataGenerator) *twitch.Client {
 		t.Fatalf("client registered an error: %v", err)
 	})
 	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	// The test events are version 1, which was removed for some types
+	client.OnDeprecation(func(deprecation twitch.Deprecation) {})
 
 	return client
 }
 
+// testCondition has every condition key so it is valid for any subscription.
+var testCondition = twitch.Condition{
+	BroadcasterUserID:     "1337",
+	ModeratorUserID:       "1337",
+	UserID:                "1337",
+	FromBroadcasterUserID: "1337",
+	ClientID:              "1337",
+	ExtensionClientID:     "1337",
+	OrganizationID:        "1337",
+}.Map()
+
 func newClientWithWelcome(t *testing.T, version string, event twitch.EventSubscription, gen messageDataGenerator) *twitch.Client {
 	client := newClient(t, gen)
 
@@ -208,7 +232,7 @@ func newClientWithWelcome(t *testing.T, version string, event twitch.EventSubscr
 			AccessToken:     "",
 			VersionOverride: version,
 			Event:           event,
-			Condition:       map[string]string{},
+			Condition:       testCondition,
 		}, strings.ReplaceAll(client.Address, "/ws", "/subscriptions"))
 		if err != nil {
 			t.Errorf("could not subscribe: %v", err)
@@ -219,7 +243,7 @@ func newClientWithWelcome(t *testing.T, version string, event twitch.EventSubscr
 
 func connect(t *testing.T, client *twitch.Client) {
 	err := client.Connect()
-	if err != nil {
+	if err != nil && !errors.Is(err, twitch.ErrClosedByUser) {
 		t.Errorf("could not connect client: %v", err)
 	}
 }
@@ -0,0 +1,72 @@
+package twitch
+
+import (
+	"fmt"
+	"strings"
+)
+
+var ErrMissingCondition = fmt.Errorf("missing condition")
+
+// Condition holds the condition keys used by subscription types. Map turns it
+// into the map of SubscribeRequest.Condition, leaving out empty keys.
+type Condition struct {
+	BroadcasterUserID     string
+	ModeratorUserID       string
+	UserID                string
+	RewardID              string
+	FromBroadcasterUserID string
+	ToBroadcasterUserID   string
+	ClientID              string
+	ExtensionClientID     string
+	OrganizationID        string
+	CategoryID            string
+	CampaignID            string
+}
+
+func (c Condition) Map() map[string]string {
+	condition := map[string]string{}
+	for key, value := range map[string]string{
+		"broadcaster_user_id":      c.BroadcasterUserID,
+		"moderator_user_id":        c.ModeratorUserID,
+		"user_id":                  c.UserID,
+		"reward_id":                c.RewardID,
+		"from_broadcaster_user_id": c.FromBroadcasterUserID,
+		"to_broadcaster_user_id":   c.ToBroadcasterUserID,
+		"client_id":                c.ClientID,
+		"extension_client_id":      c.ExtensionClientID,
+		"organization_id":          c.OrganizationID,
+		"category_id":              c.CategoryID,
+		"campaign_id":              c.CampaignID,
+	} {
+		if value != "" {
+			condition[key] = value
+		}
+	}
+	return condition
+}
+
+// Validate checks that the condition has every key the subscription type
+// requires.
+func (c Condition) Validate(event EventSubscription) error {
+	return ValidateCondition(event, c.Map())
+}
+
+// ValidateCondition checks that condition has every key the default version
+// of the subscription type requires. Unknown types are not checked.
+func ValidateCondition(event EventSubscription, condition map[string]string) error {
+	for _, required := range subMetadata[event].Condition {
+		if !hasAnyKey(condition, strings.Split(required, "|")) {
+			return fmt.Errorf("%s needs %s: %w", event, strings.ReplaceAll(required, "|", " or "), ErrMissingCondition)
+		}
+	}
+	return nil
+}
+
+func hasAnyKey(condition map[string]string, keys []string) bool {
+	for _, key := range keys {
+		if condition[key] != "" {
+			return true
+		}
+	}
+	return false
+}
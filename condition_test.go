@@ -0,0 +1,58 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionMap(t *testing.T) {
+	t.Parallel()
+
+	condition := twitch.Condition{BroadcasterUserID: "1337", RewardID: "abc"}.Map()
+	assert.Equal(t, map[string]string{"broadcaster_user_id": "1337", "reward_id": "abc"}, condition)
+}
+
+func TestValidateCondition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name      string
+		Event     twitch.EventSubscription
+		Condition twitch.Condition
+		Valid     bool
+	}{
+		{"Broadcaster", twitch.SubStreamOnline, twitch.Condition{BroadcasterUserID: "1337"}, true},
+		{"MissingBroadcaster", twitch.SubStreamOnline, twitch.Condition{UserID: "1337"}, false},
+		{"MissingModerator", twitch.SubChannelFollow, twitch.Condition{BroadcasterUserID: "1337"}, false},
+		{"Moderator", twitch.SubChannelFollow, twitch.Condition{BroadcasterUserID: "1337", ModeratorUserID: "1337"}, true},
+		{"RaidFrom", twitch.SubChannelRaid, twitch.Condition{FromBroadcasterUserID: "1337"}, true},
+		{"RaidTo", twitch.SubChannelRaid, twitch.Condition{ToBroadcasterUserID: "1337"}, true},
+		{"RaidMissing", twitch.SubChannelRaid, twitch.Condition{BroadcasterUserID: "1337"}, false},
+		{"ExtensionClient", twitch.SubExtensionBitsTransactionCreate, twitch.Condition{ExtensionClientID: "deadbeef"}, true},
+		{"MissingExtensionClient", twitch.SubExtensionBitsTransactionCreate, twitch.Condition{ClientID: "deadbeef"}, false},
+		{"Unknown", twitch.EventSubscription("unknown"), twitch.Condition{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Condition.Validate(tc.Event)
+			if tc.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, twitch.ErrMissingCondition)
+			}
+		})
+	}
+}
+
+func TestSubscribeInvalidCondition(t *testing.T) {
+	t.Parallel()
+
+	_, err := twitch.SubscribeEventUrl(twitch.SubscribeRequest{
+		Event:     twitch.SubChannelFollow,
+		Condition: twitch.Condition{BroadcasterUserID: "1337"}.Map(),
+	}, "http://127.0.0.1:0")
+	assert.ErrorIs(t, err, twitch.ErrMissingCondition)
+}
@@ -0,0 +1,194 @@
+//go:build conformance
+
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// untriggerable are the subscription types the Twitch CLI cannot trigger over
+// the websocket transport, with the reason. Every other type has to arrive.
+var untriggerable = map[EventSubscription]string{
+	SubDropEntitlementGrant:           "webhook only",
+	SubExtensionBitsTransactionCreate: "webhook only",
+	SubUserAuthorizationGrant:         "webhook only",
+	SubUserAuthorizationRevoke:        "webhook only",
+	SubChannelModerate:                "not supported by the cli",
+}
+
+// TestConformance triggers every subscription type with the websocket mock of
+// the Twitch CLI and checks the events arrive decoded without unknown fields.
+// Run it with go test -tags conformance -run TestConformance. TWITCH_CLI sets
+// the path of the CLI and TWITCH_CLI_PORT the port of the mock server.
+func TestConformance(t *testing.T) {
+	cli := os.Getenv("TWITCH_CLI")
+	if cli == "" {
+		cli = "twitch"
+	}
+	cli, err := exec.LookPath(cli)
+	if err != nil {
+		t.Fatalf("could not find the twitch cli: %v", err)
+	}
+
+	port := os.Getenv("TWITCH_CLI_PORT")
+	if port == "" {
+		port = "8080"
+	}
+	address := net.JoinHostPort("127.0.0.1", port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := exec.CommandContext(ctx, cli, "event", "websocket", "start-server", "--port", port)
+	err = server.Start()
+	if err != nil {
+		t.Fatalf("could not start mock server: %v", err)
+	}
+	defer server.Wait()
+	waitForServer(t, address)
+
+	received := newConformanceEvents()
+	client := NewClientWithUrl(fmt.Sprintf("ws://%s/ws", address))
+	client.SubscriptionUrl = fmt.Sprintf("http://%s/eventsub/subscriptions", address)
+	client.OnError(func(err error) {
+		t.Errorf("client error: %v", err)
+	})
+	client.OnUnknownFields(func(subType EventSubscription, fields []string) {
+		t.Errorf("%s has unknown fields %v", subType, fields)
+	})
+	client.AddListener(func(message NotificationMessage, event any) {
+		received.add(message.Payload.Subscription.Type, event)
+	})
+
+	subscribed := make(chan error, 1)
+	client.OnWelcome(func(message WelcomeMessage) {
+		for _, subType := range SubscriptionTypes() {
+			if _, ok := untriggerable[subType]; ok {
+				continue
+			}
+			_, err := client.Subscribe(SubscribeRequest{
+				SessionID:   message.Payload.Session.ID,
+				ClientID:    "conformance",
+				AccessToken: "conformance",
+				Event:       subType,
+				Condition:   conformanceCondition(subType),
+			})
+			if err != nil {
+				subscribed <- fmt.Errorf("could not subscribe to %s: %w", subType, err)
+				return
+			}
+		}
+		subscribed <- nil
+	})
+	go client.ConnectWithContext(ctx)
+	defer client.Close()
+
+	select {
+	case err := <-subscribed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("did not receive a welcome from the mock server")
+	}
+
+	subTypes := SubscriptionTypes()
+	sort.Slice(subTypes, func(i, j int) bool { return subTypes[i] < subTypes[j] })
+	for _, subType := range subTypes {
+		t.Run(string(subType), func(t *testing.T) {
+			if reason, ok := untriggerable[subType]; ok {
+				t.Skipf("twitch cli cannot trigger %s: %s", subType, reason)
+			}
+
+			output, err := exec.CommandContext(ctx, cli, "event", "trigger", string(subType),
+				"--transport", "websocket",
+				"--version", subMetadata[subType].Version,
+			).CombinedOutput()
+			if err != nil {
+				t.Fatalf("could not trigger %s: %v: %s", subType, err, output)
+			}
+
+			event, ok := received.wait(subType, 5*time.Second)
+			if !ok {
+				t.Fatalf("did not receive %s", subType)
+			}
+			if stringer, ok := event.(fmt.Stringer); ok && strings.Contains(stringer.String(), "%!") {
+				t.Errorf("summary of %s is malformed: %s", subType, stringer)
+			}
+		})
+	}
+}
+
+// conformanceCondition fills every condition key the subscription type needs,
+// picking the first of alternatives.
+func conformanceCondition(subType EventSubscription) map[string]string {
+	condition := map[string]string{}
+	for _, required := range subMetadata[subType].Condition {
+		condition[strings.Split(required, "|")[0]] = "1337"
+	}
+	return condition
+}
+
+func waitForServer(t *testing.T, address string) {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		conn, err := net.Dial("tcp", address)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("mock server did not listen on %s", address)
+}
+
+type conformanceEvents struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	events map[EventSubscription]any
+}
+
+func newConformanceEvents() *conformanceEvents {
+	e := &conformanceEvents{events: map[EventSubscription]any{}}
+	e.cond = sync.NewCond(&e.mu)
+	return e
+}
+
+func (e *conformanceEvents) add(subType EventSubscription, event any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.events[subType] = event
+	e.cond.Broadcast()
+}
+
+func (e *conformanceEvents) wait(subType EventSubscription, timeout time.Duration) (any, bool) {
+	timer := time.AfterFunc(timeout, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.cond.Broadcast()
+	})
+	defer timer.Stop()
+
+	deadline := time.Now().Add(timeout)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for {
+		if event, ok := e.events[subType]; ok {
+			return event, true
+		}
+		if !time.Now().Before(deadline) {
+			return nil, false
+		}
+		e.cond.Wait()
+	}
+}
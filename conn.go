@@ -1,35 +1,64 @@
 package twitch
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"nhooyr.io/websocket"
 )
 
 const (
 	twitchWebsocketUrl = "wss://eventsub.wss.twitch.tv/ws"
+
+	defaultWelcomeTimeout = 10 * time.Second
 )
 
 var (
 	ErrConnClosed   = fmt.Errorf("connection closed")
 	ErrNilOnWelcome = fmt.Errorf("OnWelcome function was not set")
-
-	messageTypeMap = map[string]func() any{
-		"session_welcome":   zeroPtrGen[WelcomeMessage](),
-		"session_keepalive": zeroPtrGen[KeepAliveMessage](),
-		"notification":      zeroPtrGen[NotificationMessage](),
-		"session_reconnect": zeroPtrGen[ReconnectMessage](),
-		"revocation":        zeroPtrGen[RevokeMessage](),
+	// ErrWelcomeTimeout is returned when connecting if no session_welcome
+	// message arrives within the welcome timeout.
+	ErrWelcomeTimeout = fmt.Errorf("did not receive a session_welcome message in time")
+	// ErrClosedByUser is returned when connecting after Close ended the
+	// connection.
+	ErrClosedByUser = fmt.Errorf("connection was closed by the client")
+
+	messageTypeMap = map[string]func(data []byte, decode Decoder) (any, error){
+		"session_welcome":   decodeAs[WelcomeMessage],
+		"session_keepalive": decodeAs[KeepAliveMessage],
+		"notification":      decodeAs[NotificationMessage],
+		"session_reconnect": decodeAs[ReconnectMessage],
+		"revocation":        decodeAs[RevokeMessage],
 	}
 )
 
-func zeroPtrGen[T any]() func() any {
-	return func() any {
-		return new(T)
-	}
+// ErrClosedByTwitch is returned when connecting after Twitch closed the
+// connection, with the websocket close code like 4003 for connections that
+// did not subscribe in time.
+type ErrClosedByTwitch struct {
+	Code   websocket.StatusCode
+	Reason string
+}
+
+func (e ErrClosedByTwitch) Error() string {
+	return fmt.Sprintf("connection was closed by twitch: %d %s", e.Code, e.Reason)
+}
+
+// Message buffers are reused between reads. Anything kept past handleMessage,
+// like the raw event, is copied out when the message is decoded.
+const maxPooledBufferSize = 64 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
 }
 
 func callFunc[T any](f func(T), v T) {
@@ -39,135 +68,290 @@ func callFunc[T any](f func(T), v T) {
 }
 
 type Client struct {
-	Address   string
-	ws        *websocket.Conn
-	connected bool
-	ctx       context.Context
+	Address         string
+	SubscriptionUrl string
+	// mu guards ws, ctx, and sessionID, which the read loop replaces while
+	// callbacks and other goroutines use them
+	mu           sync.Mutex
+	ws           *websocket.Conn
+	connected    atomic.Bool
+	closedByUser atomic.Bool
+	ctx          context.Context
+	cancel       atomic.Pointer[context.CancelFunc]
+	// labels are the pprof labels of the read loop
+	labels context.Context
 
 	reconnecting bool
 	reconnected  chan struct{}
 
+	maxReconnects        int
+	maxReconnectDuration time.Duration
+	gaveUp               atomic.Pointer[error]
+	// retrying is set while Run reconnects after losing the connection
+	retrying atomic.Bool
+	onGiveUp func(err error)
+
+	decode   Decoder
+	clock    Clock
+	recorder FrameRecorder
+	// subscriptionVersions are the versions pinned by WithSubscriptionVersion
+	subscriptionVersions map[EventSubscription]string
+	// deprecations are the ones added by WithDeprecatedVersion
+	deprecations         map[EventSubscription]map[string]time.Time
+	deprecationsReported deprecationTracker
+	frameLog             func(format string, args ...any)
+	redactFrames         bool
+	deadLetters          DeadLetterSink
+	readyGate            *notificationGate
+	pauseGate            *notificationGate
+	replay               *replayBuffer
+	state                *stateCache
+	welcomeTimeout       time.Duration
+
+	// Unix nano time of the last message and the session's keepalive timeout
+	lastMessage      atomic.Int64
+	keepaliveTimeout atomic.Int64
+	stats            statsCounter
+
+	syncDispatch   bool
+	strictDecoding bool
+	queueSize      int
+	queuePolicy    BackpressurePolicy
+	orderKey       OrderKey
+	queues         map[string]*dispatchQueue
+	queuesMu       sync.Mutex
+
+	sessionID       string
+	subscriptions   []SubscribeRequest
+	subscriptionsMu sync.Mutex
+
 	// Responses
 	onError        func(err error)
+	onWarning      func(err error)
 	onWelcome      func(message WelcomeMessage)
 	onKeepAlive    func(message KeepAliveMessage)
 	onNotification func(message NotificationMessage)
 	onReconnect    func(message ReconnectMessage)
 	onRevoke       func(message RevokeMessage)
+	onRevocation   func(revocation Revocation)
+	onDeprecation  func(deprecation Deprecation)
+	onResubscribe  func(request SubscribeRequest, err error)
+	listeners      []listener
+	listenersMu    sync.Mutex
+	nextListenerID HandlerID
+
+	onEventsDropped func(count int, eventType EventSubscription)
+
+	sessionStore       SessionStore
+	sessionCredentials SubscribeRequest
+	sessionLoad        sync.Once
+	sessionLoadErr     error
+	sessionSaveMu      sync.Mutex
+	lastSessionSave    time.Time
+	messageIDs         *messageIDs
+	restoredFrom       time.Time
+	onRestore          func(from, to time.Time)
+
+	gapStart            atomic.Int64
+	lastNotifications   map[string]time.Time
+	lastNotificationsMu sync.Mutex
+	onGap               func(gap Gap)
+
+	handlerTimeout time.Duration
+	errorPolicy    ErrorPolicy
+
+	latencyThreshold time.Duration
+	onHighLatency    func(latency time.Duration, metadata MessageMetadata)
+
+	revocationRecovery bool
+	onReauth           func(revocation Revocation)
+	onUnknownFields    func(subType EventSubscription, fields []string)
+	onInvalidEvent     func(raw string, err error)
 
 	// Events
-	onRawEvent                                              func(event string, metadata MessageMetadata, subscription PayloadSubscription)
-	onEventChannelUpdate                                    func(event EventChannelUpdate)
-	onEventChannelFollow                                    func(event EventChannelFollow)
-	onEventChannelSubscribe                                 func(event EventChannelSubscribe)
-	onEventChannelSubscriptionEnd                           func(event EventChannelSubscriptionEnd)
-	onEventChannelSubscriptionGift                          func(event EventChannelSubscriptionGift)
-	onEventChannelSubscriptionMessage                       func(event EventChannelSubscriptionMessage)
-	onEventChannelCheer                                     func(event EventChannelCheer)
-	onEventChannelRaid                                      func(event EventChannelRaid)
-	onEventChannelBan                                       func(event EventChannelBan)
-	onEventChannelUnban                                     func(event EventChannelUnban)
-	onEventChannelModeratorAdd                              func(event EventChannelModeratorAdd)
-	onEventChannelModeratorRemove                           func(event EventChannelModeratorRemove)
-	onEventChannelChannelPointsCustomRewardAdd              func(event EventChannelChannelPointsCustomRewardAdd)
-	onEventChannelChannelPointsCustomRewardUpdate           func(event EventChannelChannelPointsCustomRewardUpdate)
-	onEventChannelChannelPointsCustomRewardRemove           func(event EventChannelChannelPointsCustomRewardRemove)
-	onEventChannelChannelPointsCustomRewardRedemptionAdd    func(event EventChannelChannelPointsCustomRewardRedemptionAdd)
-	onEventChannelChannelPointsCustomRewardRedemptionUpdate func(event EventChannelChannelPointsCustomRewardRedemptionUpdate)
-	onEventChannelPollBegin                                 func(event EventChannelPollBegin)
-	onEventChannelPollProgress                              func(event EventChannelPollProgress)
-	onEventChannelPollEnd                                   func(event EventChannelPollEnd)
-	onEventChannelPredictionBegin                           func(event EventChannelPredictionBegin)
-	onEventChannelPredictionProgress                        func(event EventChannelPredictionProgress)
-	onEventChannelPredictionLock                            func(event EventChannelPredictionLock)
-	onEventChannelPredictionEnd                             func(event EventChannelPredictionEnd)
-	onEventDropEntitlementGrant                             func(event []EventDropEntitlementGrant)
-	onEventExtensionBitsTransactionCreate                   func(event EventExtensionBitsTransactionCreate)
-	onEventChannelGoalBegin                                 func(event EventChannelGoalBegin)
-	onEventChannelGoalProgress                              func(event EventChannelGoalProgress)
-	onEventChannelGoalEnd                                   func(event EventChannelGoalEnd)
-	onEventChannelHypeTrainBegin                            func(event EventChannelHypeTrainBegin)
-	onEventChannelHypeTrainProgress                         func(event EventChannelHypeTrainProgress)
-	onEventChannelHypeTrainEnd                              func(event EventChannelHypeTrainEnd)
-	onEventStreamOnline                                     func(event EventStreamOnline)
-	onEventStreamOffline                                    func(event EventStreamOffline)
-	onEventUserAuthorizationGrant                           func(event EventUserAuthorizationGrant)
-	onEventUserAuthorizationRevoke                          func(event EventUserAuthorizationRevoke)
-	onEventUserUpdate                                       func(event EventUserUpdate)
-	onEventChannelCharityCampaignDonate                     func(event EventChannelCharityCampaignDonate)
-	onEventChannelCharityCampaignProgress                   func(event EventChannelCharityCampaignProgress)
-	onEventChannelCharityCampaignStart                      func(event EventChannelCharityCampaignStart)
-	onEventChannelCharityCampaignStop                       func(event EventChannelCharityCampaignStop)
-	onEventChannelShieldModeBegin                           func(event EventChannelShieldModeBegin)
-	onEventChannelShieldModeEnd                             func(event EventChannelShieldModeEnd)
-	onEventChannelShoutoutCreate                            func(event EventChannelShoutoutCreate)
-	onEventChannelShoutoutReceive                           func(event EventChannelShoutoutReceive)
-	onEventChannelModerate                                  func(event EventChannelModerate)
-}
-
-func NewClient() *Client {
-	return NewClientWithUrl(twitchWebsocketUrl)
-}
-
-func NewClientWithUrl(url string) *Client {
-	return &Client{
-		Address:     url,
-		reconnected: make(chan struct{}),
-		onError:     func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	eventHandlers
+	onRawEvent func(event string, metadata MessageMetadata, subscription PayloadSubscription)
+}
+
+func NewClient(options ...ClientOption) *Client {
+	return NewClientWithUrl(twitchWebsocketUrl, options...)
+}
+
+func NewClientWithUrl(url string, options ...ClientOption) *Client {
+	c := &Client{
+		Address:         url,
+		SubscriptionUrl: twitchEventSubUrl,
+		reconnected:     make(chan struct{}),
+		decode:          defaultDecoder,
+		clock:           realClock{},
+		welcomeTimeout:  defaultWelcomeTimeout,
+		pauseGate:       &notificationGate{open: true},
+		onError:         func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	}
+
+	for _, option := range options {
+		option(c)
 	}
+	return c
 }
 
 func (c *Client) Connect() error {
 	return c.ConnectWithContext(context.Background())
 }
 
+// ConnectWithContext connects and handles messages until the connection ends.
+// It returns ErrClosedByUser after Close, ErrClosedByTwitch when Twitch closed
+// the connection, ctx's error when ctx is done, and other errors for failures,
+// so supervisors can tell which ones warrant a restart.
 func (c *Client) ConnectWithContext(ctx context.Context) error {
 	if c.onWelcome == nil {
 		return ErrNilOnWelcome
 	}
 
+	// Events from the last message of the previous connection or process on
+	// may have been missed
+	c.gapStart.Store(c.lastMessage.Load())
+	if c.sessionStore != nil {
+		err := c.loadSession()
+		if err != nil {
+			return err
+		}
+		defer c.saveSession()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.cancel.Store(&cancel)
+
+	c.mu.Lock()
 	c.ctx = ctx
+	c.mu.Unlock()
 	ws, err := c.dial()
 	if err != nil {
 		return err
 	}
+	c.mu.Lock()
 	c.ws = ws
-	c.connected = true
+	c.sessionID = ""
+	c.mu.Unlock()
+	c.closedByUser.Store(false)
+	c.connected.Store(true)
+	defer c.connected.Store(false)
+	c.keepaliveTimeout.Store(0)
+
+	defer pprof.SetGoroutineLabels(ctx)
+	c.labelReadLoop(ctx, "")
+
+	// Reads are bound to the welcome timeout until the welcome message arrives
+	readCtx, cancelRead := ctx, context.CancelFunc(func() {})
+	if c.welcomeTimeout > 0 {
+		readCtx, cancelRead = withClockTimeout(ctx, c.clock, c.welcomeTimeout)
+	}
+	defer cancelRead()
 
 	for {
-		_, data, err := c.ws.Read(ctx)
+		if readCtx != ctx && c.SessionID() != "" {
+			cancelRead()
+			readCtx = ctx
+		}
+
+		buf := bufferPool.Get().(*bytes.Buffer)
+		buf.Reset()
+
+		err := c.readMessage(readCtx, buf)
 		if err != nil {
-			if errors.Is(err, context.Canceled) {
-				return nil
+			bufferPool.Put(buf)
+			if readCtx.Err() != nil && ctx.Err() == nil {
+				return ErrWelcomeTimeout
 			}
 
-			if websocket.CloseStatus(err) == websocket.StatusNormalClosure {
-				if c.reconnecting {
-					c.reconnecting = false
-					<-c.reconnected
-					continue
-				}
-				return nil
+			if c.closedByUser.Load() {
+				return ErrClosedByUser
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure && c.reconnecting {
+				c.reconnecting = false
+				<-c.reconnected
+				continue
+			}
+
+			var closeError websocket.CloseError
+			if errors.As(err, &closeError) {
+				return ErrClosedByTwitch{Code: closeError.Code, Reason: closeError.Reason}
 			}
 
 			return fmt.Errorf("could not read message: %w", err)
 		}
 
-		err = c.handleMessage(data)
+		received := c.clock.Now()
+		c.lastMessage.Store(received.UnixNano())
+		if c.recorder != nil {
+			err = c.recorder.Record(received, buf.Bytes())
+			if err != nil {
+				c.onError(fmt.Errorf("could not record message: %w", err))
+			}
+		}
+
+		err = c.handleMessage(buf.Bytes(), received)
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+
 		if err != nil {
-			c.onError(err)
+			err = c.handleError(err)
+			if err != nil {
+				if ws := c.conn(); c.connected.Swap(false) && ws != nil {
+					ws.Close(websocket.StatusNormalClosure, "Stopping Connection")
+				}
+				return err
+			}
 		}
 	}
 }
 
+func (c *Client) readMessage(ctx context.Context, buf *bytes.Buffer) error {
+	// Close drops the websocket, possibly while a callback runs on this loop
+	ws := c.conn()
+	if ws == nil {
+		return ErrConnClosed
+	}
+
+	_, reader, err := ws.Reader(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = buf.ReadFrom(reader)
+	return err
+}
+
+// conn returns the websocket of the current connection.
+func (c *Client) conn() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ws
+}
+
 func (c *Client) Close() error {
-	defer func() { c.ws = nil }()
-	if !c.connected {
+	ws := c.conn()
+	defer func() {
+		// A new connection may have replaced ws meanwhile
+		c.mu.Lock()
+		if c.ws == ws {
+			c.ws = nil
+		}
+		c.mu.Unlock()
+	}()
+	if !c.connected.Swap(false) {
 		return nil
 	}
-	c.connected = false
+	c.closedByUser.Store(true)
 
-	err := c.ws.Close(websocket.StatusNormalClosure, "Stopping Connection")
+	err := ws.Close(websocket.StatusNormalClosure, "Stopping Connection")
 
 	var closeError websocket.CloseError
 	if err != nil && !errors.As(err, &closeError) {
@@ -176,45 +360,105 @@ func (c *Client) Close() error {
 	return nil
 }
 
-func (c *Client) handleMessage(data []byte) error {
-	metadata, err := parseBaseMessage(data)
-	if err != nil {
+// CloseWithContext closes the connection like Close, but stops waiting for
+// the close handshake when ctx is done and drops the connection instead, so
+// shutdown can't hang on a peer that stopped responding.
+func (c *Client) CloseWithContext(ctx context.Context) error {
+	closed := make(chan error, 1)
+	go func() {
+		closed <- c.Close()
+	}()
+
+	select {
+	case err := <-closed:
 		return err
+	case <-ctx.Done():
+		// Canceling the connection's reads makes the websocket close the
+		// underlying connection
+		if cancel := c.cancel.Load(); cancel != nil {
+			(*cancel)()
+		}
+		return fmt.Errorf("could not close websocket connection: %w", ctx.Err())
 	}
+}
 
-	messageType := metadata.MessageType
-	genMessage, ok := messageTypeMap[messageType]
-	if !ok {
-		return fmt.Errorf("unknown message type %s: %s", messageType, string(data))
+// HandleMessage processes a raw websocket message as if it was read from the
+// connection. It is meant for replaying recorded streams and benchmarks.
+func (c *Client) HandleMessage(data []byte) error {
+	return c.handleMessage(data, time.Time{})
+}
+
+// handleMessage handles a message read at received, which is zero for
+// messages that were not read from the connection.
+func (c *Client) handleMessage(data []byte, received time.Time) error {
+	if c.frameLog != nil {
+		c.logFrame(data)
 	}
 
-	message := genMessage()
-	err = json.Unmarshal(data, message)
+	err := c.processMessage(data, received)
+	if err != nil && c.deadLetters != nil && isUndecodable(err) {
+		c.deadLetter(data, err)
+	}
+	return err
+}
+
+func (c *Client) processMessage(data []byte, received time.Time) error {
+	metadata, message, err := decodeMessage(data, c.decode)
+	if metadata.MessageType != "" {
+		c.stats.message(metadata.MessageType)
+	}
 	if err != nil {
-		return fmt.Errorf("could not unmarshal message into %s: %w", messageType, err)
+		if errors.Is(err, ErrUnknownMessageType) {
+			return err
+		}
+
+		c.stats.decodeError()
+		if metadata.MessageType == "notification" {
+			return c.undecodableNotification(data, metadata, err)
+		}
+		return err
+	}
+
+	if !received.IsZero() {
+		c.observeLatency(messageMetadata(message), received)
 	}
 
 	switch msg := message.(type) {
-	case *WelcomeMessage:
-		callFunc(c.onWelcome, *msg)
-	case *KeepAliveMessage:
-		callFunc(c.onKeepAlive, *msg)
-	case *NotificationMessage:
-		callFunc(c.onNotification, *msg)
-
-		err = c.handleNotification(*msg)
-		if err != nil {
-			return fmt.Errorf("could not handle notification: %w", err)
+	case WelcomeMessage:
+		c.mu.Lock()
+		c.sessionID = msg.Payload.Session.ID
+		c.mu.Unlock()
+		c.keepaliveTimeout.Store(int64(time.Duration(msg.Payload.Session.KeepaliveTimeoutSeconds) * time.Second))
+		if !received.IsZero() {
+			c.labelReadLoop(c.context(), msg.Payload.Session.ID)
+		}
+		go c.resubscribe(msg.Payload.Session.ID)
+
+		if from := c.restoredFrom; !from.IsZero() {
+			c.restoredFrom = time.Time{}
+			if c.onRestore != nil {
+				go c.onRestore(from, c.clock.Now())
+			}
+		}
+
+		callFunc(c.onWelcome, msg)
+	case KeepAliveMessage:
+		callFunc(c.onKeepAlive, msg)
+	case NotificationMessage:
+		if c.redelivered(msg) {
+			return nil
 		}
-	case *ReconnectMessage:
-		callFunc(c.onReconnect, *msg)
+		return c.dispatchNotification(msg)
+	case ReconnectMessage:
+		callFunc(c.onReconnect, msg)
 
-		err = c.reconnect(*msg)
+		err = c.reconnect(msg)
 		if err != nil {
 			return fmt.Errorf("could not handle reconnect: %w", err)
 		}
-	case *RevokeMessage:
-		callFunc(c.onRevoke, *msg)
+	case RevokeMessage:
+		callFunc(c.onRevoke, msg)
+		c.handleRevocation(msg)
 	default:
 		return fmt.Errorf("unhandled %T message: %v", msg, msg)
 	}
@@ -222,6 +466,115 @@ func (c *Client) handleMessage(data []byte) error {
 	return nil
 }
 
+// Subscribe creates a subscription on the current session and records it so
+// it can be recreated if the client connects to a new session later on.
+func (c *Client) Subscribe(request SubscribeRequest) (SubscribeResponse, error) {
+	return c.SubscribeWithContext(context.Background(), request)
+}
+
+func (c *Client) SubscribeWithContext(ctx context.Context, request SubscribeRequest) (SubscribeResponse, error) {
+	request.SessionID = c.SessionID()
+	if request.VersionOverride == "" {
+		request.VersionOverride = c.subscriptionVersions[request.Event]
+	}
+	c.checkDeprecation(request.Event, request.VersionOverride)
+
+	// A recorded subscription, like one restored from a SessionStore, is
+	// claimed for this session so it is not created twice
+	c.subscriptionsMu.Lock()
+	i := c.recordedIndex(request)
+	if i >= 0 && request.SessionID != "" && c.subscriptions[i].SessionID == request.SessionID {
+		c.subscriptionsMu.Unlock()
+		return SubscribeResponse{}, nil
+	}
+	var previous SubscribeRequest
+	if i >= 0 {
+		previous = c.subscriptions[i]
+		c.subscriptions[i] = request
+	}
+	c.subscriptionsMu.Unlock()
+
+	response, err := SubscribeEventUrlWithContext(ctx, request, c.SubscriptionUrl)
+	if err != nil {
+		if i >= 0 {
+			c.subscriptionsMu.Lock()
+			if i = c.recordedIndex(request); i >= 0 {
+				c.subscriptions[i] = previous
+			}
+			c.subscriptionsMu.Unlock()
+		}
+		return SubscribeResponse{}, err
+	}
+
+	if i < 0 {
+		c.subscriptionsMu.Lock()
+		c.subscriptions = append(c.subscriptions, request)
+		c.subscriptionsMu.Unlock()
+	} else if previous.SessionID != request.SessionID {
+		c.reportGap(request)
+	}
+	c.saveSession()
+
+	return response, nil
+}
+
+// recordedIndex returns the index of the recorded subscription with the same
+// event and condition as request, or -1. subscriptionsMu must be held.
+func (c *Client) recordedIndex(request SubscribeRequest) int {
+	for i, recorded := range c.subscriptions {
+		if recorded.Event == request.Event && equalConditions(recorded.Condition, request.Condition) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Client) subscriptionCount() int {
+	c.subscriptionsMu.Lock()
+	defer c.subscriptionsMu.Unlock()
+	return len(c.subscriptions)
+}
+
+func (c *Client) resubscribe(sessionID string) {
+	c.subscriptionsMu.Lock()
+	subscriptions := make([]SubscribeRequest, len(c.subscriptions))
+	copy(subscriptions, c.subscriptions)
+	c.subscriptionsMu.Unlock()
+
+	for _, request := range subscriptions {
+		// Claim the subscription for the session, which Subscribe may have
+		// done meanwhile, and find it again as it can also be removed
+		c.subscriptionsMu.Lock()
+		i := c.recordedIndex(request)
+		if i < 0 || c.subscriptions[i].SessionID == sessionID {
+			c.subscriptionsMu.Unlock()
+			continue
+		}
+		request = c.subscriptions[i]
+		previous := request.SessionID
+		c.subscriptions[i].SessionID = sessionID
+		c.subscriptionsMu.Unlock()
+
+		request.SessionID = sessionID
+		_, err := SubscribeEventUrlWithContext(c.context(), request, c.SubscriptionUrl)
+		if err != nil {
+			err = fmt.Errorf("could not resubscribe to %s: %w", request.Event, err)
+
+			c.subscriptionsMu.Lock()
+			if i = c.recordedIndex(request); i >= 0 && c.subscriptions[i].SessionID == sessionID {
+				c.subscriptions[i].SessionID = previous
+			}
+			c.subscriptionsMu.Unlock()
+		} else {
+			c.reportGap(request)
+		}
+
+		if c.onResubscribe != nil {
+			c.onResubscribe(request, err)
+		}
+	}
+}
+
 func (c *Client) reconnect(message ReconnectMessage) error {
 	c.Address = message.Payload.Session.ReconnectUrl
 	ws, err := c.dial()
@@ -230,12 +583,12 @@ func (c *Client) reconnect(message ReconnectMessage) error {
 	}
 
 	go func() {
-		_, data, err := ws.Read(c.ctx)
+		_, data, err := ws.Read(c.context())
 		if err != nil {
 			c.onError(fmt.Errorf("reconnect failed: could not read reconnect websocket for welcome: %w", err))
 		}
 
-		metadata, err := parseBaseMessage(data)
+		metadata, err := peekMetadata(data)
 		if err != nil {
 			c.onError(fmt.Errorf("reconnect failed: could parse base message: %w", err))
 		}
@@ -245,9 +598,13 @@ func (c *Client) reconnect(message ReconnectMessage) error {
 			return
 		}
 
+		c.stats.reconnect()
 		c.reconnecting = true
-		c.ws.Close(websocket.StatusNormalClosure, "Stopping Connection")
+		c.mu.Lock()
+		previous := c.ws
 		c.ws = ws
+		c.mu.Unlock()
+		previous.Close(websocket.StatusNormalClosure, "Stopping Connection")
 		c.reconnected <- struct{}{}
 	}()
 
@@ -255,360 +612,269 @@ func (c *Client) reconnect(message ReconnectMessage) error {
 }
 
 func (c *Client) handleNotification(message NotificationMessage) error {
-	data, err := message.Payload.Event.MarshalJSON()
+	metadata, data, err := notificationEvent(message)
 	if err != nil {
-		return fmt.Errorf("could not get event json: %w", err)
+		return err
 	}
 
 	subscription := message.Payload.Subscription
-	metadata, ok := subMetadata[subscription.Type]
-	if !ok {
-		return fmt.Errorf("unknown subscription type %s", subscription.Type)
-	}
+	c.checkDeprecation(subscription.Type, subscription.Version)
 
 	if c.onRawEvent != nil {
 		c.onRawEvent(string(data), message.Metadata, subscription)
 	}
 
-	var newEvent any
-	if metadata.EventGen != nil {
-		newEvent = metadata.EventGen()
-		err = json.Unmarshal(data, newEvent)
-		if err != nil {
-			return fmt.Errorf("could not unmarshal %s into %T: %w", subscription.Type, newEvent, err)
-		}
+	event, err := c.decodeEvent(metadata, subscription.Type, data)
+	if err != nil {
+		c.stats.decodeError()
+		return newDecodeError(subscription.Type, message.Metadata.MessageID, data, err)
 	}
 
-	switch event := newEvent.(type) {
-	case *EventChannelUpdate:
-		callFunc(c.onEventChannelUpdate, *event)
-	case *EventChannelFollow:
-		callFunc(c.onEventChannelFollow, *event)
-	case *EventChannelSubscribe:
-		callFunc(c.onEventChannelSubscribe, *event)
-	case *EventChannelSubscriptionEnd:
-		callFunc(c.onEventChannelSubscriptionEnd, *event)
-	case *EventChannelSubscriptionGift:
-		callFunc(c.onEventChannelSubscriptionGift, *event)
-	case *EventChannelSubscriptionMessage:
-		callFunc(c.onEventChannelSubscriptionMessage, *event)
-	case *EventChannelCheer:
-		callFunc(c.onEventChannelCheer, *event)
-	case *EventChannelRaid:
-		callFunc(c.onEventChannelRaid, *event)
-	case *EventChannelBan:
-		callFunc(c.onEventChannelBan, *event)
-	case *EventChannelUnban:
-		callFunc(c.onEventChannelUnban, *event)
-	case *EventChannelModeratorAdd:
-		callFunc(c.onEventChannelModeratorAdd, *event)
-	case *EventChannelModeratorRemove:
-		callFunc(c.onEventChannelModeratorRemove, *event)
-	case *EventChannelChannelPointsCustomRewardAdd:
-		callFunc(c.onEventChannelChannelPointsCustomRewardAdd, *event)
-	case *EventChannelChannelPointsCustomRewardUpdate:
-		callFunc(c.onEventChannelChannelPointsCustomRewardUpdate, *event)
-	case *EventChannelChannelPointsCustomRewardRemove:
-		callFunc(c.onEventChannelChannelPointsCustomRewardRemove, *event)
-	case *EventChannelChannelPointsCustomRewardRedemptionAdd:
-		callFunc(c.onEventChannelChannelPointsCustomRewardRedemptionAdd, *event)
-	case *EventChannelChannelPointsCustomRewardRedemptionUpdate:
-		callFunc(c.onEventChannelChannelPointsCustomRewardRedemptionUpdate, *event)
-	case *EventChannelPollBegin:
-		callFunc(c.onEventChannelPollBegin, *event)
-	case *EventChannelPollProgress:
-		callFunc(c.onEventChannelPollProgress, *event)
-	case *EventChannelPollEnd:
-		callFunc(c.onEventChannelPollEnd, *event)
-	case *EventChannelPredictionBegin:
-		callFunc(c.onEventChannelPredictionBegin, *event)
-	case *EventChannelPredictionProgress:
-		callFunc(c.onEventChannelPredictionProgress, *event)
-	case *EventChannelPredictionLock:
-		callFunc(c.onEventChannelPredictionLock, *event)
-	case *EventChannelPredictionEnd:
-		callFunc(c.onEventChannelPredictionEnd, *event)
-	case *[]EventDropEntitlementGrant:
-		callFunc(c.onEventDropEntitlementGrant, *event)
-	case *EventExtensionBitsTransactionCreate:
-		callFunc(c.onEventExtensionBitsTransactionCreate, *event)
-	case *EventChannelGoalBegin:
-		callFunc(c.onEventChannelGoalBegin, *event)
-	case *EventChannelGoalProgress:
-		callFunc(c.onEventChannelGoalProgress, *event)
-	case *EventChannelGoalEnd:
-		callFunc(c.onEventChannelGoalEnd, *event)
-	case *EventChannelHypeTrainBegin:
-		callFunc(c.onEventChannelHypeTrainBegin, *event)
-	case *EventChannelHypeTrainProgress:
-		callFunc(c.onEventChannelHypeTrainProgress, *event)
-	case *EventChannelHypeTrainEnd:
-		callFunc(c.onEventChannelHypeTrainEnd, *event)
-	case *EventStreamOnline:
-		callFunc(c.onEventStreamOnline, *event)
-	case *EventStreamOffline:
-		callFunc(c.onEventStreamOffline, *event)
-	case *EventUserAuthorizationGrant:
-		callFunc(c.onEventUserAuthorizationGrant, *event)
-	case *EventUserAuthorizationRevoke:
-		callFunc(c.onEventUserAuthorizationRevoke, *event)
-	case *EventUserUpdate:
-		callFunc(c.onEventUserUpdate, *event)
-	case *EventChannelCharityCampaignDonate:
-		callFunc(c.onEventChannelCharityCampaignDonate, *event)
-	case *EventChannelCharityCampaignProgress:
-		callFunc(c.onEventChannelCharityCampaignProgress, *event)
-	case *EventChannelCharityCampaignStart:
-		callFunc(c.onEventChannelCharityCampaignStart, *event)
-	case *EventChannelCharityCampaignStop:
-		callFunc(c.onEventChannelCharityCampaignStop, *event)
-	case *EventChannelShieldModeBegin:
-		callFunc(c.onEventChannelShieldModeBegin, *event)
-	case *EventChannelShieldModeEnd:
-		callFunc(c.onEventChannelShieldModeEnd, *event)
-	case *EventChannelShoutoutCreate:
-		callFunc(c.onEventChannelShoutoutCreate, *event)
-	case *EventChannelShoutoutReceive:
-		callFunc(c.onEventChannelShoutoutReceive, *event)
-	case *EventChannelModerate:
-		callFunc(c.onEventChannelModerate, *event)
-	default:
-		c.onError(fmt.Errorf("unknown event type %s", subscription.Type))
+	if c.onInvalidEvent != nil {
+		if err := validateEvent(data, event); err != nil {
+			c.onInvalidEvent(string(data), fmt.Errorf("%s: %w", subscription.Type, err))
+			return nil
+		}
 	}
+	c.stats.event(subscription.Type)
+	c.trackNotification(subscription, message.Metadata.MessageTimestamp)
 
-	return nil
-}
-
-func (c *Client) dial() (*websocket.Conn, error) {
-	ws, _, err := websocket.Dial(c.ctx, c.Address, nil)
-	if err != nil {
-		return nil, fmt.Errorf("could not dial %s: %w", c.Address, err)
+	if c.onUnknownFields != nil {
+		if fields := unknownFields(data, reflect.TypeOf(event)); len(fields) > 0 {
+			c.onUnknownFields(subscription.Type, fields)
+		}
 	}
-	return ws, nil
-}
 
-func parseBaseMessage(data []byte) (MessageMetadata, error) {
-	type BaseMessage struct {
-		Metadata MessageMetadata `json:"metadata"`
+	listeners := c.replayListeners(message, event)
+	for _, listener := range listeners {
+		if listener.inline {
+			c.callHandler(subscription.Type, listener.name(), func(ctx context.Context) {
+				listener.f(ctx, message, event)
+			})
+		}
 	}
 
-	var baseMessage BaseMessage
-	err := json.Unmarshal(data, &baseMessage)
-	if err != nil {
-		return MessageMetadata{}, fmt.Errorf("could not unmarshal basemessage to get message type: %w", err)
+	handler := metadata.Handler(c)
+	categoryHandler := c.eventHandlers.categoryHandler(event)
+	callHandler := func() {
+		c.callHandler(subscription.Type, "handler", func(ctx context.Context) {
+			handler(event)
+		})
+	}
+	callCategoryHandler := func() {
+		c.callHandler(subscription.Type, "category handler", func(ctx context.Context) {
+			categoryHandler()
+		})
+	}
+	dispatch := func() {
+		for _, listener := range listeners {
+			if !listener.inline {
+				c.callHandler(subscription.Type, listener.name(), func(ctx context.Context) {
+					listener.f(ctx, message, event)
+				})
+			}
+		}
+		if handler != nil {
+			callHandler()
+		}
+		if categoryHandler != nil {
+			callCategoryHandler()
+		}
 	}
 
-	return baseMessage.Metadata, nil
-}
-
-func (c *Client) OnError(callback func(err error)) {
-	c.onError = callback
-}
-
-func (c *Client) OnWelcome(callback func(message WelcomeMessage)) {
-	c.onWelcome = callback
-}
-
-func (c *Client) OnKeepAlive(callback func(message KeepAliveMessage)) {
-	c.onKeepAlive = callback
-}
-
-func (c *Client) OnNotification(callback func(message NotificationMessage)) {
-	c.onNotification = callback
-}
-
-func (c *Client) OnReconnect(callback func(message ReconnectMessage)) {
-	c.onReconnect = callback
-}
-
-func (c *Client) OnRevoke(callback func(message RevokeMessage)) {
-	c.onRevoke = callback
-}
-
-func (c *Client) OnRawEvent(callback func(event string, metadata MessageMetadata, subscription PayloadSubscription)) {
-	c.onRawEvent = callback
-}
-
-func (c *Client) OnEventChannelUpdate(callback func(event EventChannelUpdate)) {
-	c.onEventChannelUpdate = callback
-}
-
-func (c *Client) OnEventChannelFollow(callback func(event EventChannelFollow)) {
-	c.onEventChannelFollow = callback
-}
-
-func (c *Client) OnEventChannelSubscribe(callback func(event EventChannelSubscribe)) {
-	c.onEventChannelSubscribe = callback
-}
-
-func (c *Client) OnEventChannelSubscriptionEnd(callback func(event EventChannelSubscriptionEnd)) {
-	c.onEventChannelSubscriptionEnd = callback
-}
-
-func (c *Client) OnEventChannelSubscriptionGift(callback func(event EventChannelSubscriptionGift)) {
-	c.onEventChannelSubscriptionGift = callback
-}
-
-func (c *Client) OnEventChannelSubscriptionMessage(callback func(event EventChannelSubscriptionMessage)) {
-	c.onEventChannelSubscriptionMessage = callback
-}
-
-func (c *Client) OnEventChannelCheer(callback func(event EventChannelCheer)) {
-	c.onEventChannelCheer = callback
-}
-
-func (c *Client) OnEventChannelRaid(callback func(event EventChannelRaid)) {
-	c.onEventChannelRaid = callback
-}
-
-func (c *Client) OnEventChannelBan(callback func(event EventChannelBan)) {
-	c.onEventChannelBan = callback
-}
-
-func (c *Client) OnEventChannelUnban(callback func(event EventChannelUnban)) {
-	c.onEventChannelUnban = callback
-}
-
-func (c *Client) OnEventChannelModeratorAdd(callback func(event EventChannelModeratorAdd)) {
-	c.onEventChannelModeratorAdd = callback
-}
-
-func (c *Client) OnEventChannelModeratorRemove(callback func(event EventChannelModeratorRemove)) {
-	c.onEventChannelModeratorRemove = callback
-}
-
-func (c *Client) OnEventChannelChannelPointsCustomRewardAdd(callback func(event EventChannelChannelPointsCustomRewardAdd)) {
-	c.onEventChannelChannelPointsCustomRewardAdd = callback
-}
-
-func (c *Client) OnEventChannelChannelPointsCustomRewardUpdate(callback func(event EventChannelChannelPointsCustomRewardUpdate)) {
-	c.onEventChannelChannelPointsCustomRewardUpdate = callback
-}
-
-func (c *Client) OnEventChannelChannelPointsCustomRewardRemove(callback func(event EventChannelChannelPointsCustomRewardRemove)) {
-	c.onEventChannelChannelPointsCustomRewardRemove = callback
-}
+	if c.syncDispatch {
+		dispatch()
+		return nil
+	}
 
-func (c *Client) OnEventChannelChannelPointsCustomRewardRedemptionAdd(callback func(event EventChannelChannelPointsCustomRewardRedemptionAdd)) {
-	c.onEventChannelChannelPointsCustomRewardRedemptionAdd = callback
-}
+	if queue, release := c.dispatchQueue(message, event); queue != nil {
+		queue.push(c.labels, queuedEvent{Type: subscription.Type, Run: dispatch})
+		release()
+		return nil
+	}
 
-func (c *Client) OnEventChannelChannelPointsCustomRewardRedemptionUpdate(callback func(event EventChannelChannelPointsCustomRewardRedemptionUpdate)) {
-	c.onEventChannelChannelPointsCustomRewardRedemptionUpdate = callback
-}
+	for _, listener := range listeners {
+		if !listener.inline {
+			listener := listener
+			goLabeled(c.labels, roleHandler, func() {
+				c.callHandler(subscription.Type, listener.name(), func(ctx context.Context) {
+					listener.f(ctx, message, event)
+				})
+			})
+		}
+	}
+	if handler != nil {
+		goLabeled(c.labels, roleHandler, callHandler)
+	}
+	if categoryHandler != nil {
+		goLabeled(c.labels, roleHandler, callCategoryHandler)
+	}
 
-func (c *Client) OnEventChannelPollBegin(callback func(event EventChannelPollBegin)) {
-	c.onEventChannelPollBegin = callback
+	return nil
 }
 
-func (c *Client) OnEventChannelPollProgress(callback func(event EventChannelPollProgress)) {
-	c.onEventChannelPollProgress = callback
-}
+// dispatchQueue returns the queue for the order key of an event, which is
+// removed again once it is empty and release was called.
+func (c *Client) dispatchQueue(message NotificationMessage, event any) (*dispatchQueue, func()) {
+	if c.queueSize == 0 {
+		return nil, nil
+	}
 
-func (c *Client) OnEventChannelPollEnd(callback func(event EventChannelPollEnd)) {
-	c.onEventChannelPollEnd = callback
-}
+	var key string
+	if c.orderKey != nil {
+		key = c.orderKey(message, event)
+	}
 
-func (c *Client) OnEventChannelPredictionBegin(callback func(event EventChannelPredictionBegin)) {
-	c.onEventChannelPredictionBegin = callback
-}
+	c.queuesMu.Lock()
+	defer c.queuesMu.Unlock()
 
-func (c *Client) OnEventChannelPredictionProgress(callback func(event EventChannelPredictionProgress)) {
-	c.onEventChannelPredictionProgress = callback
-}
+	if c.queues == nil {
+		c.queues = map[string]*dispatchQueue{}
+	}
 
-func (c *Client) OnEventChannelPredictionLock(callback func(event EventChannelPredictionLock)) {
-	c.onEventChannelPredictionLock = callback
-}
+	queue, ok := c.queues[key]
+	if !ok {
+		queue = newDispatchQueue(c.queueSize, c.queuePolicy, func(count int, eventType EventSubscription) {
+			if c.onEventsDropped != nil {
+				c.onEventsDropped(count, eventType)
+			}
+		})
+		queue.onIdle = func() {
+			c.queuesMu.Lock()
+			defer c.queuesMu.Unlock()
+			c.removeIdleQueue(key, queue)
+		}
+		c.queues[key] = queue
+	}
+	queue.users++
 
-func (c *Client) OnEventChannelPredictionEnd(callback func(event EventChannelPredictionEnd)) {
-	c.onEventChannelPredictionEnd = callback
-}
+	return queue, func() {
+		c.queuesMu.Lock()
+		defer c.queuesMu.Unlock()
 
-func (c *Client) OnEventDropEntitlementGrant(callback func(event []EventDropEntitlementGrant)) {
-	c.onEventDropEntitlementGrant = callback
+		queue.users--
+		c.removeIdleQueue(key, queue)
+	}
 }
 
-func (c *Client) OnEventExtensionBitsTransactionCreate(callback func(event EventExtensionBitsTransactionCreate)) {
-	c.onEventExtensionBitsTransactionCreate = callback
+// removeIdleQueue deletes the queue of a key when nothing is queued or about
+// to be. c.queuesMu has to be held.
+func (c *Client) removeIdleQueue(key string, queue *dispatchQueue) {
+	if queue.users == 0 && c.queues[key] == queue && queue.idle() {
+		delete(c.queues, key)
+	}
 }
 
-func (c *Client) OnEventChannelGoalBegin(callback func(event EventChannelGoalBegin)) {
-	c.onEventChannelGoalBegin = callback
+func (c *Client) dial() (*websocket.Conn, error) {
+	ws, _, err := websocket.Dial(c.context(), c.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not dial %s: %w", c.Address, err)
+	}
+	return ws, nil
 }
 
-func (c *Client) OnEventChannelGoalProgress(callback func(event EventChannelGoalProgress)) {
-	c.onEventChannelGoalProgress = callback
+// SessionID returns the ID of the current session, empty until the welcome
+// message arrives.
+func (c *Client) SessionID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sessionID
 }
 
-func (c *Client) OnEventChannelGoalEnd(callback func(event EventChannelGoalEnd)) {
-	c.onEventChannelGoalEnd = callback
+// context returns the context of the current connection.
+func (c *Client) context() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ctx
 }
 
-func (c *Client) OnEventChannelHypeTrainBegin(callback func(event EventChannelHypeTrainBegin)) {
-	c.onEventChannelHypeTrainBegin = callback
+func (c *Client) OnError(callback func(err error)) {
+	c.onError = callback
 }
 
-func (c *Client) OnEventChannelHypeTrainProgress(callback func(event EventChannelHypeTrainProgress)) {
-	c.onEventChannelHypeTrainProgress = callback
+// OnWarning is called for problems that did not stop a message from being
+// handled, like unknown fields with WithStrictDecoding. Warnings go to
+// OnError when it is not set.
+func (c *Client) OnWarning(callback func(err error)) {
+	c.onWarning = callback
 }
 
-func (c *Client) OnEventChannelHypeTrainEnd(callback func(event EventChannelHypeTrainEnd)) {
-	c.onEventChannelHypeTrainEnd = callback
+func (c *Client) warn(err error) {
+	if c.onWarning != nil {
+		c.onWarning(err)
+		return
+	}
+	c.onError(err)
 }
 
-func (c *Client) OnEventStreamOnline(callback func(event EventStreamOnline)) {
-	c.onEventStreamOnline = callback
+func (c *Client) OnWelcome(callback func(message WelcomeMessage)) {
+	c.onWelcome = callback
 }
 
-func (c *Client) OnEventStreamOffline(callback func(event EventStreamOffline)) {
-	c.onEventStreamOffline = callback
+func (c *Client) OnKeepAlive(callback func(message KeepAliveMessage)) {
+	c.onKeepAlive = callback
 }
 
-func (c *Client) OnEventUserAuthorizationGrant(callback func(event EventUserAuthorizationGrant)) {
-	c.onEventUserAuthorizationGrant = callback
+func (c *Client) OnNotification(callback func(message NotificationMessage)) {
+	c.onNotification = callback
 }
 
-func (c *Client) OnEventUserAuthorizationRevoke(callback func(event EventUserAuthorizationRevoke)) {
-	c.onEventUserAuthorizationRevoke = callback
+func (c *Client) OnReconnect(callback func(message ReconnectMessage)) {
+	c.onReconnect = callback
 }
 
-func (c *Client) OnEventUserUpdate(callback func(event EventUserUpdate)) {
-	c.onEventUserUpdate = callback
+func (c *Client) OnRevoke(callback func(message RevokeMessage)) {
+	c.onRevoke = callback
 }
 
-func (c *Client) OnEventChannelCharityCampaignDonate(callback func(event EventChannelCharityCampaignDonate)) {
-	c.onEventChannelCharityCampaignDonate = callback
+// OnRevocation is called for every revoked subscription with the reason and
+// the user it was revoked for.
+func (c *Client) OnRevocation(callback func(revocation Revocation)) {
+	c.onRevocation = callback
 }
 
-func (c *Client) OnEventChannelCharityCampaignProgress(callback func(event EventChannelCharityCampaignProgress)) {
-	c.onEventChannelCharityCampaignProgress = callback
+func (c *Client) OnResubscribe(callback func(request SubscribeRequest, err error)) {
+	c.onResubscribe = callback
 }
 
-func (c *Client) OnEventChannelCharityCampaignStart(callback func(event EventChannelCharityCampaignStart)) {
-	c.onEventChannelCharityCampaignStart = callback
+// OnInvalidEvent turns on validation of decoded events. Events with empty
+// broadcaster IDs, zero timestamps, or bit amounts that are not positive are
+// passed to callback with an ErrInvalidEvent error instead of being delivered.
+func (c *Client) OnInvalidEvent(callback func(raw string, err error)) {
+	c.onInvalidEvent = callback
 }
 
-func (c *Client) OnEventChannelCharityCampaignStop(callback func(event EventChannelCharityCampaignStop)) {
-	c.onEventChannelCharityCampaignStop = callback
+// OnGiveUp is called with the ErrReconnectLimit error before Run returns it.
+func (c *Client) OnGiveUp(callback func(err error)) {
+	c.onGiveUp = callback
 }
 
-func (c *Client) OnEventChannelShieldModeBegin(callback func(event EventChannelShieldModeBegin)) {
-	c.onEventChannelShieldModeBegin = callback
+// AddListener registers a callback that receives every decoded event along
+// with the notification it arrived in. Any number of listeners can be added
+// and the returned ID removes it again with Off.
+func (c *Client) AddListener(listener func(message NotificationMessage, event any)) HandlerID {
+	return c.addListener(listener, false)
 }
 
-func (c *Client) OnEventChannelShieldModeEnd(callback func(event EventChannelShieldModeEnd)) {
-	c.onEventChannelShieldModeEnd = callback
+// AddContextListener is AddListener for listeners that take a context, which
+// is canceled once the listener runs longer than WithHandlerTimeout allows.
+func (c *Client) AddContextListener(listener func(ctx context.Context, message NotificationMessage, event any)) HandlerID {
+	return c.addContextListener(listener, false)
 }
 
-func (c *Client) OnEventChannelShoutoutCreate(callback func(event EventChannelShoutoutCreate)) {
-	c.onEventChannelShoutoutCreate = callback
+// OnEventsDropped is called with the number of events of a type that were
+// dropped because the dispatch queue was full.
+func (c *Client) OnEventsDropped(callback func(count int, eventType EventSubscription)) {
+	c.onEventsDropped = callback
 }
 
-func (c *Client) OnEventChannelShoutoutReceive(callback func(event EventChannelShoutoutReceive)) {
-	c.onEventChannelShoutoutReceive = callback
+// OnUnknownFields is called with the dotted paths of the keys in an event
+// that its struct has no field for, which usually means Twitch added fields
+// this package does not map yet.
+func (c *Client) OnUnknownFields(callback func(subType EventSubscription, fields []string)) {
+	c.onUnknownFields = callback
 }
 
-func (c *Client) OnEventChannelModerate(callback func(event EventChannelModerate)) {
-	c.onEventChannelModerate = callback
+func (c *Client) OnRawEvent(callback func(event string, metadata MessageMetadata, subscription PayloadSubscription)) {
+	c.onRawEvent = callback
 }
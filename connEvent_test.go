@@ -2,6 +2,7 @@ package twitch_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/joeyak/go-twitch-eventsub/v2"
 )
@@ -89,6 +90,9 @@ func TestEventChannelSubscriptionGiftAnon(t *testing.T) {
 
 	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventChannelSubscriptionGift(func(event twitch.EventChannelSubscriptionGift) {
+			if event.Gifter() != nil {
+				t.Errorf("expected no gifter for anonymous gift")
+			}
 			close(ch)
 		})
 	}, twitch.SubChannelSubscriptionGift, "anon")
@@ -119,6 +123,9 @@ func TestEventChannelCheer(t *testing.T) {
 
 	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventChannelCheer(func(event twitch.EventChannelCheer) {
+			if cheerer := event.Cheerer(); cheerer == nil || cheerer.UserID != "1234" {
+				t.Errorf("expected cheerer 1234, got %v", cheerer)
+			}
 			close(ch)
 		})
 	}, twitch.SubChannelCheer)
@@ -129,6 +136,9 @@ func TestEventChannelCheerAnon(t *testing.T) {
 
 	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventChannelCheer(func(event twitch.EventChannelCheer) {
+			if event.Cheerer() != nil {
+				t.Errorf("expected no cheerer for anonymous cheer")
+			}
 			close(ch)
 		})
 	}, twitch.SubChannelCheer, "anon")
@@ -149,6 +159,9 @@ func TestEventChannelBan(t *testing.T) {
 
 	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventChannelBan(func(event twitch.EventChannelBan) {
+			if !event.IsTimeout() || event.Duration() != time.Minute {
+				t.Errorf("expected a one minute timeout, got %v", event.Duration())
+			}
 			close(ch)
 		})
 	}, twitch.SubChannelBan)
@@ -319,6 +332,13 @@ func TestEventExtensionBitsTransactionCreate(t *testing.T) {
 
 	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventExtensionBitsTransactionCreate(func(event twitch.EventExtensionBitsTransactionCreate) {
+			expected := twitch.ExtensionProduct{Name: "great_product", SKU: "skuskusku", Bits: 1234}
+			if event.Product != expected {
+				t.Errorf("expected product %+v got %+v", expected, event.Product)
+			}
+			if event.Bits() != 1234 || event.SKU() != "skuskusku" {
+				t.Errorf("accessors returned %d bits and sku %q", event.Bits(), event.SKU())
+			}
 			close(ch)
 		})
 	}, twitch.SubExtensionBitsTransactionCreate)
@@ -349,6 +369,12 @@ func TestEventChannelGoalEnd(t *testing.T) {
 
 	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
 		client.OnEventChannelGoalEnd(func(event twitch.EventChannelGoalEnd) {
+			if event.Type != twitch.GoalTypeSubscription || event.Description != "Help me get partner!" || event.EndedAt.IsZero() {
+				t.Errorf("goal was not decoded: %+v", event)
+			}
+			if event.Remaining() != 40 {
+				t.Errorf("expected 40 remaining got %d", event.Remaining())
+			}
 			close(ch)
 		})
 	}, twitch.SubChannelGoalEnd)
@@ -543,3 +569,16 @@ func TestEventChannelModerate(t *testing.T) {
 		})
 	}, twitch.SubChannelModerate)
 }
+
+func TestOnAnyCategoryEvent(t *testing.T) {
+	t.Parallel()
+
+	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+		client.OnAnyHypeTrainEvent(func(event twitch.HypeTrainEvent) {
+			if _, ok := event.(twitch.EventChannelHypeTrainProgress); !ok {
+				t.Errorf("got %T instead of hype train progress", event)
+			}
+			close(ch)
+		})
+	}, twitch.SubChannelHypeTrainProgress)
+}
@@ -3,11 +3,17 @@ package twitch_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
 	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
 )
 
 func noDataGen() ([][]byte, bool, error) {
@@ -114,7 +120,28 @@ func TestOnClose(t *testing.T) {
 	})
 
 	err := client.Connect()
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, twitch.ErrClosedByUser)
+}
+
+func TestClosedByTwitch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Write(r.Context(), websocket.MessageText, []byte(`{"metadata":{"message_type":"session_welcome"},"payload":{"session":{"id":"1337"}}}`))
+		conn.Close(4003, "connection unused")
+	}))
+	defer server.Close()
+
+	client := twitch.NewClientWithUrl(strings.Replace(server.URL, "http", "ws", 1))
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	err := client.Connect()
+	assert.Equal(t, twitch.ErrClosedByTwitch{Code: 4003, Reason: "connection unused"}, err)
 }
 
 func TestOnCloseWithContext(t *testing.T) {
@@ -129,6 +156,51 @@ func TestOnCloseWithContext(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestCloseWithContext(t *testing.T) {
+	t.Parallel()
+
+	// The server never reads, so it never answers the close handshake
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Write(r.Context(), websocket.MessageText, []byte(`{"metadata":{"message_type":"session_welcome"},"payload":{"session":{"id":"1337"}}}`))
+		<-release
+	}))
+	defer server.Close()
+
+	welcomed := make(chan struct{})
+	client := twitch.NewClientWithUrl(strings.Replace(server.URL, "http", "ws", 1))
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		close(welcomed)
+	})
+
+	connected := make(chan error, 1)
+	go func() {
+		connected <- client.Connect()
+	}()
+	<-welcomed
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.CloseWithContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	select {
+	case err := <-connected:
+		assert.ErrorIs(t, err, twitch.ErrClosedByUser)
+	case <-time.After(time.Second):
+		t.Fatal("connection was not dropped")
+	}
+}
+
 func TestOnKeepAlive(t *testing.T) {
 	t.Parallel()
 
@@ -219,8 +291,131 @@ func TestReconnectEvent(t *testing.T) {
 	client.OnRevoke(func(message twitch.RevokeMessage) { revokeOccured = true })
 
 	err = client.Connect()
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, twitch.ErrClosedByUser)
 	assert.Equal(t, reconnectUrl, client.Address, "addresses should match")
 	assert.True(t, revokeOccured, "revoke did not fire")
 	assert.True(t, keepAliveOccured, "keepalive did not fire")
 }
+
+func TestResubscribe(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, func() ([][]byte, bool, error) {
+		return nil, true, nil
+	})
+	client.SubscriptionUrl = strings.ReplaceAll(client.Address, "/ws", "/subscriptions")
+
+	var subscribed atomic.Bool
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		if subscribed.Swap(true) {
+			return
+		}
+
+		_, err := client.Subscribe(twitch.SubscribeRequest{
+			Event:     twitch.SubStreamOnline,
+			Condition: map[string]string{"broadcaster_user_id": "1234"},
+		})
+		assert.NoError(t, err)
+		client.Close()
+	})
+
+	resubscribed := make(chan twitch.SubscribeRequest, 1)
+	client.OnResubscribe(func(request twitch.SubscribeRequest, err error) {
+		assert.NoError(t, err)
+		resubscribed <- request
+		client.Close()
+	})
+
+	connect(t, client)
+	connect(t, client)
+
+	select {
+	case request := <-resubscribed:
+		assert.Equal(t, twitch.SubStreamOnline, request.Event)
+		assert.NotEmpty(t, request.SessionID)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not resubscribed")
+	}
+}
+
+func TestNotificationWithoutEvent(t *testing.T) {
+	t.Parallel()
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client := newClient(t, func() ([][]byte, bool, error) {
+			return [][]byte{[]byte(`{
+				"metadata": {
+					"message_id": "befa7b53-d79d-478f-86b9-120f112b044e",
+					"message_type": "notification",
+					"message_timestamp": "2019-11-16T10:11:12.464757833Z"
+				},
+				"payload": {
+					"subscription": {"type": "stream.online", "version": "1"}
+				}
+			}`)}, false, nil
+		})
+		client.OnError(func(err error) {
+			close(ch)
+		})
+
+		go connect(t, client)
+	})
+}
+
+func TestRawEventsOutliveReadBuffer(t *testing.T) {
+	t.Parallel()
+
+	online, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline, _, err := getTestEventData(twitch.SubStreamOffline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rawEvents []string
+	assertEventOccured(t, func(ch chan struct{}) {
+		client := newClient(t, func() ([][]byte, bool, error) {
+			return append(online, offline...), false, nil
+		})
+		client.OnRawEvent(func(event string, metadata twitch.MessageMetadata, subscription twitch.PayloadSubscription) {
+			rawEvents = append(rawEvents, event)
+			if len(rawEvents) == 2 {
+				close(ch)
+			}
+		})
+
+		go connect(t, client)
+	})
+
+	if assert.Len(t, rawEvents, 2) {
+		assert.Contains(t, rawEvents[0], `"started_at"`)
+		assert.NotContains(t, rawEvents[1], `"started_at"`)
+	}
+}
+
+func TestWelcomeTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Read(r.Context())
+	}))
+	defer server.Close()
+
+	clock := clocktest.NewClock(time.Now())
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithClock(clock), twitch.WithWelcomeTimeout(time.Minute))
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	go func() {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}()
+
+	err := client.Connect()
+	assert.ErrorIs(t, err, twitch.ErrWelcomeTimeout)
+}
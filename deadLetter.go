@@ -0,0 +1,42 @@
+package twitch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeadLetter is a message the client could not handle. Data is the raw
+// message, which HandleMessage can replay once the library understands it.
+type DeadLetter struct {
+	Received time.Time `json:"received"`
+	Reason   string    `json:"reason"`
+	Data     string    `json:"data"`
+}
+
+// DeadLetterSink keeps messages with unknown message types or subscription
+// types and messages that could not be decoded. The deadletter package has
+// sinks that keep them in memory or write them to a file.
+type DeadLetterSink interface {
+	DeadLetter(letter DeadLetter) error
+}
+
+func isUndecodable(err error) bool {
+	var decodeErr DecodeError
+	return errors.Is(err, ErrUnknownMessageType) ||
+		errors.Is(err, ErrUnknownSubscriptionType) ||
+		errors.As(err, &decodeErr)
+}
+
+func (c *Client) deadLetter(data []byte, err error) {
+	letter := DeadLetter{
+		Received: c.clock.Now(),
+		Reason:   err.Error(),
+		Data:     string(data),
+	}
+
+	sinkErr := c.deadLetters.DeadLetter(letter)
+	if sinkErr != nil {
+		c.onError(fmt.Errorf("could not write dead letter: %w", sinkErr))
+	}
+}
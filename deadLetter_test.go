@@ -0,0 +1,48 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type deadLetterFunc func(letter twitch.DeadLetter) error
+
+func (f deadLetterFunc) DeadLetter(letter twitch.DeadLetter) error {
+	return f(letter)
+}
+
+func TestWithDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	var letters []twitch.DeadLetter
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithDeadLetters(deadLetterFunc(func(letter twitch.DeadLetter) error {
+		letters = append(letters, letter)
+		return nil
+	})))
+
+	online, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(online[0]))
+
+	messages := []string{
+		`{"metadata":{"message_id":"1","message_type":"session_unknown"}}`,
+		`{"metadata":{"message_id":"2","message_type":"notification"},"payload":{"subscription":{"type":"channel.unknown"},"event":{}}}`,
+		`{"metadata":{"message_id":"3","message_type":"notification"},"payload":{"subscription":{"type":"channel.cheer"},"event":{"bits":"many"}}}`,
+	}
+	for _, message := range messages {
+		assert.Error(t, client.HandleMessage([]byte(message)))
+	}
+
+	if assert.Len(t, letters, 3) {
+		for i, letter := range letters {
+			assert.Equal(t, messages[i], letter.Data)
+			assert.NotEmpty(t, letter.Reason)
+			assert.False(t, letter.Received.IsZero())
+		}
+		assert.Contains(t, letters[1].Reason, "unknown subscription type")
+	}
+}
@@ -0,0 +1,127 @@
+// Package deadletter keeps the messages a client could not handle, like
+// events of subscription types the library does not know yet, so operators
+// can inspect them and replay them after upgrading.
+//
+//	file, _ := os.OpenFile("deadletters.jsonl", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+//	client := twitch.NewClient(twitch.WithDeadLetters(deadletter.NewWriter(file)))
+//
+// Files hold one json twitch.DeadLetter per line.
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const maxLetterSize = 1024 * 1024
+
+// Ring keeps the most recent dead letters in memory.
+type Ring struct {
+	mu      sync.Mutex
+	letters []twitch.DeadLetter
+	next    int
+	full    bool
+}
+
+var _ twitch.DeadLetterSink = (*Ring)(nil)
+
+// NewRing returns a Ring that keeps the last size letters.
+func NewRing(size int) *Ring {
+	if size < 1 {
+		size = 1
+	}
+	return &Ring{letters: make([]twitch.DeadLetter, size)}
+}
+
+func (r *Ring) DeadLetter(letter twitch.DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.letters[r.next] = letter
+	r.next = (r.next + 1) % len(r.letters)
+	if r.next == 0 {
+		r.full = true
+	}
+	return nil
+}
+
+// Letters returns the kept letters, oldest first.
+func (r *Ring) Letters() []twitch.DeadLetter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]twitch.DeadLetter(nil), r.letters[:r.next]...)
+	}
+	return append(append([]twitch.DeadLetter(nil), r.letters[r.next:]...), r.letters[:r.next]...)
+}
+
+// Writer writes dead letters to w as json lines.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+var _ twitch.DeadLetterSink = (*Writer)(nil)
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+func (w *Writer) DeadLetter(letter twitch.DeadLetter) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.enc.Encode(letter)
+	if err != nil {
+		return fmt.Errorf("could not write dead letter: %w", err)
+	}
+	return nil
+}
+
+// Read returns the dead letters written by a Writer.
+func Read(r io.Reader) ([]twitch.DeadLetter, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLetterSize)
+
+	var letters []twitch.DeadLetter
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var letter twitch.DeadLetter
+		err := json.Unmarshal(line, &letter)
+		if err != nil {
+			return letters, fmt.Errorf("could not parse dead letter: %w", err)
+		}
+		letters = append(letters, letter)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return letters, fmt.Errorf("could not read dead letters: %w", err)
+	}
+	return letters, nil
+}
+
+// Replay hands the letters to the client again and returns the ones it still
+// could not handle. A client with WithDeadLetters writes those to its sink
+// again.
+func Replay(client *twitch.Client, letters []twitch.DeadLetter) []twitch.DeadLetter {
+	var failed []twitch.DeadLetter
+	for _, letter := range letters {
+		err := client.HandleMessage([]byte(letter.Data))
+		if err != nil {
+			letter.Reason = err.Error()
+			failed = append(failed, letter)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,62 @@
+package deadletter_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/deadletter"
+	"github.com/stretchr/testify/assert"
+)
+
+func letter(i int) twitch.DeadLetter {
+	return twitch.DeadLetter{
+		Received: time.Date(2023, 1, 1, 0, 0, i, 0, time.UTC),
+		Reason:   "unknown subscription type",
+		Data:     fmt.Sprintf(`{"metadata":{"message_id":"%d"}}`, i),
+	}
+}
+
+func TestRing(t *testing.T) {
+	ring := deadletter.NewRing(2)
+	assert.Empty(t, ring.Letters())
+
+	assert.NoError(t, ring.DeadLetter(letter(1)))
+	assert.Equal(t, []twitch.DeadLetter{letter(1)}, ring.Letters())
+
+	assert.NoError(t, ring.DeadLetter(letter(2)))
+	assert.NoError(t, ring.DeadLetter(letter(3)))
+	assert.Equal(t, []twitch.DeadLetter{letter(2), letter(3)}, ring.Letters())
+}
+
+func TestWriterRead(t *testing.T) {
+	var buf bytes.Buffer
+	writer := deadletter.NewWriter(&buf)
+	assert.NoError(t, writer.DeadLetter(letter(1)))
+	assert.NoError(t, writer.DeadLetter(letter(2)))
+
+	letters, err := deadletter.Read(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []twitch.DeadLetter{letter(1), letter(2)}, letters)
+}
+
+func TestReplay(t *testing.T) {
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var offline bool
+	client.OnEventStreamOffline(func(event twitch.EventStreamOffline) {
+		offline = true
+	})
+
+	handled := twitch.DeadLetter{Data: `{"metadata":{"message_type":"notification"},"payload":{"subscription":{"type":"stream.offline"},"event":{"broadcaster_user_id":"1337"}}}`}
+	unknown := twitch.DeadLetter{Data: `{"metadata":{"message_type":"notification"},"payload":{"subscription":{"type":"channel.unknown"},"event":{}}}`}
+
+	failed := deadletter.Replay(client, []twitch.DeadLetter{handled, unknown})
+	assert.True(t, offline)
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, unknown.Data, failed[0].Data)
+		assert.Contains(t, failed[0].Reason, "unknown subscription type")
+	}
+}
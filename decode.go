@@ -0,0 +1,196 @@
+package twitch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownMessageType      = fmt.Errorf("unknown message type")
+	ErrUnknownSubscriptionType = fmt.Errorf("unknown subscription type")
+	ErrNoEvent                 = fmt.Errorf("notification has no event")
+	ErrUnknownField            = fmt.Errorf("unknown field")
+)
+
+// maxDecodeErrorPayload is how much of the raw payload a DecodeError keeps.
+const maxDecodeErrorPayload = 512
+
+// DecodeError is returned when a notification or its event could not be
+// decoded. Payload holds the raw event, or the raw message when the event
+// could not be found in it, truncated to 512 bytes.
+type DecodeError struct {
+	SubscriptionType EventSubscription
+	MessageID        string
+	Payload          string
+	Err              error
+}
+
+func newDecodeError(subscriptionType EventSubscription, messageID string, payload []byte, err error) DecodeError {
+	if len(payload) > maxDecodeErrorPayload {
+		payload = append(payload[:maxDecodeErrorPayload:maxDecodeErrorPayload], "..."...)
+	}
+	return DecodeError{
+		SubscriptionType: subscriptionType,
+		MessageID:        messageID,
+		Payload:          string(payload),
+		Err:              err,
+	}
+}
+
+func (e DecodeError) Error() string {
+	return fmt.Sprintf("could not decode %s message %s: %v: %s", e.SubscriptionType, e.MessageID, e.Err, e.Payload)
+}
+
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeMessage decodes a raw websocket message the same way the client does.
+// The message is one of the message types, like NotificationMessage, and for
+// notifications the event is decoded into its Event type as well.
+func DecodeMessage(data []byte) (message any, event any, err error) {
+	_, message, err = decodeMessage(data, defaultDecoder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notification, ok := message.(NotificationMessage)
+	if !ok {
+		return message, nil, nil
+	}
+
+	metadata, eventData, err := notificationEvent(notification)
+	if err != nil {
+		return message, nil, err
+	}
+
+	event, err = metadata.Decode(eventData, defaultDecoder)
+	if err != nil {
+		return message, nil, newDecodeError(notification.Payload.Subscription.Type, notification.Metadata.MessageID, eventData, err)
+	}
+	return message, event, nil
+}
+
+// DecodeEvent decodes the event of a notification that arrived some other
+// way, like from a queue, into the Event type of its version, like
+// EventChannelUpdateV1 for version 1 of channel.update. Versions without
+// their own Event type decode into the one of the default version, the same
+// way the client does. Drop entitlement grants decode into
+// []EventDropEntitlementGrant.
+func DecodeEvent(subType EventSubscription, version string, data []byte) (any, error) {
+	metadata, ok := subscriptionVersion(subType, version)
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownSubscriptionType, subType)
+	}
+
+	event, err := metadata.Decode(data, defaultDecoder)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s version %s: %w", subType, version, err)
+	}
+	return event, nil
+}
+
+// decodeMessage returns the metadata as soon as it could be read, even when
+// the rest of the message could not be decoded.
+func decodeMessage(data []byte, decode Decoder) (MessageMetadata, any, error) {
+	metadata, err := peekMetadata(data)
+	if err != nil {
+		return metadata, nil, fmt.Errorf("could not read message metadata: %w", err)
+	}
+
+	decodeType, ok := messageTypeMap[metadata.MessageType]
+	if !ok {
+		return metadata, nil, fmt.Errorf("%w %s: %s", ErrUnknownMessageType, metadata.MessageType, string(data))
+	}
+
+	message, err := decodeType(data, decode)
+	if err != nil {
+		return metadata, nil, fmt.Errorf("could not unmarshal message into %s: %w", metadata.MessageType, err)
+	}
+	return metadata, message, nil
+}
+
+// undecodableNotification reads what it can from a notification that could
+// not be decoded, so its event still reaches OnRawEvent.
+func (c *Client) undecodableNotification(data []byte, metadata MessageMetadata, err error) error {
+	var notification struct {
+		Payload struct {
+			Subscription struct {
+				ID   string            `json:"id"`
+				Type EventSubscription `json:"type"`
+			} `json:"subscription"`
+			Event  json.RawMessage `json:"event"`
+			Events json.RawMessage `json:"events"`
+		} `json:"payload"`
+	}
+	json.Unmarshal(data, &notification)
+
+	subscription := notification.Payload.Subscription
+	event := notification.Payload.Event
+	if len(event) == 0 {
+		event = notification.Payload.Events
+	}
+	if len(event) == 0 {
+		return newDecodeError(subscription.Type, metadata.MessageID, data, err)
+	}
+
+	if c.onRawEvent != nil {
+		payloadSubscription := PayloadSubscription{ID: subscription.ID}
+		payloadSubscription.Type = subscription.Type
+		c.onRawEvent(string(event), metadata, payloadSubscription)
+	}
+	return newDecodeError(subscription.Type, metadata.MessageID, event, err)
+}
+
+func decodeAs[T any](data []byte, decode Decoder) (any, error) {
+	var message T
+	err := decode(data, &message)
+	return message, err
+}
+
+// notificationEvent looks up the subscription of a notification and returns
+// its event, which was kept as raw json when the message was decoded so it is
+// unmarshalled straight into its concrete type.
+func notificationEvent(message NotificationMessage) (subscriptionMetadata, []byte, error) {
+	event := message.RawEvent()
+	if event == nil {
+		return subscriptionMetadata{}, nil, fmt.Errorf("%w: %s", ErrNoEvent, message.Metadata.MessageID)
+	}
+
+	subscriptionType := message.Payload.Subscription.Type
+	metadata, ok := subscriptionVersion(subscriptionType, message.Payload.Subscription.Version)
+	if !ok {
+		return subscriptionMetadata{}, nil, fmt.Errorf("%w %s", ErrUnknownSubscriptionType, subscriptionType)
+	}
+	return metadata, []byte(*event), nil
+}
+
+// decodeStrict is json.Unmarshal with unknown fields disallowed. Those errors
+// wrap ErrUnknownField.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(v)
+	if err != nil && strings.HasPrefix(err.Error(), "json: unknown field ") {
+		return fmt.Errorf("%w %s", ErrUnknownField, strings.TrimPrefix(err.Error(), "json: unknown field "))
+	}
+	return err
+}
+
+func (c *Client) decodeEvent(metadata subscriptionMetadata, subscriptionType EventSubscription, data []byte) (any, error) {
+	if !c.strictDecoding {
+		return metadata.Decode(data, c.decode)
+	}
+
+	event, err := metadata.Decode(data, decodeStrict)
+	if !errors.Is(err, ErrUnknownField) {
+		return event, err
+	}
+
+	c.warn(fmt.Errorf("%s event: %w", subscriptionType, err))
+	return metadata.Decode(data, c.decode)
+}
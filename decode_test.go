@@ -0,0 +1,166 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeSeeds returns a message for every message type and every test event.
+func decodeSeeds(t testing.TB) [][]byte {
+	var events map[string]json.RawMessage
+	err := json.Unmarshal(testEvents, &events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gens := []messageDataGenerator{keepAliveGen, revokeGen, genReconnectGen("ws://localhost/ws")}
+	for key := range events {
+		parts := strings.Split(key, "-")
+		gens = append(gens, getTestEventData(twitch.EventSubscription(parts[0]), parts[1:]...))
+	}
+
+	var seeds [][]byte
+	for _, gen := range gens {
+		data, _, err := gen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seeds = append(seeds, data...)
+	}
+
+	welcome, err := json.Marshal(twitch.WelcomeMessage{Metadata: newMetadata("session_welcome")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(seeds, welcome)
+}
+
+func TestDecodeMessage(t *testing.T) {
+	t.Parallel()
+
+	data, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, event, err := twitch.DecodeMessage(data[0])
+	assert.NoError(t, err)
+	assert.IsType(t, twitch.NotificationMessage{}, message)
+	if assert.IsType(t, twitch.EventStreamOnline{}, event) {
+		assert.Equal(t, "1337", event.(twitch.EventStreamOnline).BroadcasterUserId)
+	}
+
+	data, _, _ = keepAliveGen()
+	message, event, err = twitch.DecodeMessage(data[0])
+	assert.NoError(t, err)
+	assert.IsType(t, twitch.KeepAliveMessage{}, message)
+	assert.Nil(t, event)
+
+	_, _, err = twitch.DecodeMessage([]byte(`{"metadata":{"message_type":"unknown"}}`))
+	assert.ErrorIs(t, err, twitch.ErrUnknownMessageType)
+
+	data, _, _ = getTestEventData("unknown")()
+	_, _, err = twitch.DecodeMessage(data[0])
+	assert.ErrorIs(t, err, twitch.ErrUnknownSubscriptionType)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	event, err := twitch.DecodeEvent(twitch.SubChannelFollow, "2", []byte(`{"user_login":"alice","broadcaster_user_login":"bob"}`))
+	assert.NoError(t, err)
+	if assert.IsType(t, twitch.EventChannelFollow{}, event) {
+		assert.Equal(t, "alice", event.(twitch.EventChannelFollow).UserLogin)
+		assert.Equal(t, "bob", event.(twitch.EventChannelFollow).BroadcasterUserLogin)
+	}
+
+	event, err = twitch.DecodeEvent(twitch.SubChannelUpdate, "1", []byte(`{"title":"chill","is_mature":true}`))
+	assert.NoError(t, err)
+	assert.Equal(t, twitch.EventChannelUpdateV1{Title: "chill", IsMature: true}, event)
+
+	event, err = twitch.DecodeEvent(twitch.SubChannelUpdate, "3", []byte(`{"title":"chill"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, twitch.EventChannelUpdate{Title: "chill"}, event)
+
+	event, err = twitch.DecodeEvent(twitch.SubDropEntitlementGrant, "1", []byte(`[{"id":"a"},{"id":"b"}]`))
+	assert.NoError(t, err)
+	assert.Len(t, event, 2)
+
+	_, err = twitch.DecodeEvent("unknown", "1", []byte(`{}`))
+	assert.ErrorIs(t, err, twitch.ErrUnknownSubscriptionType)
+
+	_, err = twitch.DecodeEvent(twitch.SubChannelCheer, "1", []byte(`{"bits":"many"}`))
+	assert.ErrorContains(t, err, "could not decode channel.cheer version 1")
+}
+
+func FuzzDecodeMessage(f *testing.F) {
+	for _, seed := range decodeSeeds(f) {
+		f.Add(seed)
+	}
+	f.Add([]byte(`{"metadata":{"message_type":"notification"},"payload":{"event":null}}`))
+	f.Add([]byte(`{"metadata":{"message_type":"notification"},"payload":{"subscription":{"type":"stream.online"},"event":[]}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		message, event, err := twitch.DecodeMessage(data)
+		if err != nil {
+			return
+		}
+
+		if _, ok := message.(twitch.NotificationMessage); ok && event == nil {
+			t.Errorf("notification decoded without an event: %s", data)
+		}
+	})
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	event := `{"broadcaster_user_id":"1337","bits":"many","message":"` + strings.Repeat("a", 600) + `"}`
+	data := []byte(`{"metadata":{"message_id":"abc","message_type":"notification"},"payload":{"subscription":{"type":"channel.cheer"},"event":` + event + `}}`)
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var raw string
+	client.OnRawEvent(func(event string, metadata twitch.MessageMetadata, subscription twitch.PayloadSubscription) {
+		raw = event
+	})
+
+	err := client.HandleMessage(data)
+	var decodeErr twitch.DecodeError
+	if assert.ErrorAs(t, err, &decodeErr) {
+		assert.Equal(t, twitch.SubChannelCheer, decodeErr.SubscriptionType)
+		assert.Equal(t, "abc", decodeErr.MessageID)
+		assert.Equal(t, event[:512]+"...", decodeErr.Payload)
+	}
+	assert.Equal(t, event, raw)
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	// The subscription's created_at fails decoding the whole message
+	event := `{"broadcaster_user_id":"1337"}`
+	data := []byte(`{"metadata":{"message_id":"abc","message_type":"notification"},"payload":{"subscription":{"id":"sub","type":"stream.offline","created_at":"yesterday"},"event":` + event + `}}`)
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var raw string
+	var subscription twitch.PayloadSubscription
+	client.OnRawEvent(func(event string, metadata twitch.MessageMetadata, sub twitch.PayloadSubscription) {
+		raw = event
+		subscription = sub
+	})
+
+	err := client.HandleMessage(data)
+	var decodeErr twitch.DecodeError
+	if assert.ErrorAs(t, err, &decodeErr) {
+		assert.Equal(t, twitch.SubStreamOffline, decodeErr.SubscriptionType)
+		assert.Equal(t, event, decodeErr.Payload)
+	}
+	assert.Equal(t, event, raw)
+	assert.Equal(t, "sub", subscription.ID)
+	assert.Equal(t, twitch.SubStreamOffline, subscription.Type)
+	assert.Equal(t, 1, client.Stats().DecodeErrors)
+}
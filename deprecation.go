@@ -0,0 +1,108 @@
+package twitch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// deprecatedVersions are the subscription versions Twitch announced to
+// remove, by type and version, with the date they stop working.
+var deprecatedVersions = map[EventSubscription]map[string]time.Time{
+	SubChannelFollow: {"1": time.Date(2023, time.August, 3, 0, 0, 0, 0, time.UTC)},
+}
+
+// Deprecation tells that a subscription version is going away. Past the
+// Sunset, Twitch revokes its subscriptions with version_removed.
+type Deprecation struct {
+	Type    EventSubscription
+	Version string
+	Sunset  time.Time
+	// Removed is whether the sunset already passed when the deprecation was
+	// reported
+	Removed bool
+}
+
+func (d Deprecation) Error() string {
+	if d.Removed {
+		return fmt.Sprintf("%s version %s was removed on %s", d.Type, d.Version, d.Sunset.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("%s version %s is deprecated and will be removed on %s", d.Type, d.Version, d.Sunset.Format("2006-01-02"))
+}
+
+// DeprecatedVersion returns the date a subscription version will be or was
+// removed on, if Twitch announced to remove it.
+func DeprecatedVersion(subType EventSubscription, version string) (time.Time, bool) {
+	sunset, ok := deprecatedVersions[subType][version]
+	return sunset, ok
+}
+
+// WithDeprecatedVersion adds a deprecation Twitch announced after this
+// version of the package was released, so it is reported like the known ones.
+func WithDeprecatedVersion(subType EventSubscription, version string, sunset time.Time) ClientOption {
+	return func(c *Client) {
+		if c.deprecations == nil {
+			c.deprecations = map[EventSubscription]map[string]time.Time{}
+		}
+		if c.deprecations[subType] == nil {
+			c.deprecations[subType] = map[string]time.Time{}
+		}
+		c.deprecations[subType][version] = sunset
+	}
+}
+
+// OnDeprecation is called the first time the client subscribes to or
+// decodes an event of a deprecated subscription version. Deprecations go to
+// OnWarning as errors when it is not set.
+func (c *Client) OnDeprecation(callback func(deprecation Deprecation)) {
+	c.onDeprecation = callback
+}
+
+// deprecationTracker remembers which deprecations were reported, since they
+// are checked for every subscription and event.
+type deprecationTracker struct {
+	mu       sync.Mutex
+	reported map[eventType]struct{}
+}
+
+// checkDeprecation reports version of subType if it is deprecated and was not
+// reported before. An empty version is the default one.
+func (c *Client) checkDeprecation(subType EventSubscription, version string) {
+	if version == "" {
+		version = subMetadata[subType].Version
+	}
+
+	sunset, ok := c.deprecations[subType][version]
+	if !ok {
+		sunset, ok = DeprecatedVersion(subType, version)
+	}
+	if !ok {
+		return
+	}
+
+	key := eventType{subType, version}
+	c.deprecationsReported.mu.Lock()
+	_, reported := c.deprecationsReported.reported[key]
+	if !reported {
+		if c.deprecationsReported.reported == nil {
+			c.deprecationsReported.reported = map[eventType]struct{}{}
+		}
+		c.deprecationsReported.reported[key] = struct{}{}
+	}
+	c.deprecationsReported.mu.Unlock()
+	if reported {
+		return
+	}
+
+	deprecation := Deprecation{
+		Type:    subType,
+		Version: version,
+		Sunset:  sunset,
+		Removed: !c.clock.Now().Before(sunset),
+	}
+	if c.onDeprecation != nil {
+		c.onDeprecation(deprecation)
+		return
+	}
+	c.warn(deprecation)
+}
@@ -0,0 +1,93 @@
+package twitch_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedVersion(t *testing.T) {
+	t.Parallel()
+
+	sunset, ok := twitch.DeprecatedVersion(twitch.SubChannelFollow, "1")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, time.August, 3, 0, 0, 0, 0, time.UTC), sunset)
+
+	_, ok = twitch.DeprecatedVersion(twitch.SubChannelFollow, "2")
+	assert.False(t, ok)
+}
+
+func TestOnDeprecation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sunset := now.AddDate(0, 3, 0)
+	client := twitch.NewClient(
+		twitch.WithSyncDispatch(),
+		twitch.WithClock(clocktest.NewClock(now)),
+		twitch.WithDeprecatedVersion(twitch.SubStreamOnline, "1", sunset),
+	)
+	client.SubscriptionUrl = server.URL
+
+	var deprecations []twitch.Deprecation
+	client.OnDeprecation(func(deprecation twitch.Deprecation) {
+		deprecations = append(deprecations, deprecation)
+	})
+
+	for _, request := range []twitch.SubscribeRequest{
+		{Event: twitch.SubChannelFollow, Condition: testCondition, VersionOverride: "1"},
+		{Event: twitch.SubChannelFollow, Condition: testCondition},
+		{Event: twitch.SubStreamOnline, Condition: testCondition},
+	} {
+		_, err := client.Subscribe(request)
+		assert.NoError(t, err)
+	}
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+
+	assert.Equal(t, []twitch.Deprecation{
+		{Type: twitch.SubChannelFollow, Version: "1", Sunset: time.Date(2023, time.August, 3, 0, 0, 0, 0, time.UTC), Removed: true},
+		{Type: twitch.SubStreamOnline, Version: "1", Sunset: sunset},
+	}, deprecations)
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	t.Parallel()
+
+	sunset := time.Now().AddDate(1, 0, 0)
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithDeprecatedVersion(twitch.SubStreamOnline, "1", sunset))
+
+	var warnings []error
+	client.OnWarning(func(err error) {
+		warnings = append(warnings, err)
+	})
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	assert.NoError(t, client.HandleMessage(data))
+
+	if assert.Len(t, warnings, 1) {
+		var deprecation twitch.Deprecation
+		assert.True(t, errors.As(warnings[0], &deprecation))
+		assert.Equal(t, "stream.online version 1 is deprecated and will be removed on "+sunset.Format("2006-01-02"), warnings[0].Error())
+	}
+}
@@ -0,0 +1,123 @@
+package twitch
+
+import (
+	"sort"
+	"sync"
+)
+
+// Dispatcher routes the events of several clients, like one per user token,
+// to one set of listeners. Every event is tagged with the name its client was
+// added under.
+type Dispatcher struct {
+	mu        sync.Mutex
+	clients   map[string]dispatcherClient
+	listeners []dispatcherListener
+	nextID    HandlerID
+}
+
+type dispatcherClient struct {
+	client EventSubClient
+	id     HandlerID
+}
+
+type dispatcherListener struct {
+	id HandlerID
+	f  func(tag string, message NotificationMessage, event any)
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{clients: map[string]dispatcherClient{}}
+}
+
+// Add feeds the events of client into the dispatcher tagged with tag. A
+// client already added under tag is removed.
+func (d *Dispatcher) Add(tag string, client EventSubClient) {
+	d.Remove(tag)
+
+	id := client.AddListener(func(message NotificationMessage, event any) {
+		d.dispatch(tag, message, event)
+	})
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.clients[tag] = dispatcherClient{client: client, id: id}
+}
+
+// Remove stops feeding the events of the client added under tag and reports
+// whether there was one.
+func (d *Dispatcher) Remove(tag string) bool {
+	d.mu.Lock()
+	added, ok := d.clients[tag]
+	delete(d.clients, tag)
+	d.mu.Unlock()
+
+	if ok {
+		added.client.Off(added.id)
+	}
+	return ok
+}
+
+// Client returns the client added under tag, or nil.
+func (d *Dispatcher) Client(tag string) EventSubClient {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.clients[tag].client
+}
+
+// Tags returns the tags of the added clients in order.
+func (d *Dispatcher) Tags() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tags := make([]string, 0, len(d.clients))
+	for tag := range d.clients {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// AddListener registers a callback for the events of every added client and
+// returns an ID that removes it again with Off.
+func (d *Dispatcher) AddListener(listener func(tag string, message NotificationMessage, event any)) HandlerID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.nextID++
+	d.listeners = append(d.listeners, dispatcherListener{id: d.nextID, f: listener})
+	return d.nextID
+}
+
+// Off removes a listener and reports whether it was still registered.
+func (d *Dispatcher) Off(id HandlerID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, l := range d.listeners {
+		if l.id == id {
+			d.listeners = append(append([]dispatcherListener(nil), d.listeners[:i]...), d.listeners[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) dispatch(tag string, message NotificationMessage, event any) {
+	d.mu.Lock()
+	listeners := d.listeners
+	d.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener.f(tag, message, event)
+	}
+}
+
+// Dispatch registers a listener on the dispatcher for events of type T, which
+// can be an event struct or a category, like On does for a single client.
+func Dispatch[T any](d *Dispatcher, callback func(tag string, event T)) HandlerID {
+	return d.AddListener(func(tag string, message NotificationMessage, event any) {
+		if event, ok := event.(T); ok {
+			callback(tag, event)
+		}
+	})
+}
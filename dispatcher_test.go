@@ -0,0 +1,55 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+
+	online, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline, _, err := getTestEventData(twitch.SubStreamOffline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := twitch.NewClient(twitch.WithSyncDispatch())
+	bob := twitch.NewClient(twitch.WithSyncDispatch())
+
+	dispatcher := twitch.NewDispatcher()
+	dispatcher.Add("alice", alice)
+	dispatcher.Add("bob", bob)
+	assert.Equal(t, []string{"alice", "bob"}, dispatcher.Tags())
+	assert.Equal(t, alice, dispatcher.Client("alice"))
+
+	var onlineTags []string
+	twitch.Dispatch(dispatcher, func(tag string, event twitch.EventStreamOnline) {
+		onlineTags = append(onlineTags, tag)
+	})
+	var streamTags []string
+	id := twitch.Dispatch(dispatcher, func(tag string, event twitch.StreamEvent) {
+		streamTags = append(streamTags, tag)
+	})
+
+	assert.NoError(t, alice.HandleMessage(online[0]))
+	assert.NoError(t, bob.HandleMessage(offline[0]))
+	assert.NoError(t, bob.HandleMessage(online[0]))
+	assert.Equal(t, []string{"alice", "bob"}, onlineTags)
+	assert.Equal(t, []string{"alice", "bob", "bob"}, streamTags)
+
+	assert.True(t, dispatcher.Off(id))
+	assert.True(t, dispatcher.Remove("alice"))
+	assert.False(t, dispatcher.Remove("alice"))
+	assert.Nil(t, dispatcher.Client("alice"))
+
+	assert.NoError(t, alice.HandleMessage(online[0]))
+	assert.NoError(t, bob.HandleMessage(online[0]))
+	assert.Equal(t, []string{"alice", "bob", "bob"}, onlineTags)
+	assert.Equal(t, []string{"alice", "bob", "bob"}, streamTags)
+}
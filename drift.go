@@ -0,0 +1,103 @@
+package twitch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// structFields caches the json fields of struct types by reflect.Type.
+var structFields sync.Map
+
+// jsonFields returns the json names of the fields of a struct type, including
+// the fields promoted from embedded structs, mapped to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := structFields.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(indirect(field.Type)) {
+				fields[embeddedName] = embeddedType
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+
+	structFields.Store(t, fields)
+	return fields
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// unknownFields returns the dotted paths of keys in data that t has no field
+// for, looking into nested objects and arrays of objects.
+func unknownFields(data []byte, t reflect.Type) []string {
+	found := map[string]struct{}{}
+	collectUnknownFields(data, indirect(t), "", found)
+
+	fields := make([]string, 0, len(found))
+	for field := range found {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func collectUnknownFields(data []byte, t reflect.Type, prefix string, found map[string]struct{}) {
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return
+		}
+
+		fields := jsonFields(t)
+		for key, value := range object {
+			fieldType, ok := fields[key]
+			if !ok {
+				found[prefix+key] = struct{}{}
+				continue
+			}
+			collectUnknownFields(value, indirect(fieldType), prefix+key+".", found)
+		}
+	case reflect.Slice, reflect.Array:
+		elem := indirect(t.Elem())
+		if elem.Kind() != reflect.Struct {
+			return
+		}
+
+		var values []json.RawMessage
+		if json.Unmarshal(data, &values) != nil {
+			return
+		}
+		for _, value := range values {
+			collectUnknownFields(value, elem, prefix, found)
+		}
+	}
+}
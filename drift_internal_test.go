@@ -0,0 +1,29 @@
+package twitch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+		"broadcaster_user_id": "1337",
+		"reward": {"id": "1", "cost": 100, "color": "red"},
+		"added": true
+	}`)
+
+	fields := unknownFields(data, reflect.TypeOf(EventChannelChannelPointsCustomRewardRedemptionAdd{}))
+	assert.Equal(t, []string{"added", "reward.color"}, fields)
+}
+
+func TestUnknownFieldsEmbedded(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"user_id":"1","user_login":"a","user_name":"A","broadcaster_user_id":"2","followed_at":"2023-01-01T00:00:00Z"}`)
+
+	assert.Empty(t, unknownFields(data, reflect.TypeOf(EventChannelFollow{})))
+}
@@ -0,0 +1,109 @@
+package twitch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	eventTypesOnce sync.Once
+	eventTypes     map[reflect.Type]eventType
+)
+
+type eventType struct {
+	subType EventSubscription
+	version string
+}
+
+// subscriptionTypeOf returns the subscription type whose Event type event is.
+func subscriptionTypeOf(event any) (EventSubscription, bool) {
+	t, ok := eventTypeOf(event)
+	return t.subType, ok
+}
+
+// eventTypeOf returns the subscription type and version whose Event type
+// event is.
+func eventTypeOf(event any) (eventType, bool) {
+	eventTypesOnce.Do(func() {
+		eventTypes = make(map[reflect.Type]eventType, len(subMetadata))
+		for subType, metadata := range subMetadata {
+			eventTypes[metadata.Type] = eventType{subType, metadata.Version}
+		}
+		for subType, versions := range subVersions {
+			for version, metadata := range versions {
+				eventTypes[metadata.Type] = eventType{subType, version}
+			}
+		}
+	})
+
+	t := reflect.TypeOf(event)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	found, ok := eventTypes[t]
+	return found, ok
+}
+
+// NewNotification builds the notification message Twitch would send for
+// event, with a new message ID, the current time, and an enabled websocket
+// subscription of the event's type at the version of its Event type. Fields like the
+// condition or session ID can be set before marshalling it.
+func NewNotification(event any) (NotificationMessage, error) {
+	found, ok := eventTypeOf(event)
+	if !ok {
+		return NotificationMessage{}, fmt.Errorf("%w for %T", ErrUnknownSubscriptionType, event)
+	}
+	subType := found.subType
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return NotificationMessage{}, fmt.Errorf("could not marshal %s event: %w", subType, err)
+	}
+	raw := json.RawMessage(data)
+
+	now := time.Now()
+	message := NotificationMessage{
+		Metadata: MessageMetadata{
+			MessageID:        uuid.NewString(),
+			MessageType:      "notification",
+			MessageTimestamp: now,
+		},
+	}
+	message.Payload.Subscription = PayloadSubscription{
+		SubscriptionRequest: SubscriptionRequest{
+			Type:      subType,
+			Version:   found.version,
+			Condition: map[string]string{},
+			Transport: SubscriptionTransport{Method: "websocket"},
+		},
+		ID:       uuid.NewString(),
+		Status:   SubscriptionStatusEnabled,
+		CreateAt: now,
+	}
+	if subType == SubDropEntitlementGrant {
+		message.Payload.Events = &raw
+	} else {
+		message.Payload.Event = &raw
+	}
+	return message, nil
+}
+
+// EncodeNotification returns the websocket frame of NewNotification, which
+// DecodeMessage and the client decode back into event.
+func EncodeNotification(event any) ([]byte, error) {
+	message, err := NewNotification(event)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal notification: %w", err)
+	}
+	return data, nil
+}
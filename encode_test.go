@@ -0,0 +1,61 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeNotification(t *testing.T) {
+	t.Parallel()
+
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(testEvents, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, subType := range twitch.SubscriptionTypes() {
+		subType := subType
+		t.Run(string(subType), func(t *testing.T) {
+			t.Parallel()
+
+			event, err := twitch.DecodeEvent(subType, "", events[string(subType)])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := twitch.EncodeNotification(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message, decoded, err := twitch.DecodeMessage(data)
+			assert.NoError(t, err)
+			assert.Equal(t, event, decoded)
+			if assert.IsType(t, twitch.NotificationMessage{}, message) {
+				notification := message.(twitch.NotificationMessage)
+				assert.Equal(t, "notification", notification.Metadata.MessageType)
+				assert.NotEmpty(t, notification.Metadata.MessageID)
+				assert.Equal(t, subType, notification.Payload.Subscription.Type)
+				assert.NotEmpty(t, notification.Payload.Subscription.Version)
+			}
+		})
+	}
+}
+
+func TestNewNotification(t *testing.T) {
+	t.Parallel()
+
+	message, err := twitch.NewNotification(&twitch.EventStreamOnline{Type: twitch.StreamTypeLive})
+	assert.NoError(t, err)
+	assert.Equal(t, twitch.SubStreamOnline, message.Payload.Subscription.Type)
+	assert.Equal(t, twitch.SubscriptionStatusEnabled, message.Payload.Subscription.Status)
+
+	_, err = twitch.NewNotification(struct{}{})
+	assert.ErrorIs(t, err, twitch.ErrUnknownSubscriptionType)
+
+	_, err = twitch.NewNotification(nil)
+	assert.ErrorIs(t, err, twitch.ErrUnknownSubscriptionType)
+}
@@ -0,0 +1,92 @@
+package twitch
+
+// Tier is the tier of a subscription.
+type Tier string
+
+const (
+	Tier1 Tier = "1000"
+	Tier2 Tier = "2000"
+	Tier3 Tier = "3000"
+)
+
+// Level returns 1, 2, or 3 for the tier, or 0 for an unknown tier.
+func (t Tier) Level() int {
+	switch t {
+	case Tier1:
+		return 1
+	case Tier2:
+		return 2
+	case Tier3:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type StreamType string
+
+const (
+	StreamTypeLive       StreamType = "live"
+	StreamTypePlaylist   StreamType = "playlist"
+	StreamTypeWatchParty StreamType = "watch_party"
+	StreamTypePremiere   StreamType = "premiere"
+	StreamTypeRerun      StreamType = "rerun"
+)
+
+type PollStatus string
+
+const (
+	PollStatusCompleted  PollStatus = "completed"
+	PollStatusArchived   PollStatus = "archived"
+	PollStatusTerminated PollStatus = "terminated"
+)
+
+type PredictionStatus string
+
+const (
+	PredictionStatusResolved PredictionStatus = "resolved"
+	PredictionStatusCanceled PredictionStatus = "canceled"
+)
+
+type GoalType string
+
+const (
+	GoalTypeFollow               GoalType = "follow"
+	GoalTypeSubscription         GoalType = "subscription"
+	GoalTypeSubscriptionCount    GoalType = "subscription_count"
+	GoalTypeNewSubscription      GoalType = "new_subscription"
+	GoalTypeNewSubscriptionCount GoalType = "new_subscription_count"
+	GoalTypeNewBit               GoalType = "new_bit"
+	GoalTypeNewCheerer           GoalType = "new_cheerer"
+)
+
+type RedemptionStatus string
+
+const (
+	RedemptionStatusUnknown     RedemptionStatus = "unknown"
+	RedemptionStatusUnfulfilled RedemptionStatus = "unfulfilled"
+	RedemptionStatusFulfilled   RedemptionStatus = "fulfilled"
+	RedemptionStatusCanceled    RedemptionStatus = "canceled"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusEnabled                         SubscriptionStatus = "enabled"
+	SubscriptionStatusVerificationPending             SubscriptionStatus = "webhook_callback_verification_pending"
+	SubscriptionStatusVerificationFailed              SubscriptionStatus = "webhook_callback_verification_failed"
+	SubscriptionStatusNotificationFailuresExceeded    SubscriptionStatus = "notification_failures_exceeded"
+	SubscriptionStatusAuthorizationRevoked            SubscriptionStatus = "authorization_revoked"
+	SubscriptionStatusModeratorRemoved                SubscriptionStatus = "moderator_removed"
+	SubscriptionStatusUserRemoved                     SubscriptionStatus = "user_removed"
+	SubscriptionStatusVersionRemoved                  SubscriptionStatus = "version_removed"
+	SubscriptionStatusBetaMaintenance                 SubscriptionStatus = "beta_maintenance"
+	SubscriptionStatusWebsocketDisconnected           SubscriptionStatus = "websocket_disconnected"
+	SubscriptionStatusWebsocketFailedPingPong         SubscriptionStatus = "websocket_failed_ping_pong"
+	SubscriptionStatusWebsocketReceivedInboundTraffic SubscriptionStatus = "websocket_received_inbound_traffic"
+	SubscriptionStatusWebsocketConnectionUnused       SubscriptionStatus = "websocket_connection_unused"
+	SubscriptionStatusWebsocketInternalError          SubscriptionStatus = "websocket_internal_error"
+	SubscriptionStatusWebsocketNetworkTimeout         SubscriptionStatus = "websocket_network_timeout"
+	SubscriptionStatusWebsocketNetworkError           SubscriptionStatus = "websocket_network_error"
+	SubscriptionStatusWebsocketFailedToReconnect      SubscriptionStatus = "websocket_failed_to_reconnect"
+)
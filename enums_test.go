@@ -0,0 +1,36 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTierLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, twitch.Tier1.Level())
+	assert.Equal(t, 2, twitch.Tier2.Level())
+	assert.Equal(t, 3, twitch.Tier3.Level())
+	assert.Equal(t, 0, twitch.Tier("prime").Level())
+}
+
+func TestEnumDecoding(t *testing.T) {
+	t.Parallel()
+
+	var event twitch.EventChannelSubscribe
+	err := json.Unmarshal([]byte(`{"tier":"2000"}`), &event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, twitch.Tier2, event.Tier)
+
+	var online twitch.EventStreamOnline
+	err = json.Unmarshal([]byte(`{"type":"live"}`), &online)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, twitch.StreamTypeLive, online.Type)
+}
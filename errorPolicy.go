@@ -0,0 +1,65 @@
+package twitch
+
+import "fmt"
+
+// ErrorAction is what the client does with an error from handling a message
+// read from the connection.
+type ErrorAction int
+
+const (
+	// ErrorReport passes the error to OnError and keeps going
+	ErrorReport ErrorAction = iota
+	// ErrorIgnore drops the error
+	ErrorIgnore
+	// ErrorRetry drops the connection so Run reconnects
+	ErrorRetry
+	// ErrorFatal ends the connection with an ErrFatal error, which Run
+	// returns instead of reconnecting
+	ErrorFatal
+)
+
+// ErrorPolicy picks the action for an error from handling a message. Errors
+// can be told apart with errors.Is and errors.As, like ErrUnknownSubscriptionType
+// and DecodeError.
+type ErrorPolicy func(err error) ErrorAction
+
+var ErrFatal = fmt.Errorf("fatal error")
+
+type fatalError struct {
+	err error
+}
+
+func (e fatalError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrFatal, e.err)
+}
+
+func (e fatalError) Unwrap() error {
+	return e.err
+}
+
+func (e fatalError) Is(target error) bool {
+	return target == ErrFatal
+}
+
+// handleError applies the error policy and returns the error that ends the
+// connection, if it should end.
+func (c *Client) handleError(err error) error {
+	c.stats.error()
+
+	action := ErrorReport
+	if c.errorPolicy != nil {
+		action = c.errorPolicy(err)
+	}
+
+	switch action {
+	case ErrorIgnore:
+		return nil
+	case ErrorRetry:
+		return err
+	case ErrorFatal:
+		return fatalError{err}
+	}
+
+	c.onError(err)
+	return nil
+}
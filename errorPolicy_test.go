@@ -0,0 +1,117 @@
+package twitch_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+)
+
+// newUnknownEventServer welcomes, sends a notification of an unknown
+// subscription type, and then a keepalive.
+func newUnknownEventServer(t *testing.T, connections *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		connections.Add(1)
+
+		welcome := twitch.WelcomeMessage{Metadata: newMetadata("session_welcome")}
+		welcome.Payload.Session.ID = "session"
+		data, _ := json.Marshal(welcome)
+		conn.Write(r.Context(), websocket.MessageText, data)
+		conn.Write(r.Context(), websocket.MessageText, []byte(`{"metadata":{"message_type":"notification"},"payload":{"subscription":{"type":"channel.unknown"},"event":{}}}`))
+		data, _ = json.Marshal(twitch.KeepAliveMessage{Metadata: newMetadata("session_keepalive")})
+		conn.Write(r.Context(), websocket.MessageText, data)
+
+		conn.Read(r.Context())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func unknownSubscriptionPolicy(action twitch.ErrorAction) twitch.ErrorPolicy {
+	return func(err error) twitch.ErrorAction {
+		if errors.Is(err, twitch.ErrUnknownSubscriptionType) {
+			return action
+		}
+		return twitch.ErrorReport
+	}
+}
+
+func TestErrorPolicyIgnore(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := newUnknownEventServer(t, &connections)
+
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithErrorPolicy(unknownSubscriptionPolicy(twitch.ErrorIgnore)))
+	client.OnError(func(err error) {
+		t.Errorf("client registered an error: %v", err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	client.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		client.Close()
+	})
+
+	err := client.Run(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestErrorPolicyFatal(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := newUnknownEventServer(t, &connections)
+
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithErrorPolicy(unknownSubscriptionPolicy(twitch.ErrorFatal)))
+	client.OnError(func(err error) {
+		t.Errorf("client registered an error: %v", err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	client.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		t.Error("connection was kept after a fatal error")
+	})
+
+	err := client.Run(context.Background())
+	assert.ErrorIs(t, err, twitch.ErrFatal)
+	assert.ErrorIs(t, err, twitch.ErrUnknownSubscriptionType)
+	assert.Equal(t, int32(1), connections.Load())
+}
+
+func TestErrorPolicyRetry(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := newUnknownEventServer(t, &connections)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithErrorPolicy(unknownSubscriptionPolicy(twitch.ErrorRetry)))
+	var errs []error
+	client.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		if connections.Load() == 2 {
+			cancel()
+		}
+	})
+
+	err := client.Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), connections.Load())
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], twitch.ErrUnknownSubscriptionType)
+	}
+}
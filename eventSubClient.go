@@ -0,0 +1,32 @@
+package twitch
+
+import "context"
+
+// EventSubClient is the part of Client that applications use. Code that
+// depends on it instead of *Client can be tested with fakeclient.
+type EventSubClient interface {
+	EventRegistrar
+
+	OnError(callback func(err error))
+	OnWelcome(callback func(message WelcomeMessage))
+	OnKeepAlive(callback func(message KeepAliveMessage))
+	OnNotification(callback func(message NotificationMessage))
+	OnReconnect(callback func(message ReconnectMessage))
+	OnRevoke(callback func(message RevokeMessage))
+	OnRevocation(callback func(revocation Revocation))
+	OnRawEvent(callback func(event string, metadata MessageMetadata, subscription PayloadSubscription))
+	AddListener(listener func(message NotificationMessage, event any)) HandlerID
+	Off(id HandlerID) bool
+
+	Connect() error
+	ConnectWithContext(ctx context.Context) error
+	Run(ctx context.Context) error
+	Close() error
+	CloseWithContext(ctx context.Context) error
+
+	Subscribe(request SubscribeRequest) (SubscribeResponse, error)
+	SubscribeWithContext(ctx context.Context, request SubscribeRequest) (SubscribeResponse, error)
+	SessionID() string
+}
+
+var _ EventSubClient = (*Client)(nil)
@@ -1,7 +1,6 @@
 package twitch
 
 import (
-	"math"
 	"time"
 )
 
@@ -17,6 +16,10 @@ type Broadcaster struct {
 	BroadcasterUserName  string `json:"broadcaster_user_name"`
 }
 
+func (b Broadcaster) broadcasterUserID() string {
+	return b.BroadcasterUserId
+}
+
 type Moderator struct {
 	ModeratorUserId    string `json:"moderator_user_id"`
 	ModeratorUserLogin string `json:"moderator_user_login"`
@@ -77,47 +80,26 @@ type Warning struct {
 	ChatRulesCited []string `json:"chat_rules_cited"`
 }
 
-type EventChannelUpdate struct {
-	Broadcaster
-
-	Title                       string   `json:"title"`
-	Language                    string   `json:"language"`
-	CategoryID                  string   `json:"category_id"`
-	CategoryName                string   `json:"category_name"`
-	ContentClassificationLabels []string `json:"content_classification_labels"`
-}
-
-type EventChannelFollow struct {
-	User
-	Broadcaster
-
-	FollowedAt time.Time `json:"followed_at"`
-}
-
-type EventChannelSubscribe struct {
-	User
-	Broadcaster
-
-	Tier   string `json:"tier"`
-	IsGift bool   `json:"is_gift"`
-}
-
-type EventChannelSubscriptionEnd struct {
-	User
-	Broadcaster
-
-	Tier   string `json:"tier"`
-	IsGift bool   `json:"is_gift"`
+// upgrade converts the event into the default version for the
+// OnEventChannelUpdate callback. Version 1 has no content classification
+// labels, so IsMature is only available to listeners.
+func (e EventChannelUpdateV1) upgrade() EventChannelUpdate {
+	return EventChannelUpdate{
+		Broadcaster:  e.Broadcaster,
+		Title:        e.Title,
+		Language:     e.Language,
+		CategoryID:   e.CategoryID,
+		CategoryName: e.CategoryName,
+	}
 }
 
-type EventChannelSubscriptionGift struct {
-	User
-	Broadcaster
-
-	Total           int    `json:"total"`
-	Tier            string `json:"tier"`
-	CumulativeTotal int    `json:"cumulative_total"`
-	IsAnonymous     bool   `json:"is_anonymous"`
+// Gifter returns the user who gifted the subscriptions, or nil when the gift
+// is anonymous.
+func (e EventChannelSubscriptionGift) Gifter() *User {
+	if e.IsAnonymous {
+		return nil
+	}
+	return &e.User
 }
 
 type Emote struct {
@@ -131,61 +113,37 @@ type Message struct {
 	Emotes []Emote `json:"emotes"`
 }
 
-type EventChannelSubscriptionMessage struct {
-	User
-	Broadcaster
-
-	Tier             string  `json:"tier"`
-	Message          Message `json:"message"`
-	CumulativeMonths int     `json:"cumulative_months"`
-	StreakMonths     int     `json:"streak_months"`
-	DurationMonths   int     `json:"duration_months"`
+// Cheerer returns the user who cheered, or nil when the cheer is anonymous.
+func (e EventChannelCheer) Cheerer() *User {
+	if e.IsAnonymous {
+		return nil
+	}
+	return &e.User
 }
 
-type EventChannelCheer struct {
-	User
-	Broadcaster
-
-	Message     string `json:"message"`
-	Bits        int    `json:"bits"`
-	IsAnonymous bool   `json:"is_anonymous"`
+// IsTimeout reports whether the ban is a timeout that ends at EndsAt.
+func (e EventChannelBan) IsTimeout() bool {
+	return !e.IsPermanent
 }
 
-type EventChannelRaid struct {
-	FromBroadcasterUserId    string `json:"from_broadcaster_user_id"`
-	FromBroadcasterUserLogin string `json:"from_broadcaster_user_login"`
-	FromBroadcasterUserName  string `json:"from_broadcaster_user_name"`
-	ToBroadcasterUserId      string `json:"to_broadcaster_user_id"`
-	ToBroadcasterUserLogin   string `json:"to_broadcaster_user_login"`
-	ToBroadcasterUserName    string `json:"to_broadcaster_user_name"`
-	Viewers                  int    `json:"viewers"`
+// BannedTime returns BannedAt as a time, or the zero time if it is not set.
+func (e EventChannelBan) BannedTime() time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, e.BannedAt)
+	return t
 }
 
-type EventChannelBan struct {
-	User
-	Broadcaster
-	Moderator
-
-	Reason      string `json:"reason"`
-	BannedAt    string `json:"banned_at"`
-	EndsAt      string `json:"ends_at"`
-	IsPermanent bool   `json:"is_permanent"`
+// EndTime returns EndsAt as a time, or the zero time for permanent bans.
+func (e EventChannelBan) EndTime() time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, e.EndsAt)
+	return t
 }
 
-type EventChannelUnban struct {
-	User
-	Broadcaster
-	Moderator
-}
-
-type EventChannelModeratorAdd struct {
-	Broadcaster
-	User
-}
-
-type EventChannelModeratorRemove struct {
-	Broadcaster
-	User
+// Duration returns how long a timeout lasts, or 0 for permanent bans.
+func (e EventChannelBan) Duration() time.Duration {
+	if e.IsPermanent || e.EndTime().IsZero() || e.BannedTime().IsZero() {
+		return 0
+	}
+	return e.EndTime().Sub(e.BannedTime())
 }
 
 type MaxChannelPointsPerStream struct {
@@ -204,32 +162,6 @@ type GlobalCooldown struct {
 	Seconds   int  `json:"seconds"`
 }
 
-type EventChannelChannelPointsCustomRewardAdd struct {
-	Broadcaster
-
-	ID                                string                    `json:"id"`
-	IsEnabled                         bool                      `json:"is_enabled"`
-	IsPaused                          bool                      `json:"is_paused"`
-	IsInStock                         bool                      `json:"is_in_stock"`
-	Title                             string                    `json:"title"`
-	Cost                              int                       `json:"cost"`
-	Prompt                            string                    `json:"prompt"`
-	IsUserInputRequired               bool                      `json:"is_user_input_required"`
-	ShouldRedemptionsSkipRequestQueue bool                      `json:"should_redemptions_skip_request_queue"`
-	MaxPerStream                      MaxChannelPointsPerStream `json:"max_per_stream"`
-	MaxPerUserPerStream               MaxChannelPointsPerStream `json:"max_per_user_per_stream"`
-	BackgroundColor                   string                    `json:"background_color"`
-	Image                             Image                     `json:"image"`
-	DefaultImage                      Image                     `json:"default_image"`
-	GlobalCooldown                    GlobalCooldown            `json:"global_cooldown"`
-	CooldownExpiresAt                 time.Time                 `json:"cooldown_expires_at"`
-	RedemptionsRedeemedCurrentStream  int                       `json:"redemptions_redeemed_current_stream"`
-}
-
-type EventChannelChannelPointsCustomRewardUpdate EventChannelChannelPointsCustomRewardAdd
-
-type EventChannelChannelPointsCustomRewardRemove EventChannelChannelPointsCustomRewardAdd
-
 type ChannelPointReward struct {
 	ID     string `json:"id"`
 	Title  string `json:"title"`
@@ -237,19 +169,6 @@ type ChannelPointReward struct {
 	Prompt string `json:"prompt"`
 }
 
-type EventChannelChannelPointsCustomRewardRedemptionAdd struct {
-	Broadcaster
-	User
-
-	ID         string             `json:"id"`
-	UserInput  string             `json:"user_input"`
-	Status     string             `json:"status"`
-	Reward     ChannelPointReward `json:"reward"`
-	RedeemedAt time.Time          `json:"redeemed_at"`
-}
-
-type EventChannelChannelPointsCustomRewardRedemptionUpdate EventChannelChannelPointsCustomRewardRedemptionAdd
-
 type PollChoice struct {
 	ID                string `json:"id"`
 	Title             string `json:"title"`
@@ -263,26 +182,6 @@ type PollVoting struct {
 	AmountPerVote int  `json:"amount_per_vote"`
 }
 
-type EventChannelPollBegin struct {
-	Broadcaster
-
-	ID                  string       `json:"id"`
-	Title               string       `json:"title"`
-	Choices             []PollChoice `json:"choices"`
-	BitsVoting          PollVoting   `json:"bits_voting"`
-	ChannelPointsVoting PollVoting   `json:"channel_points_voting"`
-	StartedAt           time.Time    `json:"started_at"`
-	EndsAt              time.Time    `json:"ends_at"`
-}
-
-type EventChannelPollProgress EventChannelPollBegin
-
-type EventChannelPollEnd struct {
-	EventChannelPollBegin
-
-	Status string `json:"status"`
-}
-
 type TopPredictor struct {
 	User
 
@@ -299,32 +198,6 @@ type PredictionOutcome struct {
 	TopPredictors []TopPredictor `json:"top_predictors"`
 }
 
-type EventChannelPredictionBegin struct {
-	Broadcaster
-
-	ID        string              `json:"id"`
-	Title     string              `json:"title"`
-	Outcomes  []PredictionOutcome `json:"outcomes"`
-	StartedAt time.Time           `json:"started_at"`
-	LocksAt   time.Time           `json:"locks_at"`
-}
-
-type EventChannelPredictionProgress EventChannelPredictionBegin
-
-type EventChannelPredictionLock EventChannelPredictionBegin
-
-type EventChannelPredictionEnd struct {
-	Broadcaster
-
-	ID               string              `json:"id"`
-	Title            string              `json:"title"`
-	WinningOutcomeID string              `json:"winning_outcome_id"`
-	Outcomes         []PredictionOutcome `json:"outcomes"`
-	Status           string              `json:"status"`
-	StartedAt        time.Time           `json:"started_at"`
-	EndedAt          time.Time           `json:"ended_at"`
-}
-
 type DropEntitlement struct {
 	User
 
@@ -337,118 +210,74 @@ type DropEntitlement struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
-type EventDropEntitlementGrant struct {
-	ID   string          `json:"id"`
-	Data DropEntitlement `json:"data"`
-}
-
 type ExtensionProduct struct {
-	Name          string `json:"name"`
+	Name string `json:"name"`
+	// Bits is zero for products in development.
 	Bits          int    `json:"bits"`
 	SKU           string `json:"sku"`
 	InDevelopment bool   `json:"in_development"`
 }
 
-type EventExtensionBitsTransactionCreate struct {
-	Broadcaster
-	User
-
-	ID                string           `json:"id"`
-	ExtensionClientID string           `json:"extension_client_id"`
-	Product           ExtensionProduct `json:"product"`
+// Bits returns how many bits the user spent on the product.
+func (e EventExtensionBitsTransactionCreate) Bits() int {
+	return e.Product.Bits
 }
 
-type EventChannelGoalBegin struct {
-	Broadcaster
-
-	ID                 string    `json:"id"`
-	CharityName        string    `json:"charity_name"`
-	CharityDescription string    `json:"charity_description"`
-	CharityLogo        string    `json:"charity_logo"`
-	CharityWebsite     string    `json:"charity_website"`
-	CurrentAmount      int       `json:"current_amount"`
-	TargetAmount       int       `json:"target_amount"`
-	StoppedAt          time.Time `json:"stopped_at"`
+// SKU returns the SKU of the product that was bought.
+func (e EventExtensionBitsTransactionCreate) SKU() string {
+	return e.Product.SKU
 }
 
-type EventChannelGoalProgress EventChannelGoalBegin
-
-type EventChannelGoalEnd EventChannelGoalBegin
-
-type HypeTrainContribution struct {
-	User
-
-	Type  string `json:"type"`
-	Total int    `json:"total"`
+// PercentComplete returns how far the goal is along from 0 to 100, which is
+// more than 100 once the goal was exceeded.
+func (e EventChannelGoalBegin) PercentComplete() float64 {
+	return goalPercent(e.CurrentAmount, e.TargetAmount)
 }
 
-type EventChannelHypeTrainBegin struct {
-	Broadcaster
-
-	Id               string                  `json:"id"`
-	Total            int                     `json:"total"`
-	Progress         int                     `json:"progress"`
-	Goal             int                     `json:"goal"`
-	TopContributions []HypeTrainContribution `json:"top_contributions"`
-	LastContribution HypeTrainContribution   `json:"last_contribution"`
-	Level            int                     `json:"level"`
-	StartedAt        time.Time               `json:"started_at"`
-	ExpiresAt        time.Time               `json:"expires_at"`
+// Remaining returns how much is left to reach the goal, or 0 once reached.
+func (e EventChannelGoalBegin) Remaining() int {
+	return goalRemaining(e.CurrentAmount, e.TargetAmount)
 }
 
-type EventChannelHypeTrainProgress struct {
-	EventChannelHypeTrainBegin
-
-	Level int `json:"level"`
+func (e EventChannelGoalProgress) PercentComplete() float64 {
+	return goalPercent(e.CurrentAmount, e.TargetAmount)
 }
 
-type EventChannelHypeTrainEnd struct {
-	Broadcaster
-
-	Id               string                  `json:"id"`
-	Level            int                     `json:"level"`
-	Total            int                     `json:"total"`
-	TopContributions []HypeTrainContribution `json:"top_contributions"`
-	StartedAt        time.Time               `json:"started_at"`
-	ExpiresAt        time.Time               `json:"expires_at"`
-	CooldownEndsAt   time.Time               `json:"cooldown_ends_at"`
+func (e EventChannelGoalProgress) Remaining() int {
+	return goalRemaining(e.CurrentAmount, e.TargetAmount)
 }
 
-type EventStreamOnline struct {
-	Broadcaster
-
-	Id        string    `json:"id"`
-	Type      string    `json:"type"`
-	StartedAt time.Time `json:"started_at"`
+func (e EventChannelGoalEnd) PercentComplete() float64 {
+	return goalPercent(e.CurrentAmount, e.TargetAmount)
 }
 
-type EventStreamOffline Broadcaster
-
-type EventUserAuthorizationGrant struct {
-	User
+func (e EventChannelGoalEnd) Remaining() int {
+	return goalRemaining(e.CurrentAmount, e.TargetAmount)
+}
 
-	ClientID string `json:"client_id"`
+func goalPercent(current, target int) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return float64(current) / float64(target) * 100
 }
 
-type EventUserAuthorizationRevoke EventUserAuthorizationGrant
+func goalRemaining(current, target int) int {
+	if current >= target {
+		return 0
+	}
+	return target - current
+}
 
-type EventUserUpdate struct {
+type HypeTrainContribution struct {
 	User
 
-	Email         string `json:"email"`
-	EmailVerified bool   `json:"email_verified"`
-	Description   string `json:"description"`
-}
-
-type GoalAmount struct {
-	Value         int    `json:"value"`
-	DecimalPlaces int    `json:"decimal_places"`
-	Currency      string `json:"currency"`
+	Type  string `json:"type"`
+	Total int    `json:"total"`
 }
 
-func (a GoalAmount) Amount() float64 {
-	return float64(a.Value) / math.Pow10(a.DecimalPlaces)
-}
+// GoalAmount is the old name of Money.
+type GoalAmount = Money
 
 type BaseCharity struct {
 	Broadcaster
@@ -460,90 +289,10 @@ type BaseCharity struct {
 	CharityWebsite     string `json:"charity_website"`
 }
 
-type EventChannelCharityCampaignDonate struct {
-	BaseCharity
-
-	Amount GoalAmount `json:"amount"`
-}
-
-type EventChannelCharityCampaignProgress struct {
-	BaseCharity
-
-	CurrentAmount GoalAmount `json:"current_amount"`
-	TargetAmount  GoalAmount `json:"target_amount"`
-}
-
-type EventChannelCharityCampaignStart struct {
-	EventChannelCharityCampaignProgress
-
-	StartedAt time.Time `json:"started_at"`
-}
-
-type EventChannelCharityCampaignStop struct {
-	EventChannelCharityCampaignProgress
-
-	StoppedAt time.Time `json:"stopped_at"`
-}
-
-type EventChannelShieldModeBegin struct {
-	Broadcaster
-	Moderator
-
-	StartedAt time.Time `json:"started_at"`
-	StoppedAt time.Time `json:"stopped_at"`
-}
-
-type EventChannelShieldModeEnd EventChannelShieldModeBegin
-
-type EventChannelShoutoutCreate struct {
-	Broadcaster
-	Moderator
-
-	ToBroadcasterUserId    string    `json:"to_broadcaster_user_id"`
-	ToBroadcasterUserLogin string    `json:"to_broadcaster_user_login"`
-	ToBroadcasterUserName  string    `json:"to_broadcaster_user_name"`
-	StartedAt              time.Time `json:"started_at"`
-	ViewerCount            int       `json:"viewer_count"`
-	CooldownEndsAt         time.Time `json:"cooldown_ends_at"`
-	TargetCooldownEndsAt   time.Time `json:"target_cooldown_ends_at"`
-}
-
-type EventChannelShoutoutReceive struct {
-	Broadcaster
-	Moderator
-
-	FromBroadcasterUserId    string    `json:"from_broadcaster_user_id"`
-	FromBroadcasterUserLogin string    `json:"from_broadcaster_user_login"`
-	FromBroadcasterUserName  string    `json:"from_broadcaster_user_name"`
-	ViewerCount              int       `json:"viewer_count"`
-	StartedAt                time.Time `json:"started_at"`
-}
-
-type EventChannelModerate struct {
-	Broadcaster
-	SourceBroadcaster
-	Moderator
-
-	Action              string          `json:"action"`
-	Followers           *Followers      `json:"followers,omitempty"`
-	Slow                *SlowMode       `json:"slow,omitempty"`
-	Vip                 *User           `json:"vip,omitempty"`
-	Unvip               *User           `json:"unvip,omitempty"`
-	Mod                 *User           `json:"mod,omitempty"`
-	Unmod               *User           `json:"unmod,omitempty"`
-	Ban                 *Ban            `json:"ban,omitempty"`
-	Unban               *User           `json:"unban,omitempty"`
-	Timeout             *Timeout        `json:"timeout,omitempty"`
-	Untimeout           *User           `json:"untimeout,omitempty"`
-	Raid                *Raid           `json:"raid,omitempty"`
-	Unraid              *User           `json:"unraid,omitempty"`
-	Delete              *DeletedMessage `json:"delete,omitempty"`
-	AutomodTerms        *AutomodTerms   `json:"automod_terms,omitempty"`
-	UnbanRequest        *UnbanRequest   `json:"unban_request,omitempty"`
-	Warn                *Warning        `json:"warn,omitempty"`
-	SharedChatBan       *Ban            `json:"shared_chat_ban,omitempty"`
-	SharedChatUnban     *User           `json:"shared_chat_unban,omitempty"`
-	SharedChatTimeout   *Timeout        `json:"shared_chat_timeout,omitempty"`
-	SharedChatuntimeout *User           `json:"shared_chat_untimeout,omitempty"`
-	SharedChatDelete    *DeletedMessage `json:"shared_chat_delete,omitempty"`
+// login is the login of the user in event summaries.
+func (u User) login() string {
+	if u.UserLogin == "" {
+		return "anonymous"
+	}
+	return u.UserLogin
 }
@@ -0,0 +1,58 @@
+//go:build go1.23
+
+package twitch
+
+import (
+	"context"
+	"iter"
+)
+
+const eventsBufferSize = 256
+
+// Events yields every decoded event with the metadata of its notification
+// until ctx is done or the loop is left:
+//
+//	for metadata, event := range client.Events(ctx) {
+//		switch event := event.(type) {
+//		case twitch.EventChannelFollow:
+//			...
+//		}
+//	}
+//
+// The client still has to be connected, for example with Run in another
+// goroutine. Events are yielded in the order they arrived, and once the loop
+// falls 256 events behind the client waits for it before handling more.
+func (c *Client) Events(ctx context.Context) iter.Seq2[MessageMetadata, any] {
+	type item struct {
+		metadata MessageMetadata
+		event    any
+	}
+
+	return func(yield func(MessageMetadata, any) bool) {
+		items := make(chan item, eventsBufferSize)
+		done := make(chan struct{})
+
+		id := c.addListener(func(message NotificationMessage, event any) {
+			select {
+			case items <- item{message.Metadata, event}:
+			case <-done:
+			case <-ctx.Done():
+			}
+		}, true)
+		defer func() {
+			c.removeListener(id)
+			close(done)
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item := <-items:
+				if !yield(item.metadata, item.event) {
+					return
+				}
+			}
+		}
+	}
+}
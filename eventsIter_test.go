@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package twitch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+	// The test events are version 1, which was removed for some types
+	client.OnDeprecation(func(deprecation twitch.Deprecation) {})
+
+	var messages [][]byte
+	for _, event := range []twitch.EventSubscription{twitch.SubStreamOnline, twitch.SubStreamOffline, twitch.SubChannelFollow} {
+		data, _, err := getTestEventData(event)()
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, data...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		// Wait for the iterator to start listening
+		time.Sleep(10 * time.Millisecond)
+		for _, data := range messages {
+			assert.NoError(t, client.HandleMessage(data))
+		}
+	}()
+
+	var events []any
+	for metadata, event := range client.Events(ctx) {
+		assert.Equal(t, "notification", metadata.MessageType)
+		events = append(events, event)
+		if len(events) == 2 {
+			break
+		}
+	}
+
+	if assert.Len(t, events, 2) {
+		assert.IsType(t, twitch.EventStreamOnline{}, events[0])
+		assert.IsType(t, twitch.EventStreamOffline{}, events[1])
+	}
+}
+
+func TestEventsCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for range twitch.NewClient().Events(ctx) {
+		t.Fatal("canceled iterator yielded an event")
+	}
+}
@@ -3,6 +3,7 @@ package twitch
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestGoalAmount(t *testing.T) {
@@ -34,3 +35,69 @@ func TestGoalAmount(t *testing.T) {
 		})
 	}
 }
+
+func TestEventString(t *testing.T) {
+	testCases := []struct {
+		Event    fmt.Stringer
+		Expected string
+	}{
+		{
+			EventChannelFollow{User: User{UserLogin: "alice"}, Broadcaster: Broadcaster{BroadcasterUserLogin: "bob"}},
+			"channel.follow: alice -> bob",
+		},
+		{
+			EventChannelCheer{Broadcaster: Broadcaster{BroadcasterUserLogin: "bob"}, Bits: 100, IsAnonymous: true},
+			"channel.cheer: anonymous cheered 100 bits -> bob",
+		},
+		{
+			EventStreamOnline{Broadcaster: Broadcaster{BroadcasterUserLogin: "bob"}, Type: StreamTypeLive},
+			"stream.online: bob went live",
+		},
+	}
+
+	for _, tc := range testCases {
+		actual := tc.Event.String()
+		if actual != tc.Expected {
+			t.Errorf("expected %q got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestGoalPercentComplete(t *testing.T) {
+	testCases := []struct {
+		Current   int
+		Target    int
+		Percent   float64
+		Remaining int
+	}{
+		{100, 200, 50, 100},
+		{0, 10, 0, 10},
+		{15, 10, 150, 0},
+		{5, 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%d", tc.Current, tc.Target), func(t *testing.T) {
+			goal := EventChannelGoalProgress{CurrentAmount: tc.Current, TargetAmount: tc.Target}
+
+			if percent := goal.PercentComplete(); percent != tc.Percent {
+				t.Errorf("expected %f percent got %f", tc.Percent, percent)
+			}
+			if remaining := goal.Remaining(); remaining != tc.Remaining {
+				t.Errorf("expected %d remaining got %d", tc.Remaining, remaining)
+			}
+		})
+	}
+}
+
+func TestBanDuration(t *testing.T) {
+	permanent := EventChannelBan{BannedAt: "2020-07-15T18:15:11.17106713Z", IsPermanent: true}
+	if permanent.IsTimeout() || permanent.Duration() != 0 || !permanent.EndTime().IsZero() {
+		t.Errorf("permanent ban has duration %v", permanent.Duration())
+	}
+
+	timeout := EventChannelBan{BannedAt: "2020-07-15T18:15:11Z", EndsAt: "2020-07-15T18:25:11Z"}
+	if timeout.Duration() != 10*time.Minute {
+		t.Errorf("expected 10m got %v", timeout.Duration())
+	}
+}
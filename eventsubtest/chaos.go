@@ -0,0 +1,85 @@
+package eventsubtest
+
+import (
+	"bufio"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Chaos makes the server misbehave at random, to check that reconnects,
+// deduplication, and keepalive handling work. Rates are probabilities from 0
+// to 1 that apply to every message the server sends.
+type Chaos struct {
+	// Seed makes the random decisions repeatable.
+	Seed int64
+	// MaxDelay delays each message by up to MaxDelay.
+	MaxDelay time.Duration
+	// TruncateRate cuts messages short so they are not valid json.
+	TruncateRate float64
+	// DuplicateRate sends messages twice.
+	DuplicateRate float64
+	// DropRate drops the TCP connection without a close frame instead of
+	// sending the message.
+	DropRate float64
+}
+
+type ServerOption func(s *Server)
+
+// WithChaos makes the server misbehave as configured by chaos.
+func WithChaos(chaos Chaos) ServerOption {
+	return func(s *Server) {
+		s.chaos = &chaosSource{
+			Chaos: chaos,
+			rand:  rand.New(rand.NewSource(chaos.Seed)),
+		}
+	}
+}
+
+type chaosSource struct {
+	Chaos
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+type fault struct {
+	delay     time.Duration
+	truncate  bool
+	duplicate bool
+	drop      bool
+}
+
+func (c *chaosSource) next() fault {
+	if c == nil {
+		return fault{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var f fault
+	if c.MaxDelay > 0 {
+		f.delay = time.Duration(c.rand.Int63n(int64(c.MaxDelay) + 1))
+	}
+	f.truncate = c.rand.Float64() < c.TruncateRate
+	f.duplicate = c.rand.Float64() < c.DuplicateRate
+	f.drop = c.rand.Float64() < c.DropRate
+	return f
+}
+
+// hijackRecorder keeps the connection the websocket library hijacks, so it
+// can be closed without a close frame.
+type hijackRecorder struct {
+	http.ResponseWriter
+
+	conn net.Conn
+}
+
+func (h *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.ResponseWriter.(http.Hijacker).Hijack()
+	h.conn = conn
+	return conn, rw, err
+}
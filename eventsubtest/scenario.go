@@ -0,0 +1,90 @@
+package eventsubtest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is the script a Server plays. Steps run in order across
+// connections: a Reconnect or Close step ends the turn of the current
+// connection and the next connection continues with the following step.
+type Scenario struct {
+	Name  string `yaml:"name"`
+	Steps []Step `yaml:"steps"`
+}
+
+// Step sends one message after waiting Wait. Exactly one of the message
+// fields should be set.
+type Step struct {
+	Wait time.Duration `yaml:"wait"`
+
+	Welcome      *Welcome      `yaml:"welcome"`
+	KeepAlive    bool          `yaml:"keepalive"`
+	Notification *Notification `yaml:"notification"`
+	Revocation   *Revocation   `yaml:"revocation"`
+	// Reconnect sends a session_reconnect pointing back to the server.
+	Reconnect bool   `yaml:"reconnect"`
+	Close     *Close `yaml:"close"`
+	// Raw is sent as is.
+	Raw string `yaml:"raw"`
+}
+
+type Welcome struct {
+	// KeepaliveTimeoutSeconds defaults to 10.
+	KeepaliveTimeoutSeconds int `yaml:"keepalive_timeout_seconds"`
+}
+
+type Notification struct {
+	Type twitch.EventSubscription `yaml:"type"`
+	// Version defaults to 1.
+	Version   string            `yaml:"version"`
+	Condition map[string]string `yaml:"condition"`
+	// MessageID is random if empty. Repeating an ID simulates a redelivery.
+	MessageID string `yaml:"message_id"`
+	// Event is marshaled to json as the notification's event.
+	Event any `yaml:"event"`
+}
+
+type Revocation struct {
+	Type twitch.EventSubscription `yaml:"type"`
+	// Status defaults to authorization_revoked.
+	Status    twitch.SubscriptionStatus `yaml:"status"`
+	Condition map[string]string         `yaml:"condition"`
+}
+
+type Close struct {
+	// Code is a websocket close code, like 4004 for an expired reconnect.
+	Code   int    `yaml:"code"`
+	Reason string `yaml:"reason"`
+}
+
+// ParseScenario reads a scenario from yaml, where waits are durations like
+// 100ms:
+//
+//	name: reconnect
+//	steps:
+//	  - welcome: {}
+//	  - notification: {type: stream.online, event: {broadcaster_user_id: "1337"}}
+//	  - wait: 100ms
+//	    reconnect: true
+//	  - welcome: {}
+func ParseScenario(data []byte) (Scenario, error) {
+	var scenario Scenario
+	err := yaml.Unmarshal(data, &scenario)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("could not parse scenario: %w", err)
+	}
+	return scenario, nil
+}
+
+func LoadScenario(path string) (Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("could not read scenario: %w", err)
+	}
+	return ParseScenario(data)
+}
@@ -0,0 +1,353 @@
+// Package eventsubtest runs a websocket server that plays scripted scenarios
+// to a client, so reconnects, redeliveries, and keepalive handling can be
+// tested deterministically without Twitch:
+//
+//	server := eventsubtest.NewServer(scenario)
+//	defer server.Close()
+//
+//	client := twitch.NewClientWithUrl(server.URL())
+//	client.SubscriptionUrl = server.SubscriptionURL()
+package eventsubtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"nhooyr.io/websocket"
+)
+
+const defaultKeepaliveTimeout = 10
+
+// Server plays a Scenario to the clients connecting to URL and accepts every
+// subscription request sent to SubscriptionURL.
+type Server struct {
+	server   *httptest.Server
+	scenario Scenario
+	chaos    *chaosSource
+	done     chan struct{}
+
+	mu            sync.Mutex
+	next          int
+	connections   int
+	subscriptions []twitch.SubscriptionRequest
+	err           error
+}
+
+func NewServer(scenario Scenario, options ...ServerOption) *Server {
+	s := &Server{
+		scenario: scenario,
+		done:     make(chan struct{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if len(scenario.Steps) == 0 {
+		close(s.done)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", s.handleWebsocket)
+	mux.HandleFunc("/eventsub/subscriptions", s.handleSubscription)
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// URL is the websocket url to connect clients to.
+func (s *Server) URL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http") + "/ws"
+}
+
+func (s *Server) SubscriptionURL() string {
+	return s.server.URL + "/eventsub/subscriptions"
+}
+
+// Done is closed once every step was played or a step failed.
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error of the step that could not be played, if any.
+func (s *Server) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Connections returns how many websocket connections were accepted.
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections
+}
+
+// Subscriptions returns the subscription requests received so far.
+func (s *Server) Subscriptions() []twitch.SubscriptionRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]twitch.SubscriptionRequest(nil), s.subscriptions...)
+}
+
+func (s *Server) Close() {
+	s.server.CloseClientConnections()
+	s.server.Close()
+}
+
+// errDropped ends the turn of a connection that chaos dropped.
+var errDropped = errors.New("connection dropped")
+
+type connection struct {
+	ws        *websocket.Conn
+	net       net.Conn
+	sessionID string
+}
+
+func (s *Server) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	hijack := &hijackRecorder{ResponseWriter: w}
+	ws, err := websocket.Accept(hijack, r, nil)
+	if err != nil {
+		s.fail(fmt.Errorf("could not accept websocket: %w", err))
+		return
+	}
+	defer ws.Close(websocket.StatusNormalClosure, "")
+
+	s.mu.Lock()
+	s.connections++
+	s.mu.Unlock()
+
+	ctx := ws.CloseRead(r.Context())
+	conn := &connection{
+		ws:        ws,
+		net:       hijack.conn,
+		sessionID: strings.ReplaceAll(uuid.NewString(), "-", ""),
+	}
+	for {
+		i, step, ok := s.peek()
+		if !ok {
+			<-ctx.Done()
+			return
+		}
+
+		select {
+		case <-time.After(step.Wait):
+		case <-ctx.Done():
+			return
+		}
+
+		s.advance()
+		ended, err := s.play(ctx, conn, step)
+		if errors.Is(err, errDropped) {
+			return
+		}
+		if err != nil {
+			s.fail(fmt.Errorf("could not play step %d: %w", i, err))
+			return
+		}
+		if ended {
+			<-ctx.Done()
+			return
+		}
+	}
+}
+
+func (s *Server) peek() (int, Step, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil || s.next >= len(s.scenario.Steps) {
+		return 0, Step{}, false
+	}
+	return s.next, s.scenario.Steps[s.next], true
+}
+
+func (s *Server) advance() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next++
+	if s.next == len(s.scenario.Steps) {
+		close(s.done)
+	}
+}
+
+func (s *Server) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return
+	}
+	s.err = err
+	if s.next < len(s.scenario.Steps) {
+		close(s.done)
+	}
+}
+
+// play sends the message of the step and reports whether it ended the turn
+// of the connection.
+func (s *Server) play(ctx context.Context, conn *connection, step Step) (bool, error) {
+	switch {
+	case step.Welcome != nil:
+		timeout := step.Welcome.KeepaliveTimeoutSeconds
+		if timeout == 0 {
+			timeout = defaultKeepaliveTimeout
+		}
+
+		var message twitch.WelcomeMessage
+		message.Metadata = metadata("session_welcome", "")
+		message.Payload.Session = twitch.PayloadSession{
+			ID:                      conn.sessionID,
+			Status:                  "connected",
+			ConnectedAt:             time.Now(),
+			KeepaliveTimeoutSeconds: timeout,
+		}
+		return false, s.write(ctx, conn, message)
+	case step.KeepAlive:
+		return false, s.write(ctx, conn, twitch.KeepAliveMessage{Metadata: metadata("session_keepalive", "")})
+	case step.Notification != nil:
+		return false, s.notify(ctx, conn, *step.Notification)
+	case step.Revocation != nil:
+		status := step.Revocation.Status
+		if status == "" {
+			status = twitch.SubscriptionStatusAuthorizationRevoked
+		}
+
+		var message twitch.RevokeMessage
+		message.Metadata = metadata("revocation", "")
+		message.Payload.Subscription = subscription(step.Revocation.Type, "1", step.Revocation.Condition, conn.sessionID)
+		message.Payload.Subscription.Status = status
+		return false, s.write(ctx, conn, message)
+	case step.Reconnect:
+		var message twitch.ReconnectMessage
+		message.Metadata = metadata("session_reconnect", "")
+		message.Payload.Session = twitch.PayloadSession{
+			ID:           conn.sessionID,
+			Status:       "reconnecting",
+			ConnectedAt:  time.Now(),
+			ReconnectUrl: s.URL(),
+		}
+		return true, s.write(ctx, conn, message)
+	case step.Close != nil:
+		conn.ws.Close(websocket.StatusCode(step.Close.Code), step.Close.Reason)
+		return true, nil
+	case step.Raw != "":
+		return false, s.send(ctx, conn, []byte(step.Raw))
+	}
+	return false, fmt.Errorf("step has no message")
+}
+
+func (s *Server) notify(ctx context.Context, conn *connection, notification Notification) error {
+	version := notification.Version
+	if version == "" {
+		version = "1"
+	}
+
+	event, err := json.Marshal(notification.Event)
+	if err != nil {
+		return fmt.Errorf("could not marshal %s event: %w", notification.Type, err)
+	}
+	raw := json.RawMessage(event)
+
+	var message twitch.NotificationMessage
+	message.Metadata = metadata("notification", notification.MessageID)
+	message.Payload.Subscription = subscription(notification.Type, version, notification.Condition, conn.sessionID)
+	message.Payload.Event = &raw
+	return s.write(ctx, conn, message)
+}
+
+func (s *Server) handleSubscription(w http.ResponseWriter, r *http.Request) {
+	var request twitch.SubscriptionRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, request)
+	s.mu.Unlock()
+
+	created := twitch.PayloadSubscription{
+		SubscriptionRequest: request,
+		ID:                  uuid.NewString(),
+		Status:              twitch.SubscriptionStatusEnabled,
+		CreateAt:            time.Now(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(twitch.SubscribeResponse{Data: []twitch.PayloadSubscription{created}, Total: 1})
+}
+
+func metadata(messageType, messageID string) twitch.MessageMetadata {
+	if messageID == "" {
+		messageID = uuid.NewString()
+	}
+	return twitch.MessageMetadata{
+		MessageID:        messageID,
+		MessageType:      messageType,
+		MessageTimestamp: time.Now(),
+	}
+}
+
+func subscription(subType twitch.EventSubscription, version string, condition map[string]string, sessionID string) twitch.PayloadSubscription {
+	return twitch.PayloadSubscription{
+		SubscriptionRequest: twitch.SubscriptionRequest{
+			Type:      subType,
+			Version:   version,
+			Condition: condition,
+			Transport: twitch.SubscriptionTransport{Method: "websocket", SessionID: sessionID},
+		},
+		ID:       uuid.NewString(),
+		Status:   twitch.SubscriptionStatusEnabled,
+		CreateAt: time.Now(),
+	}
+}
+
+func (s *Server) write(ctx context.Context, conn *connection, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("could not marshal message: %w", err)
+	}
+	return s.send(ctx, conn, data)
+}
+
+// send writes data to the connection, after applying the configured chaos.
+func (s *Server) send(ctx context.Context, conn *connection, data []byte) error {
+	fault := s.chaos.next()
+	if fault.delay > 0 {
+		select {
+		case <-time.After(fault.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if fault.drop {
+		conn.net.Close()
+		return errDropped
+	}
+	if fault.truncate {
+		data = data[:len(data)/2]
+	}
+
+	writes := 1
+	if fault.duplicate {
+		writes = 2
+	}
+	for i := 0; i < writes; i++ {
+		err := conn.ws.Write(ctx, websocket.MessageText, data)
+		if err != nil {
+			return fmt.Errorf("could not write message: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package eventsubtest_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/eventsubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const reconnectScenario = `
+name: reconnect
+steps:
+  - welcome: {}
+  - notification: {type: stream.online, message_id: a, event: {broadcaster_user_id: "1337", type: live}}
+  - wait: 10ms
+    reconnect: true
+  - welcome: {}
+  - notification: {type: stream.offline, message_id: b, event: {broadcaster_user_id: "1337"}}
+  - close: {code: 4004, reason: reconnect grace time expired}
+  - welcome: {}
+  - keepalive: true
+`
+
+func TestScenario(t *testing.T) {
+	t.Parallel()
+
+	scenario, err := eventsubtest.ParseScenario([]byte(reconnectScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "reconnect", scenario.Name)
+	assert.Equal(t, 10*time.Millisecond, scenario.Steps[2].Wait)
+
+	server := eventsubtest.NewServer(scenario)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var events []string
+	client := twitch.NewClientWithUrl(server.URL())
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, "online")
+	})
+	client.OnEventStreamOffline(func(event twitch.EventStreamOffline) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, "offline")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go client.Run(ctx)
+
+	select {
+	case <-server.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("scenario did not finish: %d connections, %v", server.Connections(), server.Err())
+	}
+	assert.NoError(t, server.Err())
+	assert.Equal(t, 3, server.Connections())
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"online", "offline"}, events)
+}
+
+func TestSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	server := eventsubtest.NewServer(eventsubtest.Scenario{})
+	defer server.Close()
+
+	_, err := twitch.SubscribeEventUrl(twitch.SubscribeRequest{
+		Event:     twitch.SubStreamOnline,
+		Condition: map[string]string{"broadcaster_user_id": "1337"},
+	}, server.SubscriptionURL())
+	assert.NoError(t, err)
+
+	if assert.Len(t, server.Subscriptions(), 1) {
+		assert.Equal(t, twitch.SubStreamOnline, server.Subscriptions()[0].Type)
+	}
+}
+
+func TestChaos(t *testing.T) {
+	t.Parallel()
+
+	online := eventsubtest.Step{Notification: &eventsubtest.Notification{
+		Type:      twitch.SubStreamOnline,
+		MessageID: "a",
+		Event:     map[string]string{"broadcaster_user_id": "1337", "type": "live"},
+	}}
+
+	t.Run("duplicate", func(t *testing.T) {
+		t.Parallel()
+
+		server := eventsubtest.NewServer(eventsubtest.Scenario{Steps: []eventsubtest.Step{
+			{Welcome: &eventsubtest.Welcome{}},
+			online,
+		}}, eventsubtest.WithChaos(eventsubtest.Chaos{DuplicateRate: 1}))
+		defer server.Close()
+
+		var count atomic.Int32
+		client := twitch.NewClientWithUrl(server.URL())
+		client.OnWelcome(func(message twitch.WelcomeMessage) {})
+		client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+			count.Add(1)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go client.Run(ctx)
+
+		assert.Eventually(t, func() bool { return count.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		t.Parallel()
+
+		server := eventsubtest.NewServer(eventsubtest.Scenario{Steps: []eventsubtest.Step{
+			{Welcome: &eventsubtest.Welcome{}},
+		}}, eventsubtest.WithChaos(eventsubtest.Chaos{TruncateRate: 1}))
+		defer server.Close()
+
+		errs := make(chan error, 10)
+		client := twitch.NewClientWithUrl(server.URL())
+		client.OnWelcome(func(message twitch.WelcomeMessage) {})
+		client.OnError(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go client.Run(ctx)
+
+		select {
+		case err := <-errs:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("truncated message did not cause an error")
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		t.Parallel()
+
+		server := eventsubtest.NewServer(eventsubtest.Scenario{Steps: []eventsubtest.Step{
+			{Welcome: &eventsubtest.Welcome{}},
+			{Welcome: &eventsubtest.Welcome{}},
+		}}, eventsubtest.WithChaos(eventsubtest.Chaos{DropRate: 1}))
+		defer server.Close()
+
+		client := twitch.NewClientWithUrl(server.URL())
+		client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go client.Run(ctx)
+
+		select {
+		case <-server.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("scenario did not finish: %d connections, %v", server.Connections(), server.Err())
+		}
+		assert.NoError(t, server.Err())
+		assert.Equal(t, 2, server.Connections())
+	})
+}
@@ -0,0 +1,88 @@
+//go:build js && wasm
+
+// Command wasm is a browser overlay that lists the EventSub events of the
+// broadcaster who authorized it. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o main.wasm ./examples/wasm
+//
+// and serve main.wasm with index.html and wasm_exec.js from the Go
+// installation. The page is opened with the client ID in the query and the
+// user access token in the fragment, which is where Twitch's implicit grant
+// flow puts it: index.html?client_id=<id>#access_token=<token>
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"syscall/js"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+var events = []twitch.EventSubscription{
+	twitch.SubStreamOnline,
+	twitch.SubStreamOffline,
+	twitch.SubChannelFollow,
+	twitch.SubChannelCheer,
+	twitch.SubChannelRaid,
+}
+
+func main() {
+	location := js.Global().Get("location")
+	query, _ := url.ParseQuery(strings.TrimPrefix(location.Get("search").String(), "?"))
+	fragment, _ := url.ParseQuery(strings.TrimPrefix(location.Get("hash").String(), "#"))
+
+	err := run(context.Background(), query.Get("client_id"), fragment.Get("access_token"))
+	if err != nil {
+		show(fmt.Sprintf("ERROR: %v", err))
+	}
+}
+
+func run(ctx context.Context, clientID, token string) error {
+	if clientID == "" || token == "" {
+		return fmt.Errorf("client_id and access_token must be set")
+	}
+
+	info, err := twitch.ValidateScopes(ctx, token, events)
+	if err != nil {
+		return err
+	}
+
+	condition := twitch.Condition{
+		BroadcasterUserID:   info.UserID,
+		ModeratorUserID:     info.UserID,
+		ToBroadcasterUserID: info.UserID,
+	}.Map()
+
+	client := twitch.NewClient()
+	client.OnError(func(err error) {
+		show(fmt.Sprintf("ERROR: %v", err))
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		for _, event := range events {
+			_, err := client.Subscribe(twitch.SubscribeRequest{
+				ClientID:    clientID,
+				AccessToken: token,
+				Event:       event,
+				Condition:   condition,
+			})
+			if err != nil {
+				show(fmt.Sprintf("ERROR: could not subscribe to %s: %v", event, err))
+			}
+		}
+	})
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		show(fmt.Sprint(event))
+	})
+
+	return client.Run(ctx)
+}
+
+func show(text string) {
+	document := js.Global().Get("document")
+	item := document.Call("createElement", "li")
+	item.Set("textContent", text)
+	document.Call("getElementById", "events").Call("prepend", item)
+}
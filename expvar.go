@@ -0,0 +1,46 @@
+package twitch
+
+import (
+	"expvar"
+	"fmt"
+)
+
+var ErrExpvarExists = fmt.Errorf("expvar is already published")
+
+// PublishExpvar publishes the client's Stats with expvar as prefix.messages,
+// prefix.events, prefix.decode_errors, prefix.errors, prefix.reconnects,
+// prefix.queue_depth, prefix.last_message, prefix.latency_ns,
+// prefix.average_latency_ns, prefix.max_latency_ns, and prefix.healthy, so
+// /debug/vars shows them.
+// Variables can't be removed from expvar, so a prefix can only be published
+// once per process.
+func (c *Client) PublishExpvar(prefix string) error {
+	vars := map[string]func(stats Stats) any{
+		"messages":           func(stats Stats) any { return stats.Messages },
+		"events":             func(stats Stats) any { return stats.Events },
+		"decode_errors":      func(stats Stats) any { return stats.DecodeErrors },
+		"errors":             func(stats Stats) any { return stats.Errors },
+		"reconnects":         func(stats Stats) any { return stats.Reconnects },
+		"queue_depth":        func(stats Stats) any { return stats.QueueDepth },
+		"last_message":       func(stats Stats) any { return stats.LastMessage },
+		"latency_ns":         func(stats Stats) any { return stats.Latency },
+		"average_latency_ns": func(stats Stats) any { return stats.AverageLatency },
+		"max_latency_ns":     func(stats Stats) any { return stats.MaxLatency },
+	}
+
+	for name := range vars {
+		if expvar.Get(prefix+"."+name) != nil {
+			return fmt.Errorf("%w: %s.%s", ErrExpvarExists, prefix, name)
+		}
+	}
+	if expvar.Get(prefix+".healthy") != nil {
+		return fmt.Errorf("%w: %s.healthy", ErrExpvarExists, prefix)
+	}
+
+	for name, value := range vars {
+		value := value
+		expvar.Publish(prefix+"."+name, expvar.Func(func() any { return value(c.Stats()) }))
+	}
+	expvar.Publish(prefix+".healthy", expvar.Func(func() any { return c.Healthy() == nil }))
+	return nil
+}
@@ -0,0 +1,55 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	assert.NoError(t, client.PublishExpvar("test_expvar"))
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	assert.Error(t, client.HandleMessage(data[:10]))
+
+	var events map[string]int
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("test_expvar.events").String()), &events))
+	assert.Equal(t, map[string]int{"stream.online": 1}, events)
+
+	assert.Equal(t, "1", expvar.Get("test_expvar.decode_errors").String())
+	assert.Equal(t, "0", expvar.Get("test_expvar.queue_depth").String())
+	assert.Equal(t, "false", expvar.Get("test_expvar.healthy").String())
+
+	err = twitch.NewClient().PublishExpvar("test_expvar")
+	assert.ErrorIs(t, err, twitch.ErrExpvarExists)
+}
+
+func TestPublishExpvarErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, func() ([][]byte, bool, error) {
+		return [][]byte{[]byte(`{}`)}, false, nil
+	})
+	assert.NoError(t, client.PublishExpvar("test_expvar_errors"))
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client.OnError(func(err error) {
+			close(ch)
+			client.Close()
+		})
+
+		go connect(t, client)
+	})
+
+	assert.Equal(t, "1", expvar.Get("test_expvar_errors.errors").String())
+}
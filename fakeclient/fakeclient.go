@@ -0,0 +1,182 @@
+// Package fakeclient implements twitch.EventSubClient without a websocket so
+// application code can be tested with synthetic events.
+//
+//	client := fakeclient.New()
+//	app := NewApp(client) // registers its callbacks
+//	client.Emit(twitch.SubChannelFollow, twitch.EventChannelFollow{...})
+//
+// The OnEvent callbacks and listeners registered on the fake run before Emit
+// returns. Message callbacks like OnWelcome and OnNotification run on their
+// own goroutine like with the real client, so tests have to wait for them.
+package fakeclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const SessionID = "fakeclient-session"
+
+type Client struct {
+	*twitch.Client
+
+	// SubscribeError is returned by Subscribe when set
+	SubscribeError error
+
+	mu            sync.Mutex
+	subscriptions []twitch.SubscribeRequest
+	closed        chan struct{}
+	closeOnce     sync.Once
+}
+
+var _ twitch.EventSubClient = (*Client)(nil)
+
+func New() *Client {
+	return &Client{
+		Client: twitch.NewClient(twitch.WithSyncDispatch()),
+		closed: make(chan struct{}),
+	}
+}
+
+func (c *Client) Connect() error {
+	return c.ConnectWithContext(context.Background())
+}
+
+// ConnectWithContext sends a welcome message for SessionID and blocks until
+// ctx is done or Close is called, returning ctx's error or
+// twitch.ErrClosedByUser like the real client.
+func (c *Client) ConnectWithContext(ctx context.Context) error {
+	var welcome twitch.WelcomeMessage
+	welcome.Metadata = metadata("session_welcome")
+	welcome.Payload.Session = twitch.PayloadSession{
+		ID:                      SessionID,
+		Status:                  "connected",
+		ConnectedAt:             time.Now(),
+		KeepaliveTimeoutSeconds: 10,
+	}
+
+	err := c.Send(welcome)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.closed:
+		return twitch.ErrClosedByUser
+	}
+}
+
+func (c *Client) Run(ctx context.Context) error {
+	err := c.ConnectWithContext(ctx)
+	if errors.Is(err, twitch.ErrClosedByUser) || ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *Client) CloseWithContext(ctx context.Context) error {
+	return c.Close()
+}
+
+func (c *Client) Subscribe(request twitch.SubscribeRequest) (twitch.SubscribeResponse, error) {
+	return c.SubscribeWithContext(context.Background(), request)
+}
+
+// SubscribeWithContext records the request and answers with an enabled
+// subscription, or fails with SubscribeError.
+func (c *Client) SubscribeWithContext(ctx context.Context, request twitch.SubscribeRequest) (twitch.SubscribeResponse, error) {
+	if c.SubscribeError != nil {
+		return twitch.SubscribeResponse{}, c.SubscribeError
+	}
+
+	request.SessionID = c.SessionID()
+
+	c.mu.Lock()
+	c.subscriptions = append(c.subscriptions, request)
+	c.mu.Unlock()
+
+	return twitch.SubscribeResponse{
+		Data:  []twitch.PayloadSubscription{subscription(request.Event, request.VersionOverride, request.Condition)},
+		Total: 1,
+	}, nil
+}
+
+// Subscriptions returns the requests passed to Subscribe.
+func (c *Client) Subscriptions() []twitch.SubscribeRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]twitch.SubscribeRequest(nil), c.subscriptions...)
+}
+
+// Emit delivers the event as a notification of the default version of the
+// subscription type. Only the OnEvent callbacks and listeners are done when
+// it returns.
+func (c *Client) Emit(eventType twitch.EventSubscription, event any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("could not marshal %s event: %w", eventType, err)
+	}
+	raw := json.RawMessage(data)
+
+	var message twitch.NotificationMessage
+	message.Metadata = metadata("notification")
+	message.Payload.Subscription = subscription(eventType, "", nil)
+	message.Payload.Event = &raw
+
+	return c.Send(message)
+}
+
+// Send delivers any message type, like twitch.RevokeMessage, as if it was
+// read from the websocket.
+func (c *Client) Send(message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("could not marshal %T: %w", message, err)
+	}
+	return c.HandleMessage(data)
+}
+
+func metadata(messageType string) twitch.MessageMetadata {
+	return twitch.MessageMetadata{
+		MessageID:        uuid.NewString(),
+		MessageType:      messageType,
+		MessageTimestamp: time.Now(),
+	}
+}
+
+// subscription builds an enabled subscription, of the default version of the
+// type when version is empty.
+func subscription(eventType twitch.EventSubscription, version string, condition map[string]string) twitch.PayloadSubscription {
+	if version == "" {
+		version = "1"
+		if versions := twitch.SubscriptionVersions(eventType); len(versions) > 0 {
+			version = versions[0]
+		}
+	}
+
+	return twitch.PayloadSubscription{
+		SubscriptionRequest: twitch.SubscriptionRequest{
+			Type:      eventType,
+			Version:   version,
+			Condition: condition,
+			Transport: twitch.SubscriptionTransport{Method: "websocket", SessionID: SessionID},
+		},
+		ID:       uuid.NewString(),
+		Status:   "enabled",
+		CreateAt: time.Now(),
+	}
+}
@@ -0,0 +1,96 @@
+package fakeclient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/fakeclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// followCounter is application code that only knows the interface.
+type followCounter struct {
+	follows    []string
+	subscribed chan error
+}
+
+func newFollowCounter(client twitch.EventSubClient) *followCounter {
+	counter := &followCounter{subscribed: make(chan error, 1)}
+
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		_, err := client.Subscribe(twitch.SubscribeRequest{
+			Event:     twitch.SubChannelFollow,
+			Condition: map[string]string{"broadcaster_user_id": "1337"},
+		})
+		counter.subscribed <- err
+	})
+	client.OnEventChannelFollow(func(event twitch.EventChannelFollow) {
+		counter.follows = append(counter.follows, event.UserLogin)
+	})
+	return counter
+}
+
+func TestFakeClient(t *testing.T) {
+	client := fakeclient.New()
+	counter := newFollowCounter(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.Run(ctx)
+	}()
+
+	select {
+	case err := <-counter.subscribed:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("application did not subscribe on welcome")
+	}
+
+	subscriptions := client.Subscriptions()
+	if assert.Len(t, subscriptions, 1) {
+		assert.Equal(t, twitch.SubChannelFollow, subscriptions[0].Event)
+		assert.Equal(t, fakeclient.SessionID, subscriptions[0].SessionID)
+	}
+
+	err := client.Emit(twitch.SubChannelFollow, twitch.EventChannelFollow{User: twitch.User{UserLogin: "cool_user"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cool_user"}, counter.follows)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestSubscribeError(t *testing.T) {
+	client := fakeclient.New()
+	client.SubscribeError = fmt.Errorf("no scopes")
+
+	_, err := client.Subscribe(twitch.SubscribeRequest{Event: twitch.SubStreamOnline})
+	assert.EqualError(t, err, "no scopes")
+	assert.Empty(t, client.Subscriptions())
+}
+
+func TestClose(t *testing.T) {
+	client := fakeclient.New()
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		client.Close()
+	})
+
+	assert.ErrorIs(t, client.Connect(), twitch.ErrClosedByUser)
+	assert.Equal(t, fakeclient.SessionID, client.SessionID())
+}
+
+func TestEmitDefaultVersion(t *testing.T) {
+	client := fakeclient.New()
+	var updates []twitch.EventChannelUpdate
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		updates = append(updates, event)
+	})
+
+	update := twitch.EventChannelUpdate{Title: "chill", ContentClassificationLabels: []string{"Gambling"}}
+	assert.NoError(t, client.Emit(twitch.SubChannelUpdate, update))
+	assert.Equal(t, []twitch.EventChannelUpdate{update}, updates)
+}
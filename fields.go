@@ -0,0 +1,41 @@
+package twitch
+
+type fieldsMapper interface {
+	fields() map[string]any
+}
+
+// EventFields returns the fields of an event by their json keys, through
+// generated accessors instead of reflection, for rule engines and alert
+// templates that handle any event type. Fields of embedded structs like
+// Broadcaster are promoted, other structs are nested maps and lists of
+// structs are []map[string]any, named string types like Tier are plain
+// strings, and times stay time.Time. Drop entitlement grants are listed under
+// "events". It returns nil for values that are not events.
+func EventFields(event any) map[string]any {
+	switch event := event.(type) {
+	case fieldsMapper:
+		return event.fields()
+	case []EventDropEntitlementGrant:
+		return map[string]any{"events": sliceFields(event)}
+	}
+	return nil
+}
+
+func pointerFields[T fieldsMapper](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return (*value).fields()
+}
+
+func sliceFields[T fieldsMapper](values []T) []map[string]any {
+	if values == nil {
+		return nil
+	}
+
+	fields := make([]map[string]any, len(values))
+	for i, value := range values {
+		fields[i] = value.fields()
+	}
+	return fields
+}
@@ -0,0 +1,79 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFields(t *testing.T) {
+	t.Parallel()
+
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(testEvents, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, data := range events {
+		if key == "unknown" {
+			continue
+		}
+
+		subType := twitch.EventSubscription(strings.Split(key, "-")[0])
+		event, err := twitch.DecodeEvent(subType, "", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var expected any = event
+		if grants, ok := event.([]twitch.EventDropEntitlementGrant); ok {
+			expected = map[string]any{"events": grants}
+		}
+
+		// The fields hold the same data encoding/json writes for the event
+		assert.JSONEq(t, marshalString(t, expected), marshalString(t, twitch.EventFields(event)), key)
+	}
+}
+
+func TestEventFieldsValues(t *testing.T) {
+	t.Parallel()
+
+	reason := "spam"
+	fields := twitch.EventFields(twitch.EventChannelModerate{
+		Broadcaster: twitch.Broadcaster{BroadcasterUserLogin: "bob"},
+		Ban:         &twitch.Ban{User: twitch.User{UserLogin: "alice"}, Reason: &reason},
+	})
+	assert.Equal(t, "bob", fields["broadcaster_user_login"])
+	assert.Equal(t, map[string]any{"user_id": "", "user_login": "alice", "user_name": "", "reason": "spam"}, fields["ban"])
+	assert.NotContains(t, fields, "timeout")
+
+	startedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fields = twitch.EventFields(twitch.EventChannelPollEnd{
+		EventChannelPollBegin: twitch.EventChannelPollBegin{
+			Choices:   []twitch.PollChoice{{Title: "yes", Votes: 3}},
+			StartedAt: startedAt,
+		},
+		Status: twitch.PollStatusCompleted,
+	})
+	assert.Equal(t, string(twitch.PollStatusCompleted), fields["status"])
+	assert.Equal(t, startedAt, fields["started_at"])
+	if assert.IsType(t, []map[string]any{}, fields["choices"]) {
+		assert.Equal(t, "yes", fields["choices"].([]map[string]any)[0]["title"])
+	}
+
+	assert.Nil(t, twitch.EventFields("not an event"))
+}
+
+func marshalString(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
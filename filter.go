@@ -0,0 +1,49 @@
+package twitch
+
+import "sync"
+
+// Filter is returned when a callback is registered and narrows down the
+// events passed to it:
+//
+//	client.OnEventChannelCheer(onCheer).Where(func(event twitch.EventChannelCheer) bool {
+//		return event.Bits >= 100
+//	})
+type Filter[T any] struct {
+	mu         sync.RWMutex
+	predicates []func(event T) bool
+}
+
+// Where adds a predicate that events have to match. Events have to match every
+// predicate added.
+func (f *Filter[T]) Where(predicate func(event T) bool) *Filter[T] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.predicates = append(f.predicates, predicate)
+	return f
+}
+
+func (f *Filter[T]) match(event T) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, predicate := range f.predicates {
+		if !predicate(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// wrap returns the callback guarded by the filter, keeping nil callbacks nil
+// so they still count as unset.
+func (f *Filter[T]) wrap(callback func(event T)) func(event T) {
+	if callback == nil {
+		return nil
+	}
+
+	return func(event T) {
+		if f.match(event) {
+			callback(event)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var cheers int
+	filter := client.OnEventChannelCheer(func(event twitch.EventChannelCheer) {
+		cheers++
+	})
+
+	handleEvents(t, client, twitch.SubChannelCheer)
+	assert.Equal(t, 1, cheers)
+
+	filter.Where(func(event twitch.EventChannelCheer) bool {
+		return event.Bits > 1000
+	})
+	handleEvents(t, client, twitch.SubChannelCheer)
+	assert.Equal(t, 1, cheers)
+
+	var anyHypeTrain int
+	client.OnAnyHypeTrainEvent(func(event twitch.HypeTrainEvent) {
+		anyHypeTrain++
+	}).Where(func(event twitch.HypeTrainEvent) bool {
+		_, ok := event.(twitch.EventChannelHypeTrainEnd)
+		return ok
+	})
+
+	handleEvents(t, client, twitch.SubChannelHypeTrainBegin, twitch.SubChannelHypeTrainEnd)
+	assert.Equal(t, 1, anyHypeTrain)
+}
+
+func TestFilterNilCallback(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnEventChannelCheer(nil).Where(func(event twitch.EventChannelCheer) bool { return true })
+
+	handleEvents(t, client, twitch.SubChannelCheer)
+}
@@ -0,0 +1,172 @@
+package twitch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type FragmentType string
+
+const (
+	FragmentTypeText      FragmentType = "text"
+	FragmentTypeEmote     FragmentType = "emote"
+	FragmentTypeCheermote FragmentType = "cheermote"
+	FragmentTypeMention   FragmentType = "mention"
+)
+
+// Fragment is a part of a message text. Exactly one of Emote, Cheermote, and
+// Mention is set for the fragment types of the same name.
+type Fragment struct {
+	Type FragmentType
+	Text string
+
+	Emote     *FragmentEmote
+	Cheermote *FragmentCheermote
+	Mention   *FragmentMention
+}
+
+type FragmentEmote struct {
+	ID string
+	// SetID is empty when the event does not include emote sets.
+	SetID string
+}
+
+type FragmentCheermote struct {
+	Prefix string
+	Bits   int
+	// Tier is the lowest bit amount of the cheermote's image tier, which is
+	// 1, 100, 1000, 5000, or 10000.
+	Tier int
+}
+
+type FragmentMention struct {
+	UserLogin string
+}
+
+// CheermotePrefixes are the prefixes of Twitch's global cheermotes. Channels
+// can have their own, which can be passed to ParseFragments.
+var CheermotePrefixes = []string{
+	"Cheer", "DoodleCheer", "BibleThump", "cheerwhal", "Corgo", "uni",
+	"ShowLove", "Party", "SeemsGood", "Pride", "Kappa", "FrankerZ", "HeyGuys",
+	"DansGame", "EleGiggle", "TriHard", "Kreygasm", "4Head", "SwiftRage",
+	"NotLikeThis", "FailFish", "VoHiYo", "PJSalt", "MrDestructoid", "bday",
+	"RIPCheer", "Shamrock",
+}
+
+var cheermoteTiers = []int{10000, 5000, 1000, 100, 1}
+
+// Fragments splits the message into text, emote, and mention fragments.
+func (m Message) Fragments() []Fragment {
+	return parseFragments(m.Text, m.Emotes, nil)
+}
+
+// Fragments splits the cheer message into text, cheermote, and mention
+// fragments, recognizing the prefixes of CheermotePrefixes.
+func (e EventChannelCheer) Fragments() []Fragment {
+	return ParseFragments(e.Message, CheermotePrefixes...)
+}
+
+// ParseFragments splits text into text, cheermote, and mention fragments.
+// Words made of one of cheermotePrefixes, ignoring case, and a bit amount are
+// cheermotes.
+func ParseFragments(text string, cheermotePrefixes ...string) []Fragment {
+	return parseFragments(text, nil, cheermotePrefixes)
+}
+
+// parseFragments cuts the emotes out of text, whose begin and end are
+// inclusive rune indexes, and splits the rest into words.
+func parseFragments(text string, emotes []Emote, cheermotePrefixes []string) []Fragment {
+	runes := []rune(text)
+
+	emotes = append([]Emote(nil), emotes...)
+	sort.Slice(emotes, func(i, j int) bool { return emotes[i].Begin < emotes[j].Begin })
+
+	var fragments []Fragment
+	position := 0
+	for _, emote := range emotes {
+		if emote.Begin < position || emote.End < emote.Begin || emote.End >= len(runes) {
+			continue
+		}
+
+		fragments = appendWords(fragments, string(runes[position:emote.Begin]), cheermotePrefixes)
+		fragments = append(fragments, Fragment{
+			Type:  FragmentTypeEmote,
+			Text:  string(runes[emote.Begin : emote.End+1]),
+			Emote: &FragmentEmote{ID: emote.ID},
+		})
+		position = emote.End + 1
+	}
+	return appendWords(fragments, string(runes[position:]), cheermotePrefixes)
+}
+
+// appendWords appends the mentions and cheermotes in text, and the text
+// between them merged into text fragments.
+func appendWords(fragments []Fragment, text string, cheermotePrefixes []string) []Fragment {
+	appendText := func(s string) {
+		if s == "" {
+			return
+		}
+		if last := len(fragments) - 1; last >= 0 && fragments[last].Type == FragmentTypeText {
+			fragments[last].Text += s
+			return
+		}
+		fragments = append(fragments, Fragment{Type: FragmentTypeText, Text: s})
+	}
+
+	for text != "" {
+		start := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsSpace(r) })
+		if start < 0 {
+			appendText(text)
+			break
+		}
+		end := strings.IndexFunc(text[start:], unicode.IsSpace)
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+
+		appendText(text[:start])
+		word := text[start:end]
+		if fragment, ok := wordFragment(word, cheermotePrefixes); ok {
+			fragments = append(fragments, fragment)
+		} else {
+			appendText(word)
+		}
+		text = text[end:]
+	}
+	return fragments
+}
+
+func wordFragment(word string, cheermotePrefixes []string) (Fragment, bool) {
+	if login := strings.TrimPrefix(word, "@"); login != word && login != "" {
+		login = strings.TrimRightFunc(login, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' })
+		if login != "" {
+			return Fragment{Type: FragmentTypeMention, Text: word, Mention: &FragmentMention{UserLogin: strings.ToLower(login)}}, true
+		}
+	}
+
+	for _, prefix := range cheermotePrefixes {
+		if len(word) <= len(prefix) || !strings.EqualFold(word[:len(prefix)], prefix) {
+			continue
+		}
+
+		bits, err := strconv.Atoi(word[len(prefix):])
+		if err != nil || bits <= 0 || strings.HasPrefix(word[len(prefix):], "+") {
+			continue
+		}
+		return Fragment{Type: FragmentTypeCheermote, Text: word, Cheermote: &FragmentCheermote{Prefix: prefix, Bits: bits, Tier: cheermoteTier(bits)}}, true
+	}
+	return Fragment{}, false
+}
+
+func cheermoteTier(bits int) int {
+	for _, tier := range cheermoteTiers {
+		if bits >= tier {
+			return tier
+		}
+	}
+	return 1
+}
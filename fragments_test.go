@@ -0,0 +1,58 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFragments(t *testing.T) {
+	t.Parallel()
+
+	fragments := twitch.ParseFragments("hi @Cool_User, cheer100 and Kappa5000 or mp3 @", twitch.CheermotePrefixes...)
+	assert.Equal(t, []twitch.Fragment{
+		{Type: twitch.FragmentTypeText, Text: "hi "},
+		{Type: twitch.FragmentTypeMention, Text: "@Cool_User,", Mention: &twitch.FragmentMention{UserLogin: "cool_user"}},
+		{Type: twitch.FragmentTypeText, Text: " "},
+		{Type: twitch.FragmentTypeCheermote, Text: "cheer100", Cheermote: &twitch.FragmentCheermote{Prefix: "Cheer", Bits: 100, Tier: 100}},
+		{Type: twitch.FragmentTypeText, Text: " and "},
+		{Type: twitch.FragmentTypeCheermote, Text: "Kappa5000", Cheermote: &twitch.FragmentCheermote{Prefix: "Kappa", Bits: 5000, Tier: 5000}},
+		{Type: twitch.FragmentTypeText, Text: " or mp3 @"},
+	}, fragments)
+
+	assert.Equal(t, []twitch.Fragment{{Type: twitch.FragmentTypeText, Text: "cheer100"}}, twitch.ParseFragments("cheer100"))
+	assert.Empty(t, twitch.ParseFragments(""))
+}
+
+func TestMessageFragments(t *testing.T) {
+	t.Parallel()
+
+	message := twitch.Message{
+		Text: "Love it ❤ FevziGG FevziGG!",
+		Emotes: []twitch.Emote{
+			{ID: "302976485", Begin: 18, End: 24},
+			{ID: "302976485", Begin: 10, End: 16},
+			{ID: "outside", Begin: 30, End: 40},
+		},
+	}
+
+	assert.Equal(t, []twitch.Fragment{
+		{Type: twitch.FragmentTypeText, Text: "Love it ❤ "},
+		{Type: twitch.FragmentTypeEmote, Text: "FevziGG", Emote: &twitch.FragmentEmote{ID: "302976485"}},
+		{Type: twitch.FragmentTypeText, Text: " "},
+		{Type: twitch.FragmentTypeEmote, Text: "FevziGG", Emote: &twitch.FragmentEmote{ID: "302976485"}},
+		{Type: twitch.FragmentTypeText, Text: "!"},
+	}, message.Fragments())
+}
+
+func TestCheerFragments(t *testing.T) {
+	t.Parallel()
+
+	cheer := twitch.EventChannelCheer{Message: "Cheer1 Corgo25"}
+	fragments := cheer.Fragments()
+	if assert.Len(t, fragments, 3) {
+		assert.Equal(t, twitch.FragmentCheermote{Prefix: "Cheer", Bits: 1, Tier: 1}, *fragments[0].Cheermote)
+		assert.Equal(t, twitch.FragmentCheermote{Prefix: "Corgo", Bits: 25, Tier: 1}, *fragments[2].Cheermote)
+	}
+}
@@ -0,0 +1,78 @@
+package twitch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const redacted = "[redacted]"
+
+// WithFrameLog logs every message the client handles with debugf, which fits
+// the Debugf method of most loggers, along with its size, message type,
+// subscription type, and message ID. Comparing the log with what Twitch says
+// it sent tells whether a missing event was lost before or after it reached
+// the client.
+//
+// With redact, the IDs, logins, and names of users other than broadcasters
+// and the text users entered are replaced, so logs can be attached to bug
+// reports.
+func WithFrameLog(debugf func(format string, args ...any), redact bool) ClientOption {
+	return func(c *Client) {
+		c.frameLog = debugf
+		c.redactFrames = redact
+	}
+}
+
+func (c *Client) logFrame(data []byte) {
+	var frame struct {
+		Metadata MessageMetadata `json:"metadata"`
+		Payload  struct {
+			Subscription struct {
+				Type EventSubscription `json:"type"`
+			} `json:"subscription"`
+		} `json:"payload"`
+	}
+	err := json.Unmarshal(data, &frame)
+	if err != nil {
+		text := string(data)
+		if c.redactFrames {
+			text = redacted
+		}
+		c.frameLog("eventsub frame: %d bytes, not json: %s", len(data), text)
+		return
+	}
+
+	text := string(data)
+	if c.redactFrames {
+		text = redactFrame(data)
+	}
+
+	description := frame.Metadata.MessageType
+	if subType := frame.Payload.Subscription.Type; subType != "" {
+		description += " " + string(subType)
+	}
+	c.frameLog("eventsub frame: %d bytes, %s %s: %s", len(data), description, frame.Metadata.MessageID, text)
+}
+
+// redactFrame replaces the values of user fields and the text users wrote
+// anywhere in a message.
+func redactFrame(data []byte) string {
+	// Numbers are kept as they were sent instead of going through float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var message any
+	err := dec.Decode(&message)
+	if err != nil {
+		return redacted
+	}
+
+	replaceUsers(message, func(string) string { return redacted })
+	replaceMessageText(message, redacted)
+
+	out, err := json.Marshal(message)
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
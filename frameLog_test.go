@@ -0,0 +1,103 @@
+package twitch_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameLog(t *testing.T) {
+	t.Parallel()
+
+	event := twitch.EventChannelFollow{
+		User:        twitch.User{UserID: "42", UserLogin: "viewer", UserName: "Viewer"},
+		Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337", BroadcasterUserLogin: "streamer"},
+	}
+	data, err := twitch.EncodeNotification(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name     string
+		Redact   bool
+		Contains []string
+		Excludes []string
+	}{
+		{"Plain", false, []string{`"user_login":"viewer"`, `"broadcaster_user_login":"streamer"`}, nil},
+		{"Redacted", true, []string{`"user_login":"[redacted]"`, `"user_id":"[redacted]"`, `"broadcaster_user_login":"streamer"`}, []string{"viewer", "Viewer"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var lines []string
+			debugf := func(format string, args ...any) {
+				lines = append(lines, fmt.Sprintf(format, args...))
+			}
+			client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithFrameLog(debugf, tc.Redact))
+
+			assert.NoError(t, client.HandleMessage(data))
+			if assert.Len(t, lines, 1) {
+				assert.Contains(t, lines[0], fmt.Sprintf("eventsub frame: %d bytes, notification channel.follow", len(data)))
+				for _, text := range tc.Contains {
+					assert.Contains(t, lines[0], text)
+				}
+				for _, text := range tc.Excludes {
+					assert.NotContains(t, lines[0], text)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameLogMessageText(t *testing.T) {
+	t.Parallel()
+
+	cheer := twitch.EventChannelCheer{
+		User:        twitch.User{UserID: "42", UserLogin: "viewer", UserName: "Viewer"},
+		Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"},
+		Message:     "Cheer100 hello from viewer",
+		Bits:        100,
+	}
+	resub := twitch.EventChannelSubscriptionMessage{
+		User:        twitch.User{UserID: "42", UserLogin: "viewer", UserName: "Viewer"},
+		Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"},
+		Message: twitch.Message{
+			Text:   "hello from viewer Kappa",
+			Emotes: []twitch.Emote{{ID: "25", Begin: 18, End: 22}},
+		},
+	}
+
+	for _, event := range []any{cheer, resub} {
+		data, err := twitch.EncodeNotification(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var line string
+		client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithFrameLog(func(format string, args ...any) {
+			line = fmt.Sprintf(format, args...)
+		}, true))
+
+		assert.NoError(t, client.HandleMessage(data))
+		assert.NotContains(t, line, "hello")
+		assert.NotContains(t, line, "emotes")
+	}
+}
+
+func TestFrameLogInvalid(t *testing.T) {
+	t.Parallel()
+
+	var line string
+	client := twitch.NewClient(twitch.WithFrameLog(func(format string, args ...any) {
+		line = fmt.Sprintf(format, args...)
+	}, true))
+
+	assert.Error(t, client.HandleMessage([]byte(`{"user_login":`)))
+	assert.Equal(t, "eventsub frame: 14 bytes, not json: [redacted]", line)
+}
@@ -0,0 +1,70 @@
+package twitch
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Gap is a time range in which events of a subscription may have been missed
+// because the client was disconnected or restarted.
+type Gap struct {
+	Subscription SubscribeRequest
+	From         time.Time
+	To           time.Time
+}
+
+// OnGap is called when a subscription is recreated on a new session, with
+// the range from its last notification, or the last message before the
+// connection was lost if that is later, to when it was recreated. Reconnects
+// requested by Twitch keep the subscriptions and have no gaps.
+func (c *Client) OnGap(callback func(gap Gap)) {
+	c.onGap = callback
+}
+
+// subscriptionKey identifies a subscription by its type and condition.
+func subscriptionKey(event EventSubscription, condition map[string]string) string {
+	keys := make([]string, 0, len(condition))
+	for key, value := range condition {
+		keys = append(keys, key+"="+value)
+	}
+	sort.Strings(keys)
+	return string(event) + "?" + strings.Join(keys, "&")
+}
+
+func (c *Client) trackNotification(subscription PayloadSubscription, timestamp time.Time) {
+	c.lastNotificationsMu.Lock()
+	defer c.lastNotificationsMu.Unlock()
+
+	if c.lastNotifications == nil {
+		c.lastNotifications = map[string]time.Time{}
+	}
+	c.lastNotifications[subscriptionKey(subscription.Type, subscription.Condition)] = timestamp
+}
+
+func (c *Client) lastNotification(event EventSubscription, condition map[string]string) time.Time {
+	c.lastNotificationsMu.Lock()
+	defer c.lastNotificationsMu.Unlock()
+	return c.lastNotifications[subscriptionKey(event, condition)]
+}
+
+// reportGap is called after request was recreated on a new session.
+func (c *Client) reportGap(request SubscribeRequest) {
+	if c.onGap == nil {
+		return
+	}
+
+	from := c.lastNotification(request.Event, request.Condition)
+	if start := c.gapStart.Load(); start != 0 && time.Unix(0, start).After(from) {
+		from = time.Unix(0, start)
+	}
+	if from.IsZero() {
+		return
+	}
+
+	c.onGap(Gap{
+		Subscription: request,
+		From:         from,
+		To:           c.clock.Now(),
+	})
+}
@@ -0,0 +1,51 @@
+package twitch_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnGap(t *testing.T) {
+	t.Parallel()
+
+	server, err := newTestServer(getTestEventData(twitch.SubStreamOnline))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastMessage := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := &memoryStore{state: twitch.SessionState{
+		Subscriptions: []twitch.StoredSubscription{
+			{Event: twitch.SubStreamOnline, Condition: testCondition, LastNotification: lastMessage.Add(-time.Hour)},
+		},
+		LastMessage: lastMessage,
+	}}
+
+	client := twitch.NewClientWithUrl(fmt.Sprintf("http://%s/ws", server.Address), twitch.WithSessionStore(store, twitch.SubscribeRequest{}))
+	client.SubscriptionUrl = fmt.Sprintf("http://%s/subscriptions", server.Address)
+	client.OnError(func(err error) {
+		t.Errorf("client registered an error: %v", err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	gaps := make(chan twitch.Gap, 1)
+	client.OnGap(func(gap twitch.Gap) {
+		gaps <- gap
+	})
+
+	go connect(t, client)
+	defer client.Close()
+
+	select {
+	case gap := <-gaps:
+		assert.Equal(t, twitch.SubStreamOnline, gap.Subscription.Event)
+		assert.Equal(t, lastMessage, gap.From.UTC())
+		assert.True(t, gap.To.After(gap.From))
+	case <-time.After(time.Second):
+		t.Fatal("gap was not reported")
+	}
+}
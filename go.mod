@@ -4,7 +4,10 @@ go 1.19
 
 require (
 	github.com/google/uuid v1.3.0
+	github.com/mattn/go-sqlite3 v1.14.32
 	github.com/stretchr/testify v1.8.1
+	golang.org/x/oauth2 v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
 	nhooyr.io/websocket v1.8.7
 )
 
@@ -12,5 +15,4 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/klauspost/compress v1.10.3 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,145 @@
+package twitch
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of testdata/events")
+
+// TestGoldenEvents decodes every payload in testdata/events, named after its
+// subscription type with an optional -variant suffix, and compares every field
+// of the event with the .golden file next to it. Keys of the payload the
+// event has no field for are listed at the end of the golden file. Run with
+// -update after changing an event struct and review the diff.
+func TestGoldenEvents(t *testing.T) {
+	t.Parallel()
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "events", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	covered := map[EventSubscription]bool{}
+	for _, path := range paths {
+		path := path
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		subType := EventSubscription(strings.Split(name, "-")[0])
+		covered[subType] = true
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			payload, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			metadata, ok := subMetadata[subType]
+			if !ok {
+				t.Fatalf("%s is not a subscription type", subType)
+			}
+			event, err := metadata.Decode(payload, defaultDecoder)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := renderEvent(event)
+			for _, field := range unknownFields(payload, reflect.TypeOf(event)) {
+				actual += fmt.Sprintf("unknown %s\n", field)
+			}
+			goldenPath := strings.TrimSuffix(path, ".json") + ".golden"
+			if *updateGolden {
+				err = os.WriteFile(goldenPath, []byte(actual), 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("could not read golden file, run with -update to create it: %v", err)
+			}
+			assert.Equal(t, string(expected), actual)
+		})
+	}
+
+	for _, subType := range SubscriptionTypes() {
+		assert.True(t, covered[subType], "no payload in testdata/events for %s", subType)
+	}
+}
+
+// renderEvent writes one line per field of the event, with promoted fields of
+// embedded structs under their promoted name.
+func renderEvent(event any) string {
+	var lines []string
+	renderValue(&lines, reflect.TypeOf(event).String(), reflect.ValueOf(event))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func renderValue(lines *[]string, path string, v reflect.Value) {
+	switch {
+	case v.Type() == timeType:
+		*lines = append(*lines, fmt.Sprintf("%s = %s", path, v.Interface().(time.Time).Format(time.RFC3339Nano)))
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			*lines = append(*lines, path+" = nil")
+			return
+		}
+		renderValue(lines, path, v.Elem())
+	case v.Kind() == reflect.Interface:
+		if v.IsNil() {
+			*lines = append(*lines, path+" = nil")
+			return
+		}
+		renderValue(lines, path, v.Elem())
+	case v.Kind() == reflect.Struct:
+		renderStruct(lines, path, v)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Len() == 0 {
+			*lines = append(*lines, path+" = []")
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			renderValue(lines, fmt.Sprintf("%s[%d]", path, i), v.Index(i))
+		}
+	case v.Kind() == reflect.Map:
+		if v.Len() == 0 {
+			*lines = append(*lines, path+" = {}")
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			renderValue(lines, fmt.Sprintf("%s[%q]", path, fmt.Sprint(key)), v.MapIndex(key))
+		}
+	case v.Kind() == reflect.String:
+		*lines = append(*lines, fmt.Sprintf("%s = %q", path, v.String()))
+	default:
+		*lines = append(*lines, fmt.Sprintf("%s = %v", path, v.Interface()))
+	}
+}
+
+func renderStruct(lines *[]string, path string, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && v.Field(i).Kind() == reflect.Struct && field.Type != timeType {
+			renderStruct(lines, path, v.Field(i))
+			continue
+		}
+		renderValue(lines, path+"."+field.Name, v.Field(i))
+	}
+}
@@ -0,0 +1,141 @@
+package grpcproxy
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// The service is written by hand instead of generated from a .proto file, and
+// its messages are JSON objects with the field names of the json tags below.
+// Clients in other languages call /twitch.eventsub.v1.EventSub/StreamEvents
+// with the content-subtype json, that is application/grpc+json, and decode
+// every message of the stream as an Event.
+
+// Codec marshals messages as JSON. It is not registered globally, the server
+// uses it through NewGRPCServer and the client through NewEventSubClient.
+type Codec struct{}
+
+func (Codec) Name() string {
+	return "json"
+}
+
+func (Codec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (Codec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type StreamEventsRequest struct {
+	Types              []string `json:"types,omitempty"`
+	BroadcasterUserIds []string `json:"broadcasterUserIds,omitempty"`
+}
+
+type Event struct {
+	MessageId           string          `json:"messageId"`
+	SubscriptionId      string          `json:"subscriptionId"`
+	SubscriptionType    string          `json:"subscriptionType"`
+	SubscriptionVersion string          `json:"subscriptionVersion"`
+	BroadcasterUserId   string          `json:"broadcasterUserId,omitempty"`
+	Timestamp           time.Time       `json:"timestamp"`
+	Event               json.RawMessage `json:"event"`
+}
+
+type EventSubServer interface {
+	StreamEvents(request *StreamEventsRequest, stream EventSub_StreamEventsServer) error
+}
+
+type EventSub_StreamEventsServer interface {
+	Send(event *Event) error
+	grpc.ServerStream
+}
+
+type eventSubStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (s *eventSubStreamEventsServer) Send(event *Event) error {
+	return s.ServerStream.SendMsg(event)
+}
+
+func streamEventsHandler(srv any, stream grpc.ServerStream) error {
+	request := new(StreamEventsRequest)
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	return srv.(EventSubServer).StreamEvents(request, &eventSubStreamEventsServer{stream})
+}
+
+var EventSub_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "twitch.eventsub.v1.EventSub",
+	HandlerType: (*EventSubServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       streamEventsHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// NewGRPCServer creates a gRPC server that uses Codec for every service on it.
+// An existing server needs grpc.ForceServerCodec(Codec{}) in its options.
+func NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	return grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(Codec{})}, opts...)...)
+}
+
+func RegisterEventSubServer(registrar grpc.ServiceRegistrar, server EventSubServer) {
+	registrar.RegisterService(&EventSub_ServiceDesc, server)
+}
+
+type EventSubClient interface {
+	StreamEvents(ctx context.Context, request *StreamEventsRequest, opts ...grpc.CallOption) (EventSub_StreamEventsClient, error)
+}
+
+type EventSub_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type eventSubClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewEventSubClient creates a client that uses Codec.
+func NewEventSubClient(cc grpc.ClientConnInterface) EventSubClient {
+	return &eventSubClient{cc}
+}
+
+func (c *eventSubClient) StreamEvents(ctx context.Context, request *StreamEventsRequest, opts ...grpc.CallOption) (EventSub_StreamEventsClient, error) {
+	opts = append([]grpc.CallOption{grpc.ForceCodec(Codec{})}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventSub_ServiceDesc.Streams[0], "/twitch.eventsub.v1.EventSub/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &eventSubStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(request); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type eventSubStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventSubStreamEventsClient) Recv() (*Event, error) {
+	event := new(Event)
+	if err := x.ClientStream.RecvMsg(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,121 @@
+// Package grpcproxy serves EventSub notifications received by a twitch.Client
+// to downstream services over a gRPC server stream. Messages are JSON rather
+// than protobuf, so the server has to use Codec:
+//
+//	server := grpcproxy.NewGRPCServer()
+//	grpcproxy.RegisterEventSubServer(server, grpcproxy.NewServer(client))
+package grpcproxy
+
+import (
+	"sync"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const defaultBufferSize = 64
+
+type filter struct {
+	types          map[string]bool
+	broadcasterIDs map[string]bool
+}
+
+func newFilter(request *StreamEventsRequest) filter {
+	f := filter{
+		types:          map[string]bool{},
+		broadcasterIDs: map[string]bool{},
+	}
+	for _, t := range request.Types {
+		f.types[t] = true
+	}
+	for _, id := range request.BroadcasterUserIds {
+		f.broadcasterIDs[id] = true
+	}
+	return f
+}
+
+func (f filter) match(event *Event) bool {
+	if len(f.types) > 0 && !f.types[event.SubscriptionType] {
+		return false
+	}
+	if len(f.broadcasterIDs) > 0 && !f.broadcasterIDs[event.BroadcasterUserId] {
+		return false
+	}
+	return true
+}
+
+type Server struct {
+	streams map[chan *Event]filter
+	mu      sync.Mutex
+
+	// BufferSize is how many events are queued for a slow stream before
+	// newer events are dropped for it.
+	BufferSize int
+}
+
+// NewServer creates a server that streams every event of the client.
+func NewServer(client *twitch.Client) *Server {
+	s := &Server{
+		streams:    map[chan *Event]filter{},
+		BufferSize: defaultBufferSize,
+	}
+
+	if client != nil {
+		client.AddListener(s.Send)
+	}
+	return s
+}
+
+// Send forwards an event to every matching stream.
+func (s *Server) Send(message twitch.NotificationMessage, event any) {
+	subscription := message.Payload.Subscription
+	e := &Event{
+		MessageId:           message.Metadata.MessageID,
+		SubscriptionId:      subscription.ID,
+		SubscriptionType:    string(subscription.Type),
+		SubscriptionVersion: subscription.Version,
+		BroadcasterUserId:   twitch.BroadcasterUserID(message, event),
+		Timestamp:           message.Metadata.MessageTimestamp,
+	}
+	if event := message.RawEvent(); event != nil {
+		e.Event = *event
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch, f := range s.streams {
+		if !f.match(e) {
+			continue
+		}
+
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (s *Server) StreamEvents(request *StreamEventsRequest, stream EventSub_StreamEventsServer) error {
+	ch := make(chan *Event, s.BufferSize)
+	s.mu.Lock()
+	s.streams[ch] = newFilter(request)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.streams, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-ch:
+			err := stream.Send(event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package grpcproxy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/grpcproxy"
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func newMessage(event twitch.EventSubscription, broadcasterID string) twitch.NotificationMessage {
+	raw := json.RawMessage(`{"broadcaster_user_id":"` + broadcasterID + `"}`)
+
+	var message twitch.NotificationMessage
+	message.Metadata.MessageID = "befa7b53-d79d-478f-86b9-120f112b044e"
+	message.Payload.Subscription.Type = event
+	message.Payload.Event = &raw
+	return message
+}
+
+func TestServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := grpcproxy.NewServer(nil)
+	server := grpcproxy.NewGRPCServer()
+	grpcproxy.RegisterEventSubServer(server, proxy)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := grpcproxy.NewEventSubClient(conn).StreamEvents(ctx, &grpcproxy.StreamEventsRequest{
+		Types:              []string{string(twitch.SubStreamOnline)},
+		BroadcasterUserIds: []string{"1337"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for ctx.Err() == nil {
+			proxy.Send(newMessage(twitch.SubStreamOffline, "1337"), twitch.EventStreamOffline{})
+			proxy.Send(newMessage(twitch.SubStreamOnline, "42"), twitch.EventStreamOnline{})
+			proxy.Send(newMessage(twitch.SubStreamOnline, "1337"), twitch.EventStreamOnline{
+				Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"},
+			})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "stream.online", event.SubscriptionType)
+	assert.Equal(t, "1337", event.BroadcasterUserId)
+	assert.JSONEq(t, `{"broadcaster_user_id":"1337"}`, string(event.Event))
+}
@@ -0,0 +1,40 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+)
+
+var ErrSlowHandler = fmt.Errorf("handler exceeded the handler timeout")
+
+func (l listener) name() string {
+	return fmt.Sprintf("listener %d", l.id)
+}
+
+// callHandler runs f and, with WithHandlerTimeout, stops waiting for it once
+// the timeout passes. Go can't stop the handler, so it keeps running with a
+// canceled context.
+func (c *Client) callHandler(subType EventSubscription, name string, f func(ctx context.Context)) {
+	if c.handlerTimeout <= 0 {
+		f(context.Background())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer cancel()
+		defer close(done)
+		f(ctx)
+	}()
+
+	timer := c.clock.NewTimer(c.handlerTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C():
+		cancel()
+		c.warn(fmt.Errorf("%w: %s %s ran longer than %s", ErrSlowHandler, subType, name, c.handlerTimeout))
+	}
+}
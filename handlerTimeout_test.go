@@ -0,0 +1,55 @@
+package twitch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHandlerTimeout(t *testing.T) {
+	t.Parallel()
+
+	data, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithHandlerTimeout(50*time.Millisecond))
+
+	warnings := make(chan error, 2)
+	client.OnWarning(func(err error) {
+		warnings <- err
+	})
+
+	canceled := make(chan struct{})
+	client.AddContextListener(func(ctx context.Context, message twitch.NotificationMessage, event any) {
+		<-ctx.Done()
+		close(canceled)
+	})
+
+	var handled bool
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		handled = true
+	})
+
+	assert.NoError(t, client.HandleMessage(data[0]))
+	assert.True(t, handled, "the handler after the slow listener did not run")
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("listener context was not canceled")
+	}
+
+	select {
+	case err := <-warnings:
+		assert.ErrorIs(t, err, twitch.ErrSlowHandler)
+		assert.Contains(t, err.Error(), "stream.online listener 1")
+	default:
+		t.Fatal("slow listener was not reported")
+	}
+	assert.Empty(t, warnings)
+}
@@ -0,0 +1,29 @@
+package twitch
+
+import "fmt"
+
+var ErrNotConnected = fmt.Errorf("client is not connected")
+
+// Healthy returns nil while the client is connected and has received a
+// message within the session's keepalive timeout, so it can back liveness
+// and readiness probes. While Run is reconnecting within the limits of
+// WithReconnectLimit it returns nil too, so a probe doesn't restart a client
+// that is recovering. After Run gave up reconnecting it returns the
+// ErrReconnectLimit error.
+func (c *Client) Healthy() error {
+	if err := c.gaveUp.Load(); err != nil {
+		return *err
+	}
+
+	if !c.connected.Load() {
+		if c.retrying.Load() {
+			return nil
+		}
+		return ErrNotConnected
+	}
+
+	if c.keepaliveExpired() {
+		return ErrKeepaliveTimeout
+	}
+	return nil
+}
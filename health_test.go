@@ -0,0 +1,66 @@
+package twitch_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthy(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, noDataGen)
+	assert.ErrorIs(t, client.Healthy(), twitch.ErrNotConnected)
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client.OnWelcome(func(message twitch.WelcomeMessage) {
+			assert.NoError(t, client.Healthy())
+			client.Close()
+			close(ch)
+		})
+
+		go connect(t, client)
+	})
+
+	assert.ErrorIs(t, client.Healthy(), twitch.ErrNotConnected)
+}
+
+func TestHealthyWhileReconnecting(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// The clock never advances, so Run waits in its backoff after the
+	// immediate retry
+	clock := clocktest.NewClock(time.Now())
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithClock(clock))
+	client.OnError(func(err error) {})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return connections.Load() == 2
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, client.Healthy())
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.ErrorIs(t, client.Healthy(), twitch.ErrNotConnected)
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var fieldsTemplate = template.Must(template.New("fields").Parse(`// Code generated by internal/generate from subscriptions.json. DO NOT EDIT.
+
+package twitch
+{{ range . }}
+func (e {{ .Name }}) fields() map[string]any {
+	{{ if .Optional }}fields := {{ else }}return {{ end }}map[string]any{
+{{- range .Fields }}
+		"{{ .Key }}": {{ .Value }},
+{{- end }}
+	}
+{{- range .Optional }}
+	if {{ .Cond }} {
+		fields["{{ .Key }}"] = {{ .Value }}
+	}
+{{- end }}
+{{- if .Optional }}
+	return fields
+{{- end }}
+}
+{{ end }}`))
+
+// FieldsType is a struct type that gets a fields method returning its fields
+// by json key, the same ones encoding/json would write.
+type FieldsType struct {
+	Name     string
+	Fields   []FieldValue
+	Optional []FieldValue
+}
+
+// FieldValue is the expression on e of a json key. Cond is set for omitempty
+// fields, which are only added when it holds.
+type FieldValue struct {
+	Key   string
+	Value string
+	Cond  string
+}
+
+// packageTypes holds the type declarations of the twitch package, so the
+// fields of the event structs and the types they embed are known without
+// type checking.
+type packageTypes map[string]ast.Expr
+
+func parsePackageTypes(skip string) (packageTypes, error) {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		return nil, fmt.Errorf("could not list package files: %w", err)
+	}
+
+	types := packageTypes{}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if path == skip || strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", path, err)
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if _, ok := types[spec.Name.Name]; !ok {
+					types[spec.Name.Name] = spec.Type
+				}
+			}
+		}
+	}
+	return types, nil
+}
+
+// underlying follows named types of the package to their definition. Basic
+// types are returned as their identifier.
+func (p packageTypes) underlying(expr ast.Expr) ast.Expr {
+	for {
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			return expr
+		}
+		next, ok := p[ident.Name]
+		if !ok {
+			return expr
+		}
+		expr = next
+	}
+}
+
+func (p packageTypes) structOf(expr ast.Expr) (*ast.StructType, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := p[ident.Name]; !ok {
+		return nil, false
+	}
+	s, ok := p.underlying(ident).(*ast.StructType)
+	return s, ok
+}
+
+type jsonField struct {
+	Key       string
+	Name      string
+	Type      ast.Expr
+	OmitEmpty bool
+	Depth     int
+}
+
+// jsonFields lists the fields encoding/json writes for a struct, with those
+// of embedded structs promoted. Of fields with the same key the shallowest
+// wins, and if there are several of them none is written.
+func (p packageTypes) jsonFields(s *ast.StructType) ([]jsonField, error) {
+	var all []jsonField
+	err := p.collectJSONFields(s, 0, &all)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := map[string][]jsonField{}
+	var keys []string
+	for _, field := range all {
+		if _, ok := byKey[field.Key]; !ok {
+			keys = append(keys, field.Key)
+		}
+		byKey[field.Key] = append(byKey[field.Key], field)
+	}
+
+	var fields []jsonField
+	for _, key := range keys {
+		candidates := byKey[key]
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Depth < candidates[j].Depth })
+		if len(candidates) > 1 && candidates[0].Depth == candidates[1].Depth {
+			continue
+		}
+		fields = append(fields, candidates[0])
+	}
+	return fields, nil
+}
+
+func (p packageTypes) collectJSONFields(s *ast.StructType, depth int, fields *[]jsonField) error {
+	for _, field := range s.Fields.List {
+		var tag string
+		if field.Tag != nil {
+			unquoted, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return fmt.Errorf("could not unquote tag %s: %w", field.Tag.Value, err)
+			}
+			tag = reflect.StructTag(unquoted).Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		key, options, _ := strings.Cut(tag, ",")
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+
+		if len(field.Names) == 0 {
+			if _, ok := field.Type.(*ast.StarExpr); ok {
+				return fmt.Errorf("embedded pointer %s is not supported", exprString(field.Type))
+			}
+
+			name := exprString(field.Type)
+			if embedded, ok := p.structOf(field.Type); ok && key == "" {
+				err := p.collectJSONFields(embedded, depth+1, fields)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if !ast.IsExported(name) {
+				continue
+			}
+			if key == "" {
+				key = name
+			}
+			*fields = append(*fields, jsonField{key, name, field.Type, omitEmpty, depth})
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			fieldKey := key
+			if fieldKey == "" {
+				fieldKey = name.Name
+			}
+			*fields = append(*fields, jsonField{fieldKey, name.Name, field.Type, omitEmpty, depth})
+		}
+	}
+	return nil
+}
+
+// value returns the expression of a field for the fields map, with structs
+// of the package as nested maps and named basic types as their basic type.
+// The struct types it refers to are added to nested.
+func (p packageTypes) value(expr string, t ast.Expr, nested map[string]bool) string {
+	switch t := t.(type) {
+	case *ast.Ident:
+		if _, ok := p[t.Name]; !ok {
+			return expr
+		}
+		switch underlying := p.underlying(t).(type) {
+		case *ast.StructType:
+			nested[t.Name] = true
+			return expr + ".fields()"
+		case *ast.Ident:
+			return underlying.Name + "(" + expr + ")"
+		}
+	case *ast.StarExpr:
+		if _, ok := p.structOf(t.X); ok {
+			nested[exprString(t.X)] = true
+			return "pointerFields(" + expr + ")"
+		}
+	case *ast.ArrayType:
+		if _, ok := p.structOf(t.Elt); ok {
+			nested[exprString(t.Elt)] = true
+			return "sliceFields(" + expr + ")"
+		}
+	}
+	return expr
+}
+
+// optional returns the condition and value of an omitempty field.
+func (p packageTypes) optional(expr string, t ast.Expr, nested map[string]bool) (string, string, error) {
+	switch underlying := p.underlying(t).(type) {
+	case *ast.StarExpr:
+		if _, ok := p.structOf(underlying.X); ok {
+			nested[exprString(underlying.X)] = true
+			return expr + " != nil", expr + ".fields()", nil
+		}
+		return expr + " != nil", "*" + expr, nil
+	case *ast.ArrayType, *ast.MapType:
+		return "len(" + expr + ") > 0", p.value(expr, t, nested), nil
+	case *ast.Ident:
+		if underlying.Name == "string" {
+			return expr + ` != ""`, p.value(expr, t, nested), nil
+		}
+	}
+	return "", "", fmt.Errorf("omitempty on %s %s is not supported", expr, exprString(t))
+}
+
+// fieldsTypes returns the fields methods of the event types and the structs
+// their fields refer to.
+func fieldsTypes(types packageTypes, subscriptions []Subscription) ([]FieldsType, error) {
+	pending := map[string]bool{}
+	for _, s := range subscriptions {
+		pending[s.Receiver()] = true
+		for _, version := range s.Versions {
+			pending[version.Event] = true
+		}
+	}
+
+	done := map[string]FieldsType{}
+	for len(pending) > 0 {
+		var name string
+		for name = range pending {
+			break
+		}
+		delete(pending, name)
+		if _, ok := done[name]; ok {
+			continue
+		}
+
+		s, ok := types.structOf(ast.NewIdent(name))
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct of the package", name)
+		}
+		fields, err := types.jsonFields(s)
+		if err != nil {
+			return nil, fmt.Errorf("could not list fields of %s: %w", name, err)
+		}
+
+		nested := map[string]bool{}
+		fieldsType := FieldsType{Name: name}
+		for _, field := range fields {
+			expr := "e." + field.Name
+			if !field.OmitEmpty {
+				fieldsType.Fields = append(fieldsType.Fields, FieldValue{Key: field.Key, Value: types.value(expr, field.Type, nested)})
+				continue
+			}
+
+			cond, value, err := types.optional(expr, field.Type, nested)
+			if err != nil {
+				return nil, fmt.Errorf("could not list fields of %s: %w", name, err)
+			}
+			fieldsType.Optional = append(fieldsType.Optional, FieldValue{Key: field.Key, Value: value, Cond: cond})
+		}
+		done[name] = fieldsType
+
+		for nestedName := range nested {
+			pending[nestedName] = true
+		}
+	}
+
+	result := make([]FieldsType, 0, len(done))
+	for _, fieldsType := range done {
+		result = append(result, fieldsType)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func exprString(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return exprString(expr.X) + "." + expr.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(expr.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(expr.Elt)
+	}
+	return fmt.Sprintf("%T", expr)
+}
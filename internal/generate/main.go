@@ -0,0 +1,403 @@
+// Command generate builds the subscription registry, event handlers, and
+// event structs from subscriptions.json, and the fields maps of the event
+// structs. Run it with go generate from the repository root.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type Subscription struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Version       string `json:"version"`
+	Event         string `json:"event"`
+	NoBroadcaster bool   `json:"noBroadcaster"`
+	Category      string `json:"category"`
+
+	// Condition lists the required condition keys, with alternatives of
+	// which one is needed separated by |.
+	Condition []string `json:"condition"`
+
+	// Scopes lists the scopes a token needs, with alternatives of which one
+	// is needed separated by |.
+	Scopes []string `json:"scopes"`
+
+	// Summary is the fmt format of the String method of the event and
+	// SummaryArgs are its arguments, written as expressions on e.
+	Summary     string   `json:"summary"`
+	SummaryArgs []string `json:"summaryArgs"`
+
+	// Struct is the schema of the event struct generated into events_gen.go.
+	// The types it is built from and the methods of events are written by
+	// hand in events.go.
+	Struct *Struct `json:"struct"`
+
+	// Versions are the other versions of the subscription type whose events
+	// decode into their own struct. The struct needs an upgrade method
+	// returning Event, so the OnEvent callback still receives them.
+	Versions []Version `json:"versions"`
+}
+
+type Version struct {
+	Version string  `json:"version"`
+	Event   string  `json:"event"`
+	Struct  *Struct `json:"struct"`
+}
+
+// Struct is the schema of an event struct. Either Underlying names the type
+// the event is defined as, or Embeds and Fields make up a struct.
+type Struct struct {
+	Doc        string   `json:"doc"`
+	Underlying string   `json:"underlying"`
+	Embeds     []string `json:"embeds"`
+	Fields     []Field  `json:"fields"`
+}
+
+type Field struct {
+	Name string `json:"name"`
+	JSON string `json:"json"`
+	Type string `json:"type"`
+	Doc  string `json:"doc"`
+}
+
+// Receiver is the event type the String method is declared on, which is the
+// element type for events that arrive as a list.
+func (s Subscription) Receiver() string {
+	return strings.TrimPrefix(s.Event, "[]")
+}
+
+// Category groups related subscriptions behind one wildcard handler.
+type Category struct {
+	Name          string
+	Subscriptions []Subscription
+}
+
+// Method is the unexported marker method of the category interface.
+func (c Category) Method() string {
+	return strings.ToLower(c.Name[:1]) + c.Name[1:] + "Event"
+}
+
+func categories(subscriptions []Subscription) []Category {
+	var categories []Category
+	index := map[string]int{}
+	for _, s := range subscriptions {
+		if s.Category == "" {
+			continue
+		}
+
+		i, ok := index[s.Category]
+		if !ok {
+			i = len(categories)
+			index[s.Category] = i
+			categories = append(categories, Category{Name: s.Category})
+		}
+		categories[i].Subscriptions = append(categories[i].Subscriptions, s)
+	}
+	return categories
+}
+
+// Group reports whether a blank line should come before the subscription so
+// related subscriptions stay aligned together.
+func (s Subscription) Group(previous Subscription) bool {
+	return typePrefix(s.Type) != typePrefix(previous.Type)
+}
+
+func typePrefix(t string) string {
+	return t[:strings.LastIndex(t, ".")]
+}
+
+var funcs = template.FuncMap{
+	"strings": func(values []string) string {
+		if len(values) == 0 {
+			return "nil"
+		}
+		return fmt.Sprintf("%#v", values)
+	},
+	"comment": func(indent, doc string) string {
+		return indent + "// " + strings.ReplaceAll(doc, "\n", "\n"+indent+"// ")
+	},
+	"prev": func(subs []Subscription, i int) Subscription {
+		if i == 0 {
+			return subs[0]
+		}
+		return subs[i-1]
+	},
+}
+
+var subscriptionsTemplate = template.Must(template.New("subscriptions").Funcs(funcs).Parse(`// Code generated by internal/generate from subscriptions.json. DO NOT EDIT.
+
+package twitch
+
+var (
+{{- range $i, $s := . }}
+{{- if $s.Group (prev $ $i) }}
+{{ end }}
+	Sub{{ $s.Name }} EventSubscription = "{{ $s.Type }}"
+{{- end }}
+
+	subMetadata = map[EventSubscription]subscriptionMetadata{
+{{- range . }}
+		Sub{{ .Name }}: newSubscriptionMetadata("{{ .Version }}", {{ strings .Condition }}, {{ strings .Scopes }}, func(h *eventHandlers) func({{ .Event }}) { return h.onEvent{{ .Name }} }),
+{{- end }}
+	}
+
+	subVersions = map[EventSubscription]map[string]subscriptionMetadata{
+{{- range $s := . }}{{ if $s.Versions }}
+		Sub{{ $s.Name }}: {
+{{- range $s.Versions }}
+			"{{ .Version }}": newVersionMetadata("{{ .Version }}", {{ strings $s.Condition }}, {{ strings $s.Scopes }}, func(h *eventHandlers) func({{ $s.Event }}) { return h.onEvent{{ $s.Name }} }, {{ .Event }}.upgrade),
+{{- end }}
+		},
+{{- end }}{{ end }}
+	}
+)
+`))
+
+var handlersTemplate = template.Must(template.New("handlers").Parse(`// Code generated by internal/generate from subscriptions.json. DO NOT EDIT.
+
+package twitch
+
+type eventHandlers struct {
+{{- range .Subscriptions }}
+	onEvent{{ .Name }} func(event {{ .Event }})
+{{- end }}
+{{ range .Categories }}
+	onAny{{ .Name }}Event func(event {{ .Name }}Event)
+{{- end }}
+}
+
+// EventRegistrar registers a callback for every event type.
+type EventRegistrar interface {
+{{- range .Subscriptions }}
+	OnEvent{{ .Name }}(callback func(event {{ .Event }})) *Filter[{{ .Event }}]
+{{- end }}
+}
+{{ range .Subscriptions }}
+func (c *Client) OnEvent{{ .Name }}(callback func(event {{ .Event }})) *Filter[{{ .Event }}] {
+	filter := &Filter[{{ .Event }}]{}
+	c.onEvent{{ .Name }} = filter.wrap(callback)
+	return filter
+}
+{{ end }}
+{{- range .Subscriptions }}{{ if not .NoBroadcaster }}
+func (s *BroadcasterScope) OnEvent{{ .Name }}(callback func(event {{ .Event }})) {
+	s.setHandler(Sub{{ .Name }}, func(event any) {
+		if event, ok := event.({{ .Event }}); ok {
+			callback(event)
+		}
+	})
+}
+{{ end }}{{ end }}
+{{- range $c := .Categories }}
+// {{ $c.Name }}Event is one of these events:
+{{- range $c.Subscriptions }}
+//   - {{ .Event }}
+{{- end }}
+type {{ $c.Name }}Event interface {
+	{{ $c.Method }}()
+}
+{{ range $c.Subscriptions }}
+func ({{ .Event }}) {{ $c.Method }}() {}
+{{ end }}
+// OnAny{{ $c.Name }}Event is called for every {{ $c.Name }}Event in addition to
+// the callback of the event type.
+func (c *Client) OnAny{{ $c.Name }}Event(callback func(event {{ $c.Name }}Event)) *Filter[{{ $c.Name }}Event] {
+	filter := &Filter[{{ $c.Name }}Event]{}
+	c.onAny{{ $c.Name }}Event = filter.wrap(callback)
+	return filter
+}
+{{ end }}
+// categoryHandler returns the wildcard callback for the category of the
+// event, or nil if there is none.
+func (h *eventHandlers) categoryHandler(event any) func() {
+	switch event := event.(type) {
+{{- range .Categories }}
+	case {{ .Name }}Event:
+		if h.onAny{{ .Name }}Event != nil {
+			return func() { h.onAny{{ .Name }}Event(event) }
+		}
+{{- end }}
+	}
+	return nil
+}
+`))
+
+var eventsTemplate = template.Must(template.New("events").Funcs(funcs).Parse(`// Code generated by internal/generate from subscriptions.json. DO NOT EDIT.
+
+package twitch
+{{ if .Time }}
+import "time"
+{{ end }}
+{{- range .Structs }}
+{{ if .Doc }}{{ comment "" .Doc }}
+{{ end }}
+{{- if .Underlying -}}
+type {{ .Name }} {{ .Underlying }}
+{{ else -}}
+type {{ .Name }} struct {
+{{- range .Embeds }}
+	{{ . }}
+{{- end }}
+{{- if and .Embeds .Fields }}
+{{ end }}
+{{- range $i, $f := .Fields }}
+{{- if $f.Doc }}
+{{- if $i }}
+{{ end }}
+{{ comment "\t" $f.Doc }}
+{{- end }}
+	{{ $f.Name }} {{ $f.Type }} ` + "`" + `json:"{{ $f.JSON }}"` + "`" + `
+{{- end }}
+}
+{{ end }}
+{{- end }}`))
+
+// eventStruct is an event struct to generate, named after the event type.
+type eventStruct struct {
+	Name string
+	*Struct
+}
+
+type eventsData struct {
+	Structs []eventStruct
+	Time    bool
+}
+
+func structs(subscriptions []Subscription) (eventsData, error) {
+	var data eventsData
+	add := func(name string, s *Struct) error {
+		if s == nil {
+			return fmt.Errorf("no struct for %s", name)
+		}
+		if s.Underlying != "" && (len(s.Embeds) > 0 || len(s.Fields) > 0) {
+			return fmt.Errorf("struct of %s has an underlying type and fields", name)
+		}
+
+		for _, f := range s.Fields {
+			if f.Name == "" || f.JSON == "" || f.Type == "" {
+				return fmt.Errorf("field of %s needs a name, json key, and type", name)
+			}
+			if strings.Contains(f.Type, "time.") {
+				data.Time = true
+			}
+		}
+		data.Structs = append(data.Structs, eventStruct{name, s})
+		return nil
+	}
+
+	for _, s := range subscriptions {
+		err := add(s.Receiver(), s.Struct)
+		if err != nil {
+			return eventsData{}, err
+		}
+		for _, v := range s.Versions {
+			err = add(v.Event, v.Struct)
+			if err != nil {
+				return eventsData{}, err
+			}
+		}
+	}
+	return data, nil
+}
+
+var summariesTemplate = template.Must(template.New("summaries").Parse(`// Code generated by internal/generate from subscriptions.json. DO NOT EDIT.
+
+package twitch
+
+import "fmt"
+{{ range . }}
+func (e {{ .Receiver }}) String() string {
+	return fmt.Sprintf("{{ .Type }}: {{ .Summary }}"{{ range .SummaryArgs }}, {{ . }}{{ end }})
+}
+{{ end }}`))
+
+type handlersData struct {
+	Subscriptions []Subscription
+	Categories    []Category
+}
+
+func main() {
+	data, err := os.ReadFile("subscriptions.json")
+	if err != nil {
+		exit(fmt.Errorf("could not read subscriptions: %w", err))
+	}
+
+	var subscriptions []Subscription
+	err = json.Unmarshal(data, &subscriptions)
+	if err != nil {
+		exit(fmt.Errorf("could not parse subscriptions: %w", err))
+	}
+
+	for _, s := range subscriptions {
+		if s.Summary == "" {
+			exit(fmt.Errorf("no summary for %s", s.Name))
+		}
+	}
+
+	err = generate("subscriptions_gen.go", subscriptionsTemplate, subscriptions)
+	if err != nil {
+		exit(err)
+	}
+
+	err = generate("handlers_gen.go", handlersTemplate, handlersData{subscriptions, categories(subscriptions)})
+	if err != nil {
+		exit(err)
+	}
+
+	err = generate("summaries_gen.go", summariesTemplate, subscriptions)
+	if err != nil {
+		exit(err)
+	}
+
+	events, err := structs(subscriptions)
+	if err != nil {
+		exit(err)
+	}
+
+	err = generate("events_gen.go", eventsTemplate, events)
+	if err != nil {
+		exit(err)
+	}
+
+	types, err := parsePackageTypes("fields_gen.go")
+	if err != nil {
+		exit(err)
+	}
+
+	fields, err := fieldsTypes(types, subscriptions)
+	if err != nil {
+		exit(err)
+	}
+
+	err = generate("fields_gen.go", fieldsTemplate, fields)
+	if err != nil {
+		exit(err)
+	}
+}
+
+func generate(filename string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		return fmt.Errorf("could not execute %s template: %w", filename, err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not format %s: %w", filename, err)
+	}
+
+	return os.WriteFile(filename, src, 0o644)
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
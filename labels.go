@@ -0,0 +1,43 @@
+package twitch
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// Goroutines of the client carry pprof labels so profiles attribute their
+// time to this package: eventsub_role is one of the roles below and
+// eventsub_session is the session ID once the welcome message arrived.
+// Goroutines inherit the labels of the goroutine that started them, so
+// callbacks run by the read loop are attributed to it.
+const (
+	labelRole    = "eventsub_role"
+	labelSession = "eventsub_session"
+
+	roleReadLoop    = "read_loop"
+	roleWatchdog    = "watchdog"
+	roleQueueWorker = "queue_worker"
+	roleHandler     = "handler"
+)
+
+// labelReadLoop labels the calling goroutine as the read loop of a session
+// and keeps the labels for the goroutines it starts.
+func (c *Client) labelReadLoop(ctx context.Context, sessionID string) {
+	labels := []string{labelRole, roleReadLoop}
+	if sessionID != "" {
+		labels = append(labels, labelSession, sessionID)
+	}
+	c.labels = pprof.WithLabels(ctx, pprof.Labels(labels...))
+	pprof.SetGoroutineLabels(c.labels)
+}
+
+// goLabeled runs f in a new goroutine labeled with role and the labels of
+// ctx, which may be nil.
+func goLabeled(ctx context.Context, role string, f func()) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go pprof.Do(ctx, pprof.Labels(labelRole, role), func(context.Context) {
+		f()
+	})
+}
@@ -0,0 +1,34 @@
+package twitch_test
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoroutineLabels(t *testing.T) {
+	t.Parallel()
+
+	client := newClientWithWelcome(t, "", twitch.SubStreamOnline, getTestEventData(twitch.SubStreamOnline))
+
+	profile := make(chan string, 1)
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		var buf bytes.Buffer
+		pprof.Lookup("goroutine").WriteTo(&buf, 1)
+		profile <- buf.String()
+	})
+	go connect(t, client)
+	defer client.Close()
+
+	select {
+	case goroutines := <-profile:
+		assert.Regexp(t, `"eventsub_role":"handler", "eventsub_session":"\w+"`, goroutines)
+		assert.Regexp(t, `"eventsub_role":"read_loop", "eventsub_session":"\w+"`, goroutines)
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+}
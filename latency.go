@@ -0,0 +1,44 @@
+package twitch
+
+import "time"
+
+func messageMetadata(message any) MessageMetadata {
+	switch message := message.(type) {
+	case WelcomeMessage:
+		return message.Metadata
+	case KeepAliveMessage:
+		return message.Metadata
+	case NotificationMessage:
+		return message.Metadata
+	case ReconnectMessage:
+		return message.Metadata
+	case RevokeMessage:
+		return message.Metadata
+	}
+	return MessageMetadata{}
+}
+
+// observeLatency records how long after its message_timestamp a message was
+// read, which is network and buffering delay plus clock skew.
+func (c *Client) observeLatency(metadata MessageMetadata, received time.Time) {
+	if metadata.MessageTimestamp.IsZero() {
+		return
+	}
+
+	latency := received.Sub(metadata.MessageTimestamp)
+	c.stats.latency(latency)
+
+	if c.latencyThreshold <= 0 || c.onHighLatency == nil {
+		return
+	}
+	if latency > c.latencyThreshold || -latency > c.latencyThreshold {
+		c.onHighLatency(latency, metadata)
+	}
+}
+
+// OnHighLatency is called with messages that cross the WithLatencyThreshold
+// threshold, which shows clock drift and network buffering before events
+// appear late.
+func (c *Client) OnHighLatency(callback func(latency time.Duration, metadata MessageMetadata)) {
+	c.onHighLatency = callback
+}
@@ -0,0 +1,64 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+)
+
+func TestLatency(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktest.NewClock(now)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		welcome := twitch.WelcomeMessage{Metadata: newMetadata("session_welcome")}
+		welcome.Metadata.MessageTimestamp = now.Add(-time.Second)
+		welcome.Payload.Session.ID = "session"
+		keepalive := twitch.KeepAliveMessage{Metadata: newMetadata("session_keepalive")}
+		keepalive.Metadata.MessageTimestamp = now.Add(-3 * time.Second)
+
+		for _, message := range []any{welcome, keepalive} {
+			data, _ := json.Marshal(message)
+			conn.Write(r.Context(), websocket.MessageText, data)
+		}
+		conn.Read(r.Context())
+	}))
+	defer server.Close()
+
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithClock(clock), twitch.WithWelcomeTimeout(0), twitch.WithLatencyThreshold(2*time.Second))
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	var latencies []time.Duration
+	var messageTypes []string
+	client.OnHighLatency(func(latency time.Duration, metadata twitch.MessageMetadata) {
+		latencies = append(latencies, latency)
+		messageTypes = append(messageTypes, metadata.MessageType)
+	})
+	client.OnKeepAlive(func(message twitch.KeepAliveMessage) {
+		client.Close()
+	})
+
+	connect(t, client)
+
+	assert.Equal(t, []time.Duration{3 * time.Second}, latencies)
+	assert.Equal(t, []string{"session_keepalive"}, messageTypes)
+
+	stats := client.Stats()
+	assert.Equal(t, 3*time.Second, stats.Latency)
+	assert.Equal(t, 3*time.Second, stats.MaxLatency)
+	assert.Equal(t, 2*time.Second, stats.AverageLatency)
+}
@@ -0,0 +1,143 @@
+package twitch
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+)
+
+// HandlerID identifies a listener so it can be removed with Off.
+type HandlerID uint64
+
+type listener struct {
+	id HandlerID
+	f  func(ctx context.Context, message NotificationMessage, event any)
+	// inline listeners run on the goroutine handling the message, before the
+	// event is dispatched, so they see events in order
+	inline bool
+	// eventType is the type of events the listener takes, if it was added
+	// with On or Once, for RequiredSubscriptions
+	eventType reflect.Type
+}
+
+func (c *Client) addListener(f func(message NotificationMessage, event any), inline bool) HandlerID {
+	return c.addContextListener(func(ctx context.Context, message NotificationMessage, event any) {
+		f(message, event)
+	}, inline)
+}
+
+func (c *Client) addContextListener(f func(ctx context.Context, message NotificationMessage, event any), inline bool) HandlerID {
+	return c.addListenerOf(listener{f: f, inline: inline})
+}
+
+func (c *Client) addListenerOf(l listener) HandlerID {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	c.nextListenerID++
+	l.id = c.nextListenerID
+	c.listeners = append(c.listeners, l)
+	return c.nextListenerID
+}
+
+// addTypedListener adds a listener for events of type T, remembering the
+// type when client is a *Client.
+func addTypedListener[T any](client EventSubClient, f func(message NotificationMessage, event any)) HandlerID {
+	c, ok := client.(*Client)
+	if !ok {
+		return client.AddListener(f)
+	}
+	return c.addListenerOf(listener{
+		f: func(ctx context.Context, message NotificationMessage, event any) {
+			f(message, event)
+		},
+		eventType: reflect.TypeOf((*T)(nil)).Elem(),
+	})
+}
+
+func (c *Client) removeListener(id HandlerID) bool {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	for i, l := range c.listeners {
+		if l.id == id {
+			// Copy so snapshots being dispatched are not changed underneath
+			c.listeners = append(append([]listener(nil), c.listeners[:i]...), c.listeners[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) listenerSnapshot() []listener {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+	return c.listeners
+}
+
+// Off removes a listener and reports whether it was still registered. Events
+// already being dispatched may still reach it.
+func (c *Client) Off(id HandlerID) bool {
+	return c.removeListener(id)
+}
+
+// On registers a listener for events of type T, which can be an event struct
+// like EventStreamOnline or a category like HypeTrainEvent. Unlike the
+// OnEvent setters any number of listeners can be registered for a type.
+func On[T any](client EventSubClient, callback func(event T)) HandlerID {
+	return addTypedListener[T](client, func(message NotificationMessage, event any) {
+		if event, ok := event.(T); ok {
+			callback(event)
+		}
+	})
+}
+
+// Once registers a listener that is called for the next event of type T only
+// and then removed.
+func Once[T any](client EventSubClient, callback func(event T)) HandlerID {
+	var id atomic.Uint64
+	var fired atomic.Bool
+
+	id.Store(uint64(addTypedListener[T](client, func(message NotificationMessage, event any) {
+		typed, ok := event.(T)
+		if !ok || !fired.CompareAndSwap(false, true) {
+			return
+		}
+
+		client.Off(HandlerID(id.Load()))
+		callback(typed)
+	})))
+
+	// The event may have arrived before the ID was stored
+	if fired.Load() {
+		client.Off(HandlerID(id.Load()))
+	}
+	return HandlerID(id.Load())
+}
+
+// AwaitEvent blocks until an event of type T matching the predicate arrives or
+// ctx is done. A nil predicate matches every event of the type. Only events
+// arriving after the call are seen, so the action that causes the event should
+// start once AwaitEvent is waiting, for example from another goroutine.
+func AwaitEvent[T any](ctx context.Context, client EventSubClient, predicate func(event T) bool) (T, error) {
+	events := make(chan T, 1)
+	id := On(client, func(event T) {
+		if predicate != nil && !predicate(event) {
+			return
+		}
+
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	defer client.Off(id)
+
+	select {
+	case event := <-events:
+		return event, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,85 @@
+package twitch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func handleEvents(t *testing.T, client *twitch.Client, events ...twitch.EventSubscription) {
+	for _, event := range events {
+		data, _, err := getTestEventData(event)()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data[0]))
+	}
+}
+
+func TestOnOff(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var online, hypeTrain int
+	id := twitch.On(client, func(event twitch.EventStreamOnline) { online++ })
+	twitch.On(client, func(event twitch.HypeTrainEvent) { hypeTrain++ })
+
+	handleEvents(t, client, twitch.SubStreamOnline, twitch.SubStreamOffline, twitch.SubChannelHypeTrainBegin)
+	assert.Equal(t, 1, online)
+	assert.Equal(t, 1, hypeTrain)
+
+	assert.True(t, client.Off(id))
+	assert.False(t, client.Off(id))
+
+	handleEvents(t, client, twitch.SubStreamOnline, twitch.SubChannelHypeTrainEnd)
+	assert.Equal(t, 1, online)
+	assert.Equal(t, 2, hypeTrain)
+}
+
+func TestOnce(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var online int
+	id := twitch.Once(client, func(event twitch.EventStreamOnline) { online++ })
+
+	handleEvents(t, client, twitch.SubStreamOffline, twitch.SubStreamOnline, twitch.SubStreamOnline)
+	assert.Equal(t, 1, online)
+	assert.False(t, client.Off(id))
+}
+
+func TestAwaitEvent(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+
+	go func() {
+		// Give AwaitEvent time to register before the events arrive
+		time.Sleep(10 * time.Millisecond)
+		handleEvents(t, client, twitch.SubStreamOffline, twitch.SubChannelCheer)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event, err := twitch.AwaitEvent(ctx, client, func(event twitch.EventChannelCheer) bool {
+		return event.Bits >= 1000
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, event.Bits)
+}
+
+func TestAwaitEventCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := twitch.AwaitEvent[twitch.EventStreamOnline](ctx, twitch.NewClient(), nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
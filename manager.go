@@ -0,0 +1,167 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ManagedUser is a user whose events a Manager receives with their token.
+type ManagedUser struct {
+	UserID      string
+	ClientID    string
+	AccessToken string
+	TokenSource TokenSource
+
+	// Subscriptions are created on every new session of the user's client.
+	// Their credentials default to the user's.
+	Subscriptions []SubscribeRequest
+}
+
+// Manager runs a websocket client for each of many user tokens, like the
+// users of a bot platform that logged in with Twitch. The clients connect,
+// reconnect, and stop independently, and their events go to Dispatcher tagged
+// with the user ID.
+type Manager struct {
+	Address         string
+	SubscriptionUrl string
+	Dispatcher      *Dispatcher
+
+	options []ClientOption
+	users   map[string]*managedClient
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	onError func(userID string, err error)
+}
+
+type managedClient struct {
+	client *Client
+	cancel context.CancelFunc
+}
+
+// NewManager creates a manager whose clients are created with options.
+func NewManager(options ...ClientOption) *Manager {
+	return NewManagerWithUrl(twitchWebsocketUrl, options...)
+}
+
+func NewManagerWithUrl(url string, options ...ClientOption) *Manager {
+	return &Manager{
+		Address:         url,
+		SubscriptionUrl: twitchEventSubUrl,
+		Dispatcher:      NewDispatcher(),
+		options:         options,
+		users:           map[string]*managedClient{},
+		onError:         func(userID string, err error) { fmt.Printf("ERROR: %s: %v\n", userID, err) },
+	}
+}
+
+// OnError is called with the errors of every user's client.
+func (m *Manager) OnError(callback func(userID string, err error)) {
+	m.onError = callback
+}
+
+// Add starts a client for the user, replacing the one of a user added with
+// the same ID, for example after their token changed. The client runs until
+// the user is removed, the manager is closed, or Run gives up, which is
+// reported to OnError and removes the user.
+func (m *Manager) Add(user ManagedUser) {
+	m.Remove(user.UserID)
+
+	client := NewClientWithUrl(m.Address, m.options...)
+	client.SubscriptionUrl = m.SubscriptionUrl
+	client.OnError(func(err error) {
+		m.onError(user.UserID, err)
+	})
+	client.OnWelcome(func(message WelcomeMessage) {
+		for _, request := range user.Subscriptions {
+			if request.ClientID == "" {
+				request.ClientID = user.ClientID
+			}
+			if request.AccessToken == "" && request.TokenSource == nil {
+				request.AccessToken, request.TokenSource = user.AccessToken, user.TokenSource
+			}
+
+			_, err := client.Subscribe(request)
+			if err != nil {
+				m.onError(user.UserID, fmt.Errorf("could not subscribe to %s: %w", request.Event, err))
+			}
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	managed := &managedClient{client: client, cancel: cancel}
+
+	m.mu.Lock()
+	m.users[user.UserID] = managed
+	m.mu.Unlock()
+	m.Dispatcher.Add(user.UserID, client)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		err := client.Run(ctx)
+		if err != nil {
+			m.onError(user.UserID, fmt.Errorf("client stopped: %w", err))
+		}
+		m.remove(user.UserID, managed)
+	}()
+}
+
+// Remove stops the client of the user and reports whether there was one.
+func (m *Manager) Remove(userID string) bool {
+	m.mu.Lock()
+	managed, ok := m.users[userID]
+	m.mu.Unlock()
+
+	if ok {
+		managed.cancel()
+		m.remove(userID, managed)
+	}
+	return ok
+}
+
+// remove forgets the user if managed is still their client.
+func (m *Manager) remove(userID string, managed *managedClient) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.users[userID] != managed {
+		return
+	}
+	delete(m.users, userID)
+	m.Dispatcher.Remove(userID)
+}
+
+// Client returns the client of the user, or nil.
+func (m *Manager) Client(userID string) *Client {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if managed, ok := m.users[userID]; ok {
+		return managed.client
+	}
+	return nil
+}
+
+// Users returns the IDs of the managed users in order.
+func (m *Manager) Users() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make([]string, 0, len(m.users))
+	for userID := range m.users {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+	return users
+}
+
+// Close stops every client and waits for them to return.
+func (m *Manager) Close() {
+	for _, userID := range m.Users() {
+		m.Remove(userID)
+	}
+	m.wg.Wait()
+}
@@ -0,0 +1,86 @@
+package twitch_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	manager := twitch.NewManager()
+	defer manager.Close()
+	manager.OnError(func(userID string, err error) {
+		t.Errorf("%s registered an error: %v", userID, err)
+	})
+
+	var mu sync.Mutex
+	online := map[string]bool{}
+	twitch.Dispatch(manager.Dispatcher, func(userID string, event twitch.EventStreamOnline) {
+		mu.Lock()
+		defer mu.Unlock()
+		online[userID] = true
+	})
+
+	// Every user gets their own server, which sends stream.online once subscribed
+	for _, userID := range []string{"alice", "bob"} {
+		server, err := newTestServer(getTestEventData(twitch.SubStreamOnline))
+		if err != nil {
+			t.Fatal(err)
+		}
+		manager.Address = fmt.Sprintf("http://%s/ws", server.Address)
+		manager.SubscriptionUrl = fmt.Sprintf("http://%s/subscriptions", server.Address)
+
+		manager.Add(twitch.ManagedUser{
+			UserID:      userID,
+			ClientID:    "client",
+			AccessToken: userID,
+			Subscriptions: []twitch.SubscribeRequest{
+				{Event: twitch.SubStreamOnline, Condition: testCondition},
+			},
+		})
+	}
+	assert.Equal(t, []string{"alice", "bob"}, manager.Users())
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return online["alice"] && online["bob"]
+	}, time.Second, time.Millisecond)
+
+	assert.True(t, manager.Remove("alice"))
+	assert.Nil(t, manager.Client("alice"))
+	assert.Equal(t, []string{"bob"}, manager.Users())
+	assert.Equal(t, []string{"bob"}, manager.Dispatcher.Tags())
+}
+
+func TestManagerGiveUp(t *testing.T) {
+	t.Parallel()
+
+	manager := twitch.NewManagerWithUrl("http://127.0.0.1:1/ws", twitch.WithReconnectLimit(1, 0))
+	defer manager.Close()
+
+	errs := make(chan error, 10)
+	manager.OnError(func(userID string, err error) {
+		errs <- err
+	})
+	manager.Add(twitch.ManagedUser{UserID: "alice"})
+
+	assert.Eventually(t, func() bool {
+		return len(manager.Users()) == 0
+	}, time.Second, time.Millisecond)
+
+	var gaveUp bool
+	for len(errs) > 0 {
+		if errors.Is(<-errs, twitch.ErrReconnectLimit) {
+			gaveUp = true
+		}
+	}
+	assert.True(t, gaveUp, "giving up was not reported")
+}
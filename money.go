@@ -0,0 +1,135 @@
+package twitch
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the smallest unit of a currency, like the amounts of
+// charity campaigns. 5.50 USD has a Value of 550 and 2 DecimalPlaces.
+type Money struct {
+	Value         int    `json:"value"`
+	DecimalPlaces int    `json:"decimal_places"`
+	Currency      string `json:"currency"`
+}
+
+// Amount returns the amount as a float, which can be off by rounding. Use
+// Decimal or Localized to display it.
+func (m Money) Amount() float64 {
+	return float64(m.Value) / math.Pow10(m.DecimalPlaces)
+}
+
+// Decimal returns the exact amount with DecimalPlaces digits after the point,
+// like 5.50 for a Value of 550.
+func (m Money) Decimal() string {
+	return m.format(".", "")
+}
+
+// String returns the amount with its ISO 4217 currency code, like 5.50 USD.
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+type moneyLocale struct {
+	decimal     string
+	group       string
+	symbolAfter bool
+}
+
+var moneyLocales = map[string]moneyLocale{
+	"en": {".", ",", false},
+	"ja": {".", ",", false},
+	"ko": {".", ",", false},
+	"zh": {".", ",", false},
+	"de": {",", ".", true},
+	"es": {",", ".", true},
+	"it": {",", ".", true},
+	"nl": {",", ".", false},
+	"pt": {",", ".", true},
+	"tr": {",", ".", false},
+	"fr": {",", " ", true},
+	"pl": {",", " ", true},
+	"ru": {",", " ", true},
+	"sv": {",", " ", true},
+	"fi": {",", " ", true},
+	"cs": {",", " ", true},
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"KRW": "₩",
+	"BRL": "R$",
+	"CAD": "CA$",
+	"AUD": "A$",
+	"INR": "₹",
+	"PLN": "zł",
+	"RUB": "₽",
+}
+
+// Localized returns the amount formatted for a BCP 47 locale like en-US or
+// de, with the separators and currency symbol placement of its language.
+// Unknown languages are formatted like English.
+func (m Money) Localized(locale string) string {
+	language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	l, ok := moneyLocales[strings.ToLower(language)]
+	if !ok {
+		l = moneyLocales["en"]
+	}
+
+	amount := m.format(l.decimal, l.group)
+	symbol, ok := currencySymbols[m.Currency]
+	switch {
+	case m.Currency == "":
+		return amount
+	case !ok && l.symbolAfter:
+		return amount + " " + m.Currency
+	case !ok:
+		return m.Currency + " " + amount
+	case l.symbolAfter:
+		return amount + " " + symbol
+	case strings.HasPrefix(amount, "-"):
+		return "-" + symbol + amount[1:]
+	default:
+		return symbol + amount
+	}
+}
+
+func (m Money) format(decimal, group string) string {
+	digits := strconv.Itoa(m.Value)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	places := m.DecimalPlaces
+	if places < 0 {
+		places = 0
+	}
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-places], digits[len(digits)-places:]
+	if group != "" {
+		var grouped strings.Builder
+		for i, r := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 {
+				grouped.WriteString(group)
+			}
+			grouped.WriteRune(r)
+		}
+		whole = grouped.String()
+	}
+
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + decimal + fraction
+}
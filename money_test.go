@@ -0,0 +1,34 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Money     twitch.Money
+		Decimal   string
+		Locale    string
+		Localized string
+	}{
+		{twitch.Money{Value: 550, DecimalPlaces: 2, Currency: "USD"}, "5.50", "en-US", "$5.50"},
+		{twitch.Money{Value: 123456789, DecimalPlaces: 2, Currency: "EUR"}, "1234567.89", "de-DE", "1.234.567,89 €"},
+		{twitch.Money{Value: 5, DecimalPlaces: 2, Currency: "EUR"}, "0.05", "fr", "0,05 €"},
+		{twitch.Money{Value: 1500, DecimalPlaces: 0, Currency: "JPY"}, "1500", "ja_JP", "¥1,500"},
+		{twitch.Money{Value: -2500, DecimalPlaces: 2, Currency: "USD"}, "-25.00", "", "-$25.00"},
+		{twitch.Money{Value: 100000, DecimalPlaces: 2, Currency: "CHF"}, "1000.00", "en", "CHF 1,000.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Localized, func(t *testing.T) {
+			assert.Equal(t, tc.Decimal, tc.Money.Decimal())
+			assert.Equal(t, tc.Localized, tc.Money.Localized(tc.Locale))
+			assert.Equal(t, tc.Decimal+" "+tc.Money.Currency, tc.Money.String())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMonitorInterval = 5 * time.Minute
+
+// SubscriptionMonitor lists subscriptions through Helix and reports the ones
+// whose status changed since the last check, so services notice
+// subscriptions that Twitch disabled without a revocation reaching them.
+// Clock defaults to the system clock and times the checks of Run.
+type SubscriptionMonitor struct {
+	Request  ListSubscriptionsRequest
+	Url      string
+	Interval time.Duration
+	Clock    Clock
+
+	statuses       map[string]SubscriptionStatus
+	mu             sync.Mutex
+	onStatusChange func(subscription PayloadSubscription, previous SubscriptionStatus)
+	onError        func(err error)
+}
+
+func NewSubscriptionMonitor(request ListSubscriptionsRequest) *SubscriptionMonitor {
+	return &SubscriptionMonitor{
+		Request:  request,
+		Url:      twitchEventSubUrl,
+		Interval: defaultMonitorInterval,
+		Clock:    realClock{},
+		statuses: map[string]SubscriptionStatus{},
+		onError:  func(err error) {},
+	}
+}
+
+// OnStatusChange is called when a subscription's status differs from the
+// previous check. Subscriptions seen for the first time are only reported
+// when they are not enabled, with an empty previous status.
+func (m *SubscriptionMonitor) OnStatusChange(callback func(subscription PayloadSubscription, previous SubscriptionStatus)) {
+	m.onStatusChange = callback
+}
+
+func (m *SubscriptionMonitor) OnError(callback func(err error)) {
+	m.onError = callback
+}
+
+// Check lists the subscriptions once and reports status changes.
+func (m *SubscriptionMonitor) Check(ctx context.Context) error {
+	subscriptions, err := ListSubscriptionsUrlWithContext(ctx, m.Request, m.Url)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	var changed []PayloadSubscription
+	var previous []SubscriptionStatus
+	statuses := map[string]SubscriptionStatus{}
+	for _, subscription := range subscriptions {
+		statuses[subscription.ID] = subscription.Status
+
+		last, seen := m.statuses[subscription.ID]
+		if last == subscription.Status || (!seen && subscription.Status == SubscriptionStatusEnabled) {
+			continue
+		}
+		changed = append(changed, subscription)
+		previous = append(previous, last)
+	}
+	m.statuses = statuses
+	m.mu.Unlock()
+
+	if m.onStatusChange != nil {
+		for i, subscription := range changed {
+			m.onStatusChange(subscription, previous[i])
+		}
+	}
+	return nil
+}
+
+// Run checks the subscriptions every Interval until ctx is done. Failed
+// checks are reported to OnError.
+func (m *SubscriptionMonitor) Run(ctx context.Context) error {
+	clock := m.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	ticker := clock.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := m.Check(ctx)
+		if err != nil && ctx.Err() == nil {
+			m.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package twitch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "enabled", r.URL.Query().Get("status"))
+
+		response := map[string]any{"data": []twitch.PayloadSubscription{{ID: "2"}}}
+		if r.URL.Query().Get("after") == "" {
+			response = map[string]any{
+				"data":       []twitch.PayloadSubscription{{ID: "1"}},
+				"pagination": map[string]string{"cursor": "next"},
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	subscriptions, err := twitch.ListSubscriptionsUrl(twitch.ListSubscriptionsRequest{Status: twitch.SubscriptionStatusEnabled}, server.URL)
+	assert.NoError(t, err)
+	if assert.Len(t, subscriptions, 2) {
+		assert.Equal(t, "1", subscriptions[0].ID)
+		assert.Equal(t, "2", subscriptions[1].ID)
+	}
+}
+
+func TestSubscriptionMonitor(t *testing.T) {
+	t.Parallel()
+
+	statuses := map[string]twitch.SubscriptionStatus{
+		"1": twitch.SubscriptionStatusEnabled,
+		"2": twitch.SubscriptionStatusEnabled,
+		"3": twitch.SubscriptionStatusVersionRemoved,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var subscriptions []twitch.PayloadSubscription
+		for _, id := range []string{"1", "2", "3"} {
+			subscriptions = append(subscriptions, twitch.PayloadSubscription{ID: id, Status: statuses[id]})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": subscriptions})
+	}))
+	defer server.Close()
+
+	monitor := twitch.NewSubscriptionMonitor(twitch.ListSubscriptionsRequest{})
+	monitor.Url = server.URL
+
+	changes := map[string]twitch.SubscriptionStatus{}
+	monitor.OnStatusChange(func(subscription twitch.PayloadSubscription, previous twitch.SubscriptionStatus) {
+		changes[subscription.ID] = previous
+	})
+
+	assert.NoError(t, monitor.Check(context.Background()))
+	assert.Equal(t, map[string]twitch.SubscriptionStatus{"3": ""}, changes)
+
+	statuses["2"] = twitch.SubscriptionStatusAuthorizationRevoked
+	changes = map[string]twitch.SubscriptionStatus{}
+	assert.NoError(t, monitor.Check(context.Background()))
+	assert.Equal(t, map[string]twitch.SubscriptionStatus{"2": twitch.SubscriptionStatusEnabled}, changes)
+}
+
+func TestSubscriptionMonitorRun(t *testing.T) {
+	t.Parallel()
+
+	checks := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"data": []twitch.PayloadSubscription{}})
+		checks <- struct{}{}
+	}))
+	defer server.Close()
+
+	clock := clocktest.NewClock(time.Now())
+	monitor := twitch.NewSubscriptionMonitor(twitch.ListSubscriptionsRequest{})
+	monitor.Url = server.URL
+	monitor.Clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Run(ctx)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-checks:
+		case <-time.After(time.Second):
+			t.Fatalf("check %d did not happen", i+1)
+		}
+
+		clock.BlockUntil(1)
+		clock.Advance(monitor.Interval)
+	}
+}
@@ -0,0 +1,97 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+)
+
+var broadcasterConditionKeys = []string{
+	"broadcaster_user_id",
+	"to_broadcaster_user_id",
+	"from_broadcaster_user_id",
+}
+
+// MultiBroadcaster subscribes a client to the same set of events for many
+// broadcasters and tags each dispatched event with the broadcaster it is for.
+type MultiBroadcaster struct {
+	client         *Client
+	broadcasterIDs map[string]bool
+
+	BroadcasterIDs []string
+	Events         []EventSubscription
+
+	// Condition builds the condition for an event and broadcaster. The
+	// default only sets broadcaster_user_id.
+	Condition func(event EventSubscription, broadcasterID string) map[string]string
+
+	onEvent func(broadcasterID string, message NotificationMessage, event any)
+}
+
+func NewMultiBroadcaster(client *Client, broadcasterIDs []string, events []EventSubscription) *MultiBroadcaster {
+	m := &MultiBroadcaster{
+		client:         client,
+		broadcasterIDs: map[string]bool{},
+		BroadcasterIDs: broadcasterIDs,
+		Events:         events,
+		Condition:      defaultBroadcasterCondition,
+	}
+
+	for _, id := range broadcasterIDs {
+		m.broadcasterIDs[id] = true
+	}
+
+	client.AddListener(m.handleEvent)
+	return m
+}
+
+func defaultBroadcasterCondition(event EventSubscription, broadcasterID string) map[string]string {
+	return map[string]string{"broadcaster_user_id": broadcasterID}
+}
+
+// Subscribe creates every event subscription for every broadcaster on the
+// client's current session. The request's Event, Condition, and SessionID
+// are filled in for each subscription.
+func (m *MultiBroadcaster) Subscribe(request SubscribeRequest) error {
+	return m.SubscribeWithContext(context.Background(), request)
+}
+
+func (m *MultiBroadcaster) SubscribeWithContext(ctx context.Context, request SubscribeRequest) error {
+	for _, broadcasterID := range m.BroadcasterIDs {
+		for _, event := range m.Events {
+			request.Event = event
+			request.Condition = m.Condition(event, broadcasterID)
+
+			_, err := m.client.SubscribeWithContext(ctx, request)
+			if err != nil {
+				return fmt.Errorf("could not subscribe to %s for broadcaster %s: %w", event, broadcasterID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MultiBroadcaster) handleEvent(message NotificationMessage, event any) {
+	if m.onEvent == nil {
+		return
+	}
+
+	broadcasterID := conditionBroadcasterID(message.Payload.Subscription.Condition)
+	if !m.broadcasterIDs[broadcasterID] {
+		return
+	}
+
+	m.onEvent(broadcasterID, message, event)
+}
+
+func conditionBroadcasterID(condition map[string]string) string {
+	for _, key := range broadcasterConditionKeys {
+		if id, ok := condition[key]; ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func (m *MultiBroadcaster) OnEvent(callback func(broadcasterID string, message NotificationMessage, event any)) {
+	m.onEvent = callback
+}
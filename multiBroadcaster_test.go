@@ -0,0 +1,34 @@
+package twitch_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client := newClient(t, getTestEventDataWithCondition(twitch.SubStreamOnline, map[string]string{
+			"broadcaster_user_id": "1337",
+		}))
+		client.SubscriptionUrl = strings.ReplaceAll(client.Address, "/ws", "/subscriptions")
+
+		multi := twitch.NewMultiBroadcaster(client, []string{"1337"}, []twitch.EventSubscription{twitch.SubStreamOnline})
+		multi.OnEvent(func(broadcasterID string, message twitch.NotificationMessage, event any) {
+			assert.Equal(t, "1337", broadcasterID)
+			assert.IsType(t, twitch.EventStreamOnline{}, event)
+			close(ch)
+		})
+
+		client.OnWelcome(func(message twitch.WelcomeMessage) {
+			err := multi.Subscribe(twitch.SubscribeRequest{})
+			assert.NoError(t, err)
+		})
+
+		go connect(t, client)
+	})
+}
@@ -0,0 +1,184 @@
+package twitch
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Decoder unmarshals json into v. It has the same signature as json.Unmarshal
+// and like it must not keep a reference to data, which is reused after reading.
+type Decoder func(data []byte, v any) error
+
+type ClientOption func(c *Client)
+
+// WithDecoder replaces json.Unmarshal for decoding messages and events, for
+// example with json-iterator, sonic, or easyjson generated decoders.
+func WithDecoder(decoder Decoder) ClientOption {
+	return func(c *Client) {
+		c.decode = decoder
+	}
+}
+
+// WithStrictDecoding decodes events with unknown fields disallowed, which is
+// how changes to Twitch's schemas show up. Unknown fields are reported to
+// OnWarning as ErrUnknownField and the event is still decoded and delivered
+// with the regular decoder. Without it extra fields are ignored.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithRevocationRecovery cleans up after revoked subscriptions. The revoked
+// subscription is no longer recreated on new sessions and is deleted with the
+// credentials of the request that created it, if the client has one. When the
+// reason is authorization_revoked, reauth is called so the user can be asked
+// to authorize again. Failed deletes are reported to OnError.
+func WithRevocationRecovery(reauth func(revocation Revocation)) ClientOption {
+	return func(c *Client) {
+		c.revocationRecovery = true
+		c.onReauth = reauth
+	}
+}
+
+// WithErrorPolicy decides per error what happens with errors from handling
+// messages, like unknown subscription types and decode failures, instead of
+// passing every one of them to OnError.
+func WithErrorPolicy(policy ErrorPolicy) ClientOption {
+	return func(c *Client) {
+		c.errorPolicy = policy
+	}
+}
+
+// WithHandlerTimeout limits how long each handler and listener may take for
+// an event. Slower ones are reported to OnWarning as ErrSlowHandler, the
+// context of AddContextListener listeners is canceled, and the client moves on
+// without waiting for them to return.
+func WithHandlerTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.handlerTimeout = timeout
+	}
+}
+
+// WithReconnectLimit makes Run give up after attempts reconnects in a row
+// failed, or once it has been failing to connect for duration, instead of
+// retrying forever against problems like a revoked token. Zero leaves either
+// limit off.
+func WithReconnectLimit(attempts int, duration time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxReconnects = attempts
+		c.maxReconnectDuration = duration
+	}
+}
+
+// WithSessionStore saves the client's subscriptions and the IDs of recently
+// handled messages to store, and loads them before the first connection. The
+// loaded subscriptions are recreated on the new session with the ClientID,
+// AccessToken, and TokenSource of credentials, so OnWelcome does not need to
+// subscribe again, and redelivered messages are skipped.
+func WithSessionStore(store SessionStore, credentials SubscribeRequest) ClientOption {
+	return func(c *Client) {
+		c.sessionStore = store
+		c.sessionCredentials = credentials
+		c.messageIDs = newMessageIDs(sessionMessageIDs)
+	}
+}
+
+// WithWelcomeTimeout sets how long connecting waits for the session_welcome
+// message before failing with ErrWelcomeTimeout. It defaults to 10 seconds and
+// zero waits forever.
+func WithWelcomeTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.welcomeTimeout = timeout
+	}
+}
+
+// WithClock replaces the real time used for keepalive checks, timeouts, and
+// backoff, mostly so tests can advance it without sleeping.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// FrameRecorder receives every raw websocket message before it is handled.
+// The frame is only valid during the call. The recording package writes
+// frames to a file that can be replayed later.
+type FrameRecorder interface {
+	Record(received time.Time, frame []byte) error
+}
+
+// WithSubscriptionVersion pins the version Subscribe creates subscriptions of
+// a type with, unless a request sets its own VersionOverride. Events of
+// versions listed by SubscriptionVersions decode into their own Event type,
+// like EventChannelUpdateV1, for listeners, and are converted into the Event
+// type of the default version for its OnEvent callback.
+func WithSubscriptionVersion(subType EventSubscription, version string) ClientOption {
+	return func(c *Client) {
+		if c.subscriptionVersions == nil {
+			c.subscriptionVersions = map[EventSubscription]string{}
+		}
+		c.subscriptionVersions[subType] = version
+	}
+}
+
+// WithFrameRecorder records every message the client reads.
+func WithFrameRecorder(recorder FrameRecorder) ClientOption {
+	return func(c *Client) {
+		c.recorder = recorder
+	}
+}
+
+// WithLatencyThreshold calls OnHighLatency for messages read more than
+// threshold after their message_timestamp, or that much before it when the
+// local clock is behind Twitch's.
+func WithLatencyThreshold(threshold time.Duration) ClientOption {
+	return func(c *Client) {
+		c.latencyThreshold = threshold
+	}
+}
+
+// WithDeadLetters writes messages the client could not handle to sink, so
+// they can be inspected and replayed after upgrading the library.
+func WithDeadLetters(sink DeadLetterSink) ClientOption {
+	return func(c *Client) {
+		c.deadLetters = sink
+	}
+}
+
+// WithSyncDispatch runs event callbacks on the goroutine that handles the
+// message, so they have finished when HandleMessage returns. It takes
+// precedence over dispatch queues.
+func WithSyncDispatch() ClientOption {
+	return func(c *Client) {
+		c.syncDispatch = true
+	}
+}
+
+// WithDispatchQueue runs event callbacks in order from a queue holding at most
+// size events instead of starting a goroutine per event. The policy decides
+// what happens when callbacks fall behind and the queue fills up, and dropped
+// events are reported to OnEventsDropped.
+func WithDispatchQueue(size int, policy BackpressurePolicy) ClientOption {
+	return func(c *Client) {
+		c.queueSize = size
+		c.queuePolicy = policy
+	}
+}
+
+// WithOrderedDispatch gives every key its own dispatch queue, so events with
+// different keys are handled concurrently while events with the same key are
+// handled one at a time in the order they arrived. Queues use the size and
+// policy of WithDispatchQueue, or hold 1024 events and block by default. The
+// queue of a key is removed once it is empty, so keys that stop getting events
+// don't keep one.
+func WithOrderedDispatch(key OrderKey) ClientOption {
+	return func(c *Client) {
+		c.orderKey = key
+		if c.queueSize == 0 {
+			c.queueSize = defaultQueueSize
+		}
+	}
+}
+
+var defaultDecoder Decoder = json.Unmarshal
@@ -0,0 +1,204 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDecoder(t *testing.T) {
+	t.Parallel()
+
+	server, err := newTestServer(getTestEventData(twitch.SubStreamOnline))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls atomic.Int32
+	client := twitch.NewClientWithUrl(fmt.Sprintf("http://%s/ws", server.Address), twitch.WithDecoder(func(data []byte, v any) error {
+		calls.Add(1)
+		return json.Unmarshal(data, v)
+	}))
+	client.OnError(func(err error) {
+		t.Errorf("client registered an error: %v", err)
+	})
+	client.SubscriptionUrl = fmt.Sprintf("http://%s/subscriptions", server.Address)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		_, err := client.Subscribe(twitch.SubscribeRequest{Event: twitch.SubStreamOnline, Condition: testCondition})
+		if err != nil {
+			t.Errorf("could not subscribe: %v", err)
+		}
+	})
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+			if calls.Load() == 0 {
+				t.Error("custom decoder was not used")
+			}
+			close(ch)
+		})
+
+		go connect(t, client)
+	})
+}
+
+func TestWithOrderedDispatch(t *testing.T) {
+	t.Parallel()
+
+	var messages [][]byte
+	for _, event := range []twitch.EventSubscription{twitch.SubStreamOnline, twitch.SubStreamOnline, twitch.SubStreamOffline, twitch.SubStreamOnline} {
+		data, _, err := getTestEventData(event)()
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, data...)
+	}
+
+	client := twitch.NewClient(twitch.WithOrderedDispatch(twitch.OrderBySubscriptionType))
+
+	release := make(chan struct{})
+	offline := make(chan struct{})
+	online := make(chan string, 3)
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		switch event.(type) {
+		case twitch.EventStreamOnline:
+			<-release
+			online <- message.Metadata.MessageID
+		case twitch.EventStreamOffline:
+			close(offline)
+		}
+	})
+
+	var expected []string
+	for _, data := range messages {
+		err := client.HandleMessage(data)
+		assert.NoError(t, err)
+
+		var message twitch.NotificationMessage
+		assert.NoError(t, json.Unmarshal(data, &message))
+		if message.Payload.Subscription.Type == twitch.SubStreamOnline {
+			expected = append(expected, message.Metadata.MessageID)
+		}
+	}
+
+	// stream.offline is handled while the stream.online queue is blocked
+	select {
+	case <-offline:
+	case <-time.After(time.Second):
+		t.Fatal("stream.offline waited on stream.online")
+	}
+	close(release)
+
+	var received []string
+	for range expected {
+		select {
+		case id := <-online:
+			received = append(received, id)
+		case <-time.After(time.Second):
+			t.Fatal("stream.online was not handled")
+		}
+	}
+	assert.Equal(t, expected, received)
+}
+
+type recorderFunc func(received time.Time, frame []byte) error
+
+func (f recorderFunc) Record(received time.Time, frame []byte) error {
+	return f(received, frame)
+}
+
+func TestWithFrameRecorder(t *testing.T) {
+	t.Parallel()
+
+	server, err := newTestServer(keepAliveGen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames := make(chan string, 2)
+	client := twitch.NewClientWithUrl(fmt.Sprintf("http://%s/ws", server.Address), twitch.WithFrameRecorder(recorderFunc(func(received time.Time, frame []byte) error {
+		frames <- string(frame)
+		return nil
+	})))
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+	go connect(t, client)
+	defer client.Close()
+
+	for _, messageType := range []string{"session_welcome", "session_keepalive"} {
+		select {
+		case frame := <-frames:
+			assert.Contains(t, frame, messageType)
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not recorded", messageType)
+		}
+	}
+}
+
+func TestWithSyncDispatch(t *testing.T) {
+	t.Parallel()
+
+	data, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var handled bool
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		handled = true
+	})
+
+	assert.NoError(t, client.HandleMessage(data[0]))
+	assert.True(t, handled)
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	t.Parallel()
+
+	var message twitch.NotificationMessage
+	message.Metadata.MessageType = "notification"
+	message.Payload.Subscription.Type = twitch.SubStreamOffline
+	event := json.RawMessage(`{"broadcaster_user_id":"1337","new_field":true}`)
+	message.Payload.Event = &event
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, strict := range []bool{false, true} {
+		var options []twitch.ClientOption
+		if strict {
+			options = append(options, twitch.WithStrictDecoding())
+		}
+		client := twitch.NewClient(append(options, twitch.WithSyncDispatch())...)
+
+		var warnings []error
+		client.OnWarning(func(err error) {
+			warnings = append(warnings, err)
+		})
+
+		var offline twitch.EventStreamOffline
+		client.OnEventStreamOffline(func(event twitch.EventStreamOffline) {
+			offline = event
+		})
+
+		assert.NoError(t, client.HandleMessage(data))
+		assert.Equal(t, "1337", offline.BroadcasterUserId)
+
+		if !strict {
+			assert.Empty(t, warnings)
+			continue
+		}
+
+		if assert.Len(t, warnings, 1) {
+			assert.ErrorIs(t, warnings[0], twitch.ErrUnknownField)
+			assert.Contains(t, warnings[0].Error(), "new_field")
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package twitch
+
+import "sort"
+
+// TotalVotes returns the votes of all choices.
+func (e EventChannelPollBegin) TotalVotes() int {
+	return totalVotes(e.Choices)
+}
+
+// WinningChoice returns the choice with the most votes, or nil when no one
+// voted yet or choices are tied.
+func (e EventChannelPollBegin) WinningChoice() *PollChoice {
+	return winningChoice(e.Choices)
+}
+
+func (e EventChannelPollProgress) TotalVotes() int {
+	return totalVotes(e.Choices)
+}
+
+func (e EventChannelPollProgress) WinningChoice() *PollChoice {
+	return winningChoice(e.Choices)
+}
+
+func totalVotes(choices []PollChoice) int {
+	total := 0
+	for _, choice := range choices {
+		total += choice.Votes
+	}
+	return total
+}
+
+func winningChoice(choices []PollChoice) *PollChoice {
+	var winner *PollChoice
+	tied := false
+	for i := range choices {
+		switch {
+		case winner == nil || choices[i].Votes > winner.Votes:
+			winner, tied = &choices[i], false
+		case choices[i].Votes == winner.Votes:
+			tied = true
+		}
+	}
+
+	if winner == nil || winner.Votes == 0 || tied {
+		return nil
+	}
+	return winner
+}
+
+// TotalChannelPoints returns the channel points used on all outcomes.
+func (e EventChannelPredictionBegin) TotalChannelPoints() int {
+	return totalChannelPoints(e.Outcomes)
+}
+
+// TotalUsers returns how many users predicted any outcome.
+func (e EventChannelPredictionBegin) TotalUsers() int {
+	return totalUsers(e.Outcomes)
+}
+
+// TopPredictors returns up to n predictors of all outcomes with the most
+// channel points used.
+func (e EventChannelPredictionBegin) TopPredictors(n int) []TopPredictor {
+	return topPredictors(e.Outcomes, n, false)
+}
+
+func (e EventChannelPredictionProgress) TotalChannelPoints() int {
+	return totalChannelPoints(e.Outcomes)
+}
+
+func (e EventChannelPredictionProgress) TotalUsers() int {
+	return totalUsers(e.Outcomes)
+}
+
+func (e EventChannelPredictionProgress) TopPredictors(n int) []TopPredictor {
+	return topPredictors(e.Outcomes, n, false)
+}
+
+func (e EventChannelPredictionLock) TotalChannelPoints() int {
+	return totalChannelPoints(e.Outcomes)
+}
+
+func (e EventChannelPredictionLock) TotalUsers() int {
+	return totalUsers(e.Outcomes)
+}
+
+func (e EventChannelPredictionLock) TopPredictors(n int) []TopPredictor {
+	return topPredictors(e.Outcomes, n, false)
+}
+
+func (e EventChannelPredictionEnd) TotalChannelPoints() int {
+	return totalChannelPoints(e.Outcomes)
+}
+
+func (e EventChannelPredictionEnd) TotalUsers() int {
+	return totalUsers(e.Outcomes)
+}
+
+// WinningOutcome returns the outcome of WinningOutcomeID, or nil when the
+// prediction was canceled.
+func (e EventChannelPredictionEnd) WinningOutcome() *PredictionOutcome {
+	for i := range e.Outcomes {
+		if e.Outcomes[i].ID == e.WinningOutcomeID {
+			return &e.Outcomes[i]
+		}
+	}
+	return nil
+}
+
+// TopPredictors returns up to n predictors of all outcomes with the most
+// channel points won.
+func (e EventChannelPredictionEnd) TopPredictors(n int) []TopPredictor {
+	return topPredictors(e.Outcomes, n, true)
+}
+
+func totalChannelPoints(outcomes []PredictionOutcome) int {
+	total := 0
+	for _, outcome := range outcomes {
+		total += outcome.ChannelPoints
+	}
+	return total
+}
+
+func totalUsers(outcomes []PredictionOutcome) int {
+	total := 0
+	for _, outcome := range outcomes {
+		total += outcome.Users
+	}
+	return total
+}
+
+func topPredictors(outcomes []PredictionOutcome, n int, byWon bool) []TopPredictor {
+	var predictors []TopPredictor
+	for _, outcome := range outcomes {
+		predictors = append(predictors, outcome.TopPredictors...)
+	}
+
+	sort.SliceStable(predictors, func(i, j int) bool {
+		if byWon && predictors[i].ChannelPointsWon != predictors[j].ChannelPointsWon {
+			return predictors[i].ChannelPointsWon > predictors[j].ChannelPointsWon
+		}
+		return predictors[i].ChannelPointsUsed > predictors[j].ChannelPointsUsed
+	})
+
+	if n >= 0 && len(predictors) > n {
+		predictors = predictors[:n]
+	}
+	return predictors
+}
@@ -0,0 +1,59 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestEvent(t *testing.T, eventType twitch.EventSubscription, v any) {
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(testEvents, &events); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(events[string(eventType)], v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPollOutcome(t *testing.T) {
+	t.Parallel()
+
+	var poll twitch.EventChannelPollProgress
+	decodeTestEvent(t, twitch.SubChannelPollProgress, &poll)
+
+	assert.Equal(t, 33, poll.TotalVotes())
+	if assert.NotNil(t, poll.WinningChoice()) {
+		assert.Equal(t, "No!", poll.WinningChoice().Title)
+	}
+
+	tied := twitch.EventChannelPollBegin{Choices: []twitch.PollChoice{{Votes: 3}, {Votes: 3}, {Votes: 1}}}
+	assert.Nil(t, tied.WinningChoice())
+	assert.Nil(t, twitch.EventChannelPollBegin{Choices: []twitch.PollChoice{{}, {}}}.WinningChoice())
+}
+
+func TestPredictionOutcome(t *testing.T) {
+	t.Parallel()
+
+	var prediction twitch.EventChannelPredictionEnd
+	decodeTestEvent(t, twitch.SubChannelPredictionEnd, &prediction)
+
+	assert.Equal(t, 15200, prediction.TotalChannelPoints())
+	assert.Equal(t, 4, prediction.TotalUsers())
+	if assert.NotNil(t, prediction.WinningOutcome()) {
+		assert.Equal(t, "Yeah!", prediction.WinningOutcome().Title)
+	}
+
+	top := prediction.TopPredictors(3)
+	if assert.Len(t, top, 3) {
+		assert.Equal(t, "1234", top[0].UserID)
+		assert.Equal(t, "1236", top[1].UserID)
+		assert.Equal(t, "12345", top[2].UserID)
+	}
+	assert.Len(t, prediction.TopPredictors(10), 4)
+
+	prediction.WinningOutcomeID = ""
+	assert.Nil(t, prediction.WinningOutcome())
+}
@@ -0,0 +1,34 @@
+package twitch
+
+// WithPauseBuffer sets how many notifications are held while the client is
+// paused. The ones after that are dropped and reported to OnEventsDropped. A
+// limit of 0 holds any number, like WithHoldUntilReady. Without it, every
+// notification arriving while paused is dropped.
+func WithPauseBuffer(limit int) ClientOption {
+	return func(c *Client) {
+		if limit == 0 {
+			limit = -1
+		}
+		c.pauseGate.limit = limit
+	}
+}
+
+// Pause stops handing notifications to the callbacks, for example while the
+// application migrates its database. The connection stays up and keepalives
+// are still handled, so the session is kept. Notifications are held up to
+// the limit of WithPauseBuffer until Resume.
+func (c *Client) Pause() {
+	c.pauseGate.close()
+}
+
+// Resume hands the notifications held since Pause to the callbacks in the
+// order they arrived and returns how many were handed over and how many were
+// dropped.
+func (c *Client) Resume() (delivered, dropped int) {
+	return c.pauseGate.release(func(message NotificationMessage) {
+		err := c.deliverNotification(message)
+		if err != nil {
+			c.onError(err)
+		}
+	})
+}
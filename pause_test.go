@@ -0,0 +1,90 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func handleTitles(t *testing.T, client *twitch.Client, titles ...string) {
+	t.Helper()
+
+	for _, title := range titles {
+		data, err := twitch.EncodeNotification(twitch.EventChannelUpdate{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+}
+
+func TestPause(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithPauseBuffer(2))
+
+	var titles []string
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		titles = append(titles, event.Title)
+	})
+	dropped := 0
+	client.OnEventsDropped(func(count int, eventType twitch.EventSubscription) {
+		dropped += count
+	})
+
+	handleTitles(t, client, "before")
+	client.Pause()
+	handleTitles(t, client, "first", "second", "third")
+	assert.Equal(t, []string{"before"}, titles)
+	assert.Equal(t, 1, dropped)
+
+	delivered, droppedOnResume := client.Resume()
+	assert.Equal(t, 2, delivered)
+	assert.Equal(t, 1, droppedOnResume)
+
+	handleTitles(t, client, "after")
+	assert.Equal(t, []string{"before", "first", "second", "after"}, titles)
+
+	delivered, droppedOnResume = client.Resume()
+	assert.Zero(t, delivered)
+	assert.Zero(t, droppedOnResume)
+}
+
+func TestPauseWithoutBuffer(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var titles []string
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		titles = append(titles, event.Title)
+	})
+
+	client.Pause()
+	handleTitles(t, client, "first", "second")
+
+	delivered, dropped := client.Resume()
+	assert.Zero(t, delivered)
+	assert.Equal(t, 2, dropped)
+	assert.Empty(t, titles)
+}
+
+func TestPauseUnlimitedBuffer(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithPauseBuffer(0))
+
+	var titles []string
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		titles = append(titles, event.Title)
+	})
+
+	client.Pause()
+	handleTitles(t, client, "first", "second", "third")
+
+	delivered, dropped := client.Resume()
+	assert.Equal(t, 3, delivered)
+	assert.Zero(t, dropped)
+	assert.Equal(t, []string{"first", "second", "third"}, titles)
+}
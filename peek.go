@@ -0,0 +1,243 @@
+package twitch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var errPeekSyntax = errors.New("invalid json")
+
+// peekMetadata reads only the message type and id out of a message so the
+// rest of it is decoded once into its final type. It scans the bytes without
+// allocating, apart from the message id, and stops as soon as both fields are
+// found, which is right away since Twitch sends the metadata before the
+// payload.
+func peekMetadata(data []byte) (MessageMetadata, error) {
+	var metadata MessageMetadata
+
+	s := peekScanner{data: data}
+	err := s.object(func(key []byte) (bool, error) {
+		if string(key) != "metadata" {
+			return true, s.skipValue()
+		}
+
+		err := s.object(func(key []byte) (bool, error) {
+			var err error
+			switch string(key) {
+			case "message_type":
+				metadata.MessageType, err = s.stringValue()
+			case "message_id":
+				metadata.MessageID, err = s.stringValue()
+			default:
+				err = s.skipValue()
+			}
+			return metadata.MessageType == "" || metadata.MessageID == "", err
+		})
+		return false, err
+	})
+	if err != nil {
+		return MessageMetadata{}, fmt.Errorf("could not read message: %w", err)
+	}
+	return metadata, nil
+}
+
+// peekScanner walks a json message without decoding the values it skips.
+type peekScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *peekScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// next returns the next byte that is not whitespace without consuming it.
+func (s *peekScanner) next() (byte, error) {
+	s.skipSpace()
+	if s.pos >= len(s.data) {
+		return 0, fmt.Errorf("%w: unexpected end", errPeekSyntax)
+	}
+	return s.data[s.pos], nil
+}
+
+func (s *peekScanner) expect(c byte) error {
+	next, err := s.next()
+	if err != nil {
+		return err
+	}
+	if next != c {
+		return fmt.Errorf("%w: expected %q but got %q", errPeekSyntax, c, next)
+	}
+	s.pos++
+	return nil
+}
+
+// object calls member with the raw key of every member of an object, with
+// the scanner at the value, which member has to consume. Scanning stops early
+// when member returns false.
+func (s *peekScanner) object(member func(key []byte) (bool, error)) error {
+	err := s.expect('{')
+	if err != nil {
+		return err
+	}
+
+	next, err := s.next()
+	if err != nil {
+		return err
+	}
+	if next == '}' {
+		s.pos++
+		return nil
+	}
+
+	for {
+		key, _, err := s.rawString()
+		if err != nil {
+			return err
+		}
+		err = s.expect(':')
+		if err != nil {
+			return err
+		}
+
+		more, err := member(key)
+		if err != nil || !more {
+			return err
+		}
+
+		next, err := s.next()
+		if err != nil {
+			return err
+		}
+		s.pos++
+		switch next {
+		case ',':
+		case '}':
+			return nil
+		default:
+			return fmt.Errorf("%w: expected , or } but got %q", errPeekSyntax, next)
+		}
+	}
+}
+
+// rawString consumes a string and returns its contents between the quotes,
+// which still need unescaping if escaped is set.
+func (s *peekScanner) rawString() (raw []byte, escaped bool, err error) {
+	err = s.expect('"')
+	if err != nil {
+		return nil, false, err
+	}
+
+	start := s.pos
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\\':
+			escaped = true
+			s.pos += 2
+		case '"':
+			raw = s.data[start:s.pos]
+			s.pos++
+			return raw, escaped, nil
+		default:
+			s.pos++
+		}
+	}
+	return nil, false, fmt.Errorf("%w: unterminated string", errPeekSyntax)
+}
+
+func (s *peekScanner) stringValue() (string, error) {
+	next, err := s.next()
+	if err != nil {
+		return "", err
+	}
+	if next != '"' {
+		return "", fmt.Errorf("expected metadata string but got %q", next)
+	}
+
+	start := s.pos
+	raw, escaped, err := s.rawString()
+	if err != nil {
+		return "", err
+	}
+	if escaped {
+		var value string
+		err = json.Unmarshal(s.data[start:s.pos], &value)
+		if err != nil {
+			return "", fmt.Errorf("could not unescape metadata string: %w", err)
+		}
+		return value, nil
+	}
+
+	// The message types are constants, so only the id is allocated
+	switch string(raw) {
+	case "notification":
+		return "notification", nil
+	case "session_welcome":
+		return "session_welcome", nil
+	case "session_keepalive":
+		return "session_keepalive", nil
+	case "session_reconnect":
+		return "session_reconnect", nil
+	case "revocation":
+		return "revocation", nil
+	}
+	return string(raw), nil
+}
+
+// skipValue consumes any value, keeping track of nesting and strings so
+// brackets inside strings are not counted.
+func (s *peekScanner) skipValue() error {
+	next, err := s.next()
+	if err != nil {
+		return err
+	}
+
+	switch next {
+	case '"':
+		_, _, err = s.rawString()
+		return err
+	case '{', '[':
+		depth := 0
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case '"':
+				_, _, err = s.rawString()
+				if err != nil {
+					return err
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+			s.pos++
+			if depth == 0 {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: unexpected end", errPeekSyntax)
+	default:
+		// Numbers, true, false, and null run until the next delimiter
+		start := s.pos
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				if s.pos == start {
+					return fmt.Errorf("%w: unexpected %q", errPeekSyntax, s.data[s.pos])
+				}
+				return nil
+			}
+			s.pos++
+		}
+		return fmt.Errorf("%w: unexpected end", errPeekSyntax)
+	}
+}
@@ -0,0 +1,91 @@
+package twitch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeekMetadata(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Data     string
+		Expected MessageMetadata
+		Error    bool
+	}{
+		{
+			"MetadataFirst",
+			`{"metadata":{"message_id":"1","message_type":"notification","message_timestamp":"2023-01-01T00:00:00Z"},"payload":{"event":{}}}`,
+			MessageMetadata{MessageID: "1", MessageType: "notification"},
+			false,
+		},
+		{
+			"PayloadFirst",
+			`{"payload":{"session":{"id":"abc","nested":[1,{"a":"b"}]}},"metadata":{"message_type":"session_welcome","message_id":"2"}}`,
+			MessageMetadata{MessageID: "2", MessageType: "session_welcome"},
+			false,
+		},
+		{
+			"SkippedValues",
+			`{"payload": {"a": "}]\"", "b": [true, null, -1.5e3]}, "n": 12, "metadata": {"x": false, "message_type": "session_keepalive", "message_id": "3"}}`,
+			MessageMetadata{MessageID: "3", MessageType: "session_keepalive"},
+			false,
+		},
+		{
+			"Escaped",
+			`{"metadata":{"message_id":"a\"b","message_type":"revocation"}}`,
+			MessageMetadata{MessageID: `a"b`, MessageType: "revocation"},
+			false,
+		},
+		{
+			"NoMetadata",
+			`{}`,
+			MessageMetadata{},
+			false,
+		},
+		{
+			"Incomplete",
+			`{`,
+			MessageMetadata{},
+			true,
+		},
+		{
+			"NotObject",
+			`[]`,
+			MessageMetadata{},
+			true,
+		},
+		{
+			"NonStringType",
+			`{"metadata":{"message_type":1}}`,
+			MessageMetadata{},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			metadata, err := peekMetadata([]byte(tc.Data))
+			if tc.Error {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, metadata)
+		})
+	}
+}
+
+func TestPeekMetadataAllocs(t *testing.T) {
+	data := []byte(`{"metadata":{"message_id":"befa7b53-d79d-478f-86b9-120f112b044e","message_type":"notification","message_timestamp":"2023-01-01T00:00:00Z"},"payload":{"event":{}}}`)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, err := peekMetadata(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	// Only the message id is allocated
+	assert.Equal(t, 1.0, allocs)
+}
@@ -0,0 +1,97 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Publisher is implemented by adapters for message buses such as NATS, Kafka,
+// or Redis.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// NotificationPublisher is a Publisher that also gets the notification the
+// payload was made from, for buses that send keys, headers, or flags along
+// with it.
+type NotificationPublisher interface {
+	Publisher
+	PublishNotification(ctx context.Context, topic string, payload []byte, message NotificationMessage, event any) error
+}
+
+// PublisherBridge forwards every decoded notification of a client to a
+// Publisher. By default the topic is the subscription type and the payload is
+// the notification message as JSON.
+//
+// Notifications are published one at a time in the order they arrived. A
+// slow publisher holds up the read loop once 1024 of them are waiting.
+type PublisherBridge struct {
+	client    *Client
+	publisher Publisher
+	queue     *dispatchQueue
+
+	Topic   func(message NotificationMessage, event any) string
+	Payload func(message NotificationMessage, event any) ([]byte, error)
+}
+
+func NewPublisherBridge(client *Client, publisher Publisher) *PublisherBridge {
+	b := &PublisherBridge{
+		client:    client,
+		publisher: publisher,
+		queue:     newDispatchQueue(defaultQueueSize, BackpressureBlock, nil),
+		Topic:     defaultPublisherTopic,
+		Payload:   defaultPublisherPayload,
+	}
+	client.addContextListener(b.enqueue, true)
+	return b
+}
+
+func defaultPublisherTopic(message NotificationMessage, event any) string {
+	return string(message.Payload.Subscription.Type)
+}
+
+func defaultPublisherPayload(message NotificationMessage, event any) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+// enqueue runs on the read loop, so notifications are queued in order.
+func (b *PublisherBridge) enqueue(ctx context.Context, message NotificationMessage, event any) {
+	if b.client.syncDispatch {
+		b.publish(ctx, message, event)
+		return
+	}
+
+	subType := message.Payload.Subscription.Type
+	b.queue.push(b.client.labels, queuedEvent{Type: subType, Run: func() {
+		b.client.callHandler(subType, "publisher", func(ctx context.Context) {
+			b.publish(ctx, message, event)
+		})
+	}})
+}
+
+// publish uses the context of the handler call rather than the connection,
+// so events that arrived before a disconnect are still published, and only a
+// handler timeout cancels them.
+func (b *PublisherBridge) publish(ctx context.Context, message NotificationMessage, event any) {
+	payload, err := b.Payload(message, event)
+	if err != nil {
+		b.client.onError(fmt.Errorf("could not create payload for %s: %w", message.Metadata.MessageID, err))
+		return
+	}
+
+	topic := b.Topic(message, event)
+	if err := ctx.Err(); err != nil {
+		b.client.onError(fmt.Errorf("could not publish %s to %s: %w", message.Metadata.MessageID, topic, err))
+		return
+	}
+
+	if publisher, ok := b.publisher.(NotificationPublisher); ok {
+		err = publisher.PublishNotification(ctx, topic, payload, message, event)
+	} else {
+		err = b.publisher.Publish(ctx, topic, payload)
+	}
+	if err != nil {
+		b.client.onError(fmt.Errorf("could not publish %s to %s: %w", message.Metadata.MessageID, topic, err))
+	}
+}
@@ -0,0 +1,65 @@
+package twitch_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type publisherFunc func(ctx context.Context, topic string, payload []byte) error
+
+func (f publisherFunc) Publish(ctx context.Context, topic string, payload []byte) error {
+	return f(ctx, topic, payload)
+}
+
+func TestPublisherBridge(t *testing.T) {
+	t.Parallel()
+
+	assertSpecificEventOccured(t, func(client *twitch.Client, ch chan struct{}) {
+		twitch.NewPublisherBridge(client, publisherFunc(func(ctx context.Context, topic string, payload []byte) error {
+			assert.Equal(t, string(twitch.SubStreamOnline), topic)
+
+			var message twitch.NotificationMessage
+			err := json.Unmarshal(payload, &message)
+			assert.NoError(t, err)
+			assert.Equal(t, twitch.SubStreamOnline, message.Payload.Subscription.Type)
+
+			close(ch)
+			return nil
+		}))
+	}, twitch.SubStreamOnline)
+}
+
+func TestPublisherBridgeAfterDisconnect(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, func() ([][]byte, bool, error) { return nil, false, nil })
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		client.Close()
+	})
+
+	published := make(chan error, 1)
+	twitch.NewPublisherBridge(client, publisherFunc(func(ctx context.Context, topic string, payload []byte) error {
+		published <- ctx.Err()
+		return nil
+	}))
+
+	connect(t, client)
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+
+	select {
+	case err := <-published:
+		assert.NoError(t, err, "events that arrived before the disconnect are published")
+	case <-time.After(time.Second):
+		t.Fatal("event was not published")
+	}
+}
@@ -0,0 +1,158 @@
+package twitch
+
+import (
+	"context"
+	"sync"
+)
+
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock stops reading messages until the queue has room
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest drops the event waiting the longest to make room
+	BackpressureDropOldest
+	// BackpressureDropNewest drops the incoming event when the queue is full
+	BackpressureDropNewest
+)
+
+const defaultQueueSize = 1024
+
+// OrderKey groups events that have to be handled in order.
+type OrderKey func(message NotificationMessage, event any) string
+
+// OrderBySubscriptionType keeps events of the same subscription type in order.
+func OrderBySubscriptionType(message NotificationMessage, event any) string {
+	return string(message.Payload.Subscription.Type)
+}
+
+// OrderByBroadcaster keeps events of the same subscription type and
+// broadcaster in order.
+func OrderByBroadcaster(message NotificationMessage, event any) string {
+	return string(message.Payload.Subscription.Type) + ":" + BroadcasterUserID(message, event)
+}
+
+type queuedEvent struct {
+	Type EventSubscription
+	Run  func()
+}
+
+// dispatchQueue runs queued events one at a time. The worker goroutine is
+// started when an event is pushed and exits once the queue is empty, so the
+// queue needs no closing when the client stops.
+type dispatchQueue struct {
+	size      int
+	policy    BackpressurePolicy
+	onDropped func(count int, eventType EventSubscription)
+
+	mu      sync.Mutex
+	notFull *sync.Cond
+	items   []queuedEvent
+	dropped map[EventSubscription]int
+	running bool
+
+	// onIdle is called when the worker exits
+	onIdle func()
+	// users counts the callers about to push, guarded by the lock of the
+	// map the queue is kept in, so the queue is not removed under them
+	users int
+}
+
+func newDispatchQueue(size int, policy BackpressurePolicy, onDropped func(count int, eventType EventSubscription)) *dispatchQueue {
+	if size < 1 {
+		size = 1
+	}
+
+	q := &dispatchQueue{
+		size:      size,
+		policy:    policy,
+		onDropped: onDropped,
+		dropped:   map[EventSubscription]int{},
+	}
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues an item, starting a worker labeled with the labels of ctx when
+// none is running.
+func (q *dispatchQueue) push(ctx context.Context, item queuedEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.policy == BackpressureBlock {
+		for len(q.items) >= q.size {
+			q.notFull.Wait()
+		}
+	}
+
+	if len(q.items) >= q.size {
+		if q.policy == BackpressureDropNewest {
+			q.dropped[item.Type]++
+			return
+		}
+
+		q.dropped[q.items[0].Type]++
+		q.items[0] = queuedEvent{}
+		q.items = q.items[1:]
+	}
+	q.items = append(q.items, item)
+
+	if !q.running {
+		q.running = true
+		goLabeled(ctx, roleQueueWorker, q.run)
+	}
+}
+
+func (q *dispatchQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// idle reports whether the queue is empty and has no worker.
+func (q *dispatchQueue) idle() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return !q.running && len(q.items) == 0
+}
+
+func (q *dispatchQueue) run() {
+	for {
+		q.mu.Lock()
+		dropped := q.takeDropped()
+		if len(q.items) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			q.reportDropped(dropped)
+			if q.onIdle != nil {
+				q.onIdle()
+			}
+			return
+		}
+
+		item := q.items[0]
+		q.items[0] = queuedEvent{}
+		q.items = q.items[1:]
+		q.notFull.Signal()
+		q.mu.Unlock()
+
+		q.reportDropped(dropped)
+		item.Run()
+	}
+}
+
+func (q *dispatchQueue) takeDropped() map[EventSubscription]int {
+	if len(q.dropped) == 0 {
+		return nil
+	}
+
+	dropped := q.dropped
+	q.dropped = map[EventSubscription]int{}
+	return dropped
+}
+
+func (q *dispatchQueue) reportDropped(dropped map[EventSubscription]int) {
+	for eventType, count := range dropped {
+		q.onDropped(count, eventType)
+	}
+}
@@ -0,0 +1,147 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type queueRecorder struct {
+	mu      sync.Mutex
+	ran     []string
+	dropped map[EventSubscription]int
+	done    chan struct{}
+}
+
+func newQueueRecorder() *queueRecorder {
+	return &queueRecorder{dropped: map[EventSubscription]int{}, done: make(chan struct{}, 10)}
+}
+
+func (r *queueRecorder) item(name string, block chan struct{}) queuedEvent {
+	return queuedEvent{
+		Type: EventSubscription(name),
+		Run: func() {
+			if block != nil {
+				<-block
+			}
+
+			r.mu.Lock()
+			r.ran = append(r.ran, name)
+			r.mu.Unlock()
+			r.done <- struct{}{}
+		},
+	}
+}
+
+func (r *queueRecorder) onDropped(count int, eventType EventSubscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dropped[eventType] += count
+}
+
+func (r *queueRecorder) wait(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-r.done:
+		case <-time.After(time.Second):
+			t.Fatal("queued event did not run")
+		}
+	}
+}
+
+func TestDispatchQueueDrop(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Policy  BackpressurePolicy
+		Ran     []string
+		Dropped map[EventSubscription]int
+	}{
+		{"DropOldest", BackpressureDropOldest, []string{"a", "c"}, map[EventSubscription]int{"b": 1}},
+		{"DropNewest", BackpressureDropNewest, []string{"a", "b"}, map[EventSubscription]int{"c": 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			recorder := newQueueRecorder()
+			queue := newDispatchQueue(1, tc.Policy, recorder.onDropped)
+
+			release := make(chan struct{})
+			queue.push(context.Background(), recorder.item("a", release))
+			// Wait for the worker to take a so the queue only holds b
+			assert.Eventually(t, func() bool {
+				queue.mu.Lock()
+				defer queue.mu.Unlock()
+				return len(queue.items) == 0
+			}, time.Second, time.Millisecond)
+
+			queue.push(context.Background(), recorder.item("b", nil))
+			queue.push(context.Background(), recorder.item("c", nil))
+			close(release)
+			recorder.wait(t, 2)
+
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			assert.Equal(t, tc.Ran, recorder.ran)
+			assert.Equal(t, tc.Dropped, recorder.dropped)
+		})
+	}
+}
+
+func TestDispatchQueueBlock(t *testing.T) {
+	recorder := newQueueRecorder()
+	queue := newDispatchQueue(1, BackpressureBlock, recorder.onDropped)
+
+	release := make(chan struct{})
+	queue.push(context.Background(), recorder.item("a", release))
+	queue.push(context.Background(), recorder.item("b", nil))
+
+	pushed := make(chan struct{})
+	go func() {
+		queue.push(context.Background(), recorder.item("c", nil))
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("push did not block on a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	recorder.wait(t, 3)
+	<-pushed
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	assert.Equal(t, []string{"a", "b", "c"}, recorder.ran)
+	assert.Empty(t, recorder.dropped)
+}
+
+func TestDispatchQueueRemovedWhenEmpty(t *testing.T) {
+	client := NewClient(WithOrderedDispatch(OrderByBroadcaster))
+
+	var handled sync.WaitGroup
+	client.OnEventStreamOnline(func(event EventStreamOnline) {
+		handled.Done()
+	})
+
+	for i := 0; i < 50; i++ {
+		data, err := EncodeNotification(EventStreamOnline{Broadcaster: Broadcaster{BroadcasterUserId: fmt.Sprint(i)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		handled.Add(1)
+		assert.NoError(t, client.HandleMessage(data))
+	}
+	handled.Wait()
+
+	assert.Eventually(t, func() bool {
+		client.queuesMu.Lock()
+		defer client.queuesMu.Unlock()
+		return len(client.queues) == 0
+	}, time.Second, time.Millisecond, "queues of broadcasters without events are removed")
+}
@@ -0,0 +1,49 @@
+package twitch
+
+// IncomingRaids returns a channel.raid request for raids into the
+// broadcaster's channel. The credentials still need to be set.
+func IncomingRaids(broadcasterID string) SubscribeRequest {
+	return SubscribeRequest{
+		Event:     SubChannelRaid,
+		Condition: Condition{ToBroadcasterUserID: broadcasterID}.Map(),
+	}
+}
+
+// OutgoingRaids returns a channel.raid request for raids the broadcaster
+// starts. The credentials still need to be set.
+func OutgoingRaids(broadcasterID string) SubscribeRequest {
+	return SubscribeRequest{
+		Event:     SubChannelRaid,
+		Condition: Condition{FromBroadcasterUserID: broadcasterID}.Map(),
+	}
+}
+
+// IsIncomingFor reports whether the raid goes into the broadcaster's channel.
+func (e EventChannelRaid) IsIncomingFor(broadcasterID string) bool {
+	return e.ToBroadcasterUserId == broadcasterID
+}
+
+// IsOutgoingFor reports whether the broadcaster started the raid.
+func (e EventChannelRaid) IsOutgoingFor(broadcasterID string) bool {
+	return e.FromBroadcasterUserId == broadcasterID
+}
+
+// OnIncomingRaid registers a listener for raids into the broadcaster's
+// channel. Off removes it again.
+func OnIncomingRaid(client EventSubClient, broadcasterID string, callback func(event EventChannelRaid)) HandlerID {
+	return On(client, func(event EventChannelRaid) {
+		if event.IsIncomingFor(broadcasterID) {
+			callback(event)
+		}
+	})
+}
+
+// OnOutgoingRaid registers a listener for raids the broadcaster starts. Off
+// removes it again.
+func OnOutgoingRaid(client EventSubClient, broadcasterID string, callback func(event EventChannelRaid)) HandlerID {
+	return On(client, func(event EventChannelRaid) {
+		if event.IsOutgoingFor(broadcasterID) {
+			callback(event)
+		}
+	})
+}
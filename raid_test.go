@@ -0,0 +1,38 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaidRequests(t *testing.T) {
+	t.Parallel()
+
+	incoming := twitch.IncomingRaids("1337")
+	assert.Equal(t, twitch.SubChannelRaid, incoming.Event)
+	assert.Equal(t, map[string]string{"to_broadcaster_user_id": "1337"}, incoming.Condition)
+
+	outgoing := twitch.OutgoingRaids("1337")
+	assert.Equal(t, map[string]string{"from_broadcaster_user_id": "1337"}, outgoing.Condition)
+	assert.NoError(t, twitch.ValidateCondition(outgoing.Event, outgoing.Condition))
+}
+
+func TestRaidDirection(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var incoming, outgoing []twitch.EventChannelRaid
+	twitch.OnIncomingRaid(client, "1337", func(event twitch.EventChannelRaid) { incoming = append(incoming, event) })
+	twitch.OnOutgoingRaid(client, "1337", func(event twitch.EventChannelRaid) { outgoing = append(outgoing, event) })
+
+	handleEvents(t, client, twitch.SubChannelRaid)
+	if assert.Len(t, incoming, 1) {
+		assert.True(t, incoming[0].IsIncomingFor("1337"))
+		assert.True(t, incoming[0].IsOutgoingFor("1234"))
+		assert.False(t, incoming[0].IsOutgoingFor("1337"))
+	}
+	assert.Empty(t, outgoing)
+}
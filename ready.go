@@ -0,0 +1,131 @@
+package twitch
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WithHoldUntilReady holds notifications back from the callbacks until the
+// application calls Ready, so subscriptions created in OnWelcome don't race
+// handlers that are registered later. At most limit notifications are held
+// and the ones after that are dropped and reported to OnEventsDropped. A
+// limit of 0 holds any number.
+func WithHoldUntilReady(limit int) ClientOption {
+	return func(c *Client) {
+		if limit == 0 {
+			limit = -1
+		}
+		c.readyGate = &notificationGate{limit: limit}
+	}
+}
+
+// notificationGate holds notifications back while it is closed.
+type notificationGate struct {
+	// limit is the number of notifications held, or negative for any number
+	limit   int
+	open    bool
+	held    []NotificationMessage
+	dropped int
+	mu      sync.Mutex
+}
+
+// hold keeps message until the gate opens and reports whether it did. The
+// second result is whether the message was dropped instead.
+func (g *notificationGate) hold(message NotificationMessage) (held bool, dropped bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.open {
+		return false, false
+	}
+	if g.limit >= 0 && len(g.held) >= g.limit {
+		g.dropped++
+		return true, true
+	}
+	g.held = append(g.held, message)
+	return true, false
+}
+
+func (g *notificationGate) close() {
+	g.mu.Lock()
+	g.open = false
+	g.mu.Unlock()
+}
+
+// release passes the held notifications to deliver, followed by the ones held
+// meanwhile, and opens the gate. It returns how many were delivered and how
+// many were dropped while the gate was closed.
+func (g *notificationGate) release(deliver func(message NotificationMessage)) (delivered, dropped int) {
+	for {
+		g.mu.Lock()
+		held := g.held
+		g.held = nil
+		if len(held) == 0 {
+			g.open = true
+			dropped = g.dropped
+			g.dropped = 0
+		}
+		g.mu.Unlock()
+
+		if len(held) == 0 {
+			return delivered, dropped
+		}
+		for _, message := range held {
+			deliver(message)
+		}
+		delivered += len(held)
+	}
+}
+
+// Ready hands the notifications held by WithHoldUntilReady to the callbacks in
+// the order they arrived, followed by the ones arriving meanwhile, and lets
+// later ones through. Without WithHoldUntilReady or once the client is ready,
+// it does nothing.
+func (c *Client) Ready() {
+	if c.readyGate == nil {
+		return
+	}
+	c.readyGate.release(func(message NotificationMessage) {
+		err := c.dispatchReady(message)
+		if err != nil {
+			c.onError(err)
+		}
+	})
+}
+
+func (c *Client) dispatchNotification(message NotificationMessage) error {
+	if c.holdNotification(c.readyGate, message) {
+		return nil
+	}
+	return c.dispatchReady(message)
+}
+
+// dispatchReady dispatches a notification that passed the ready gate, which
+// the pause gate may still hold.
+func (c *Client) dispatchReady(message NotificationMessage) error {
+	if c.holdNotification(c.pauseGate, message) {
+		return nil
+	}
+	return c.deliverNotification(message)
+}
+
+func (c *Client) holdNotification(gate *notificationGate, message NotificationMessage) bool {
+	if gate == nil {
+		return false
+	}
+	held, dropped := gate.hold(message)
+	if dropped && c.onEventsDropped != nil {
+		c.onEventsDropped(1, message.Payload.Subscription.Type)
+	}
+	return held
+}
+
+func (c *Client) deliverNotification(message NotificationMessage) error {
+	callFunc(c.onNotification, message)
+
+	err := c.handleNotification(message)
+	if err != nil {
+		return fmt.Errorf("could not handle notification: %w", err)
+	}
+	return nil
+}
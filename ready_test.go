@@ -0,0 +1,62 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldUntilReady(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithHoldUntilReady(2))
+
+	var dropped []twitch.EventSubscription
+	client.OnEventsDropped(func(count int, eventType twitch.EventSubscription) {
+		assert.Equal(t, 1, count)
+		dropped = append(dropped, eventType)
+	})
+
+	for _, title := range []string{"first", "second", "third"} {
+		data, err := twitch.EncodeNotification(twitch.EventChannelUpdate{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+	assert.Equal(t, []twitch.EventSubscription{twitch.SubChannelUpdate}, dropped)
+
+	var titles []string
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		titles = append(titles, event.Title)
+	})
+	client.Ready()
+	assert.Equal(t, []string{"first", "second"}, titles)
+
+	data, err := twitch.EncodeNotification(twitch.EventChannelUpdate{Title: "fourth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	assert.Equal(t, []string{"first", "second", "fourth"}, titles)
+}
+
+func TestReadyWithoutHold(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.Ready()
+
+	var titles []string
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		titles = append(titles, event.Title)
+	})
+
+	data, err := twitch.EncodeNotification(twitch.EventChannelUpdate{Title: "live"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	assert.Equal(t, []string{"live"}, titles)
+}
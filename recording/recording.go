@@ -0,0 +1,187 @@
+// Package recording writes the raw messages a client reads to a file and
+// replays them into a client later, at their original pace or faster, to
+// reproduce decode bugs and test handlers against real traffic.
+//
+//	file, _ := os.Create("session.jsonl")
+//	client := twitch.NewClient(twitch.WithFrameRecorder(recording.NewWriter(file)))
+//
+// Recordings hold one json Frame per line. The bench package replays
+// message streams through the same Replayer.
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const maxFrameSize = 1024 * 1024
+
+// Frame is a recorded message. Data is stored base64 encoded, so malformed
+// messages, including invalid UTF-8, are recorded as they arrived.
+type Frame struct {
+	Received time.Time `json:"received"`
+	Data     []byte    `json:"data"`
+}
+
+// Writer implements twitch.FrameRecorder.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+var _ twitch.FrameRecorder = (*Writer)(nil)
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+func (w *Writer) Record(received time.Time, frame []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.enc.Encode(Frame{Received: received, Data: frame})
+	if err != nil {
+		return fmt.Errorf("could not write frame: %w", err)
+	}
+	return nil
+}
+
+type Reader struct {
+	scanner *bufio.Scanner
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxFrameSize)
+	return &Reader{scanner: scanner}
+}
+
+// Next returns the next frame, or io.EOF after the last one.
+func (r *Reader) Next() (Frame, error) {
+	for r.scanner.Scan() {
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var frame Frame
+		err := json.Unmarshal(line, &frame)
+		if err != nil {
+			return Frame{}, fmt.Errorf("could not parse frame: %w", err)
+		}
+		return frame, nil
+	}
+
+	err := r.scanner.Err()
+	if err != nil {
+		return Frame{}, fmt.Errorf("could not read frame: %w", err)
+	}
+	return Frame{}, io.EOF
+}
+
+// Replayer feeds recordings into a client.
+type Replayer struct {
+	Client *twitch.Client
+	// Speed multiplies the recorded pace, so 2 replays twice as fast and zero
+	// replays as fast as possible.
+	Speed float64
+	// Clock paces the replay and defaults to the system clock
+	Clock twitch.Clock
+	// OnError is called for every frame the client could not handle
+	OnError func(err error)
+}
+
+// Replay handles every frame of the recording and returns how many were
+// replayed.
+func (r Replayer) Replay(ctx context.Context, recording io.Reader) (int, error) {
+	return r.replay(ctx, NewReader(recording).Next)
+}
+
+// ReplayFrames handles the frames like Replay, for recordings that are
+// already in memory.
+func (r Replayer) ReplayFrames(ctx context.Context, frames []Frame) (int, error) {
+	return r.replay(ctx, func() (Frame, error) {
+		if len(frames) == 0 {
+			return Frame{}, io.EOF
+		}
+		frame := frames[0]
+		frames = frames[1:]
+		return frame, nil
+	})
+}
+
+func (r Replayer) replay(ctx context.Context, next func() (Frame, error)) (int, error) {
+	var first time.Time
+	start := r.now()
+	for count := 0; ; count++ {
+		frame, err := next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+
+		if count == 0 {
+			first = frame.Received
+		}
+
+		if r.Speed > 0 {
+			offset := time.Duration(float64(frame.Received.Sub(first)) / r.Speed)
+			err = r.wait(ctx, start.Add(offset).Sub(r.now()))
+			if err != nil {
+				return count, err
+			}
+		}
+
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+
+		err = r.Client.HandleMessage(frame.Data)
+		if err != nil && r.OnError != nil {
+			r.OnError(err)
+		}
+	}
+}
+
+func (r Replayer) now() time.Time {
+	if r.Clock != nil {
+		return r.Clock.Now()
+	}
+	return time.Now()
+}
+
+// wait blocks for d or until ctx is done.
+func (r Replayer) wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	var timer interface {
+		Stop() bool
+	}
+	var fired <-chan time.Time
+	if r.Clock != nil {
+		t := r.Clock.NewTimer(d)
+		timer, fired = t, t.C()
+	} else {
+		t := time.NewTimer(d)
+		timer, fired = t, t.C
+	}
+
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	case <-fired:
+		return nil
+	}
+}
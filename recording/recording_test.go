@@ -0,0 +1,121 @@
+package recording_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/joeyak/go-twitch-eventsub/v2/recording"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamOnline(t *testing.T) []byte {
+	var message twitch.NotificationMessage
+	message.Metadata.MessageType = "notification"
+	message.Payload.Subscription.Type = twitch.SubStreamOnline
+	event := json.RawMessage(`{"broadcaster_user_id":"1337"}`)
+	message.Payload.Event = &event
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestWriterReader(t *testing.T) {
+	var buf bytes.Buffer
+	writer := recording.NewWriter(&buf)
+
+	received := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, writer.Record(received, []byte(`{"metadata":{}}`)))
+	// Invalid UTF-8 is kept as it arrived
+	assert.NoError(t, writer.Record(received.Add(time.Second), []byte("{\"broken\xff")))
+
+	reader := recording.NewReader(&buf)
+
+	frame, err := reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, recording.Frame{Received: received, Data: []byte(`{"metadata":{}}`)}, frame)
+
+	frame, err = reader.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, recording.Frame{Received: received.Add(time.Second), Data: []byte("{\"broken\xff")}, frame)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReaderInvalid(t *testing.T) {
+	_, err := recording.NewReader(strings.NewReader("not json\n")).Next()
+	assert.Error(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	var buf bytes.Buffer
+	writer := recording.NewWriter(&buf)
+
+	received := time.Now()
+	for i := 0; i < 3; i++ {
+		writer.Record(received.Add(time.Duration(i)*100*time.Millisecond), streamOnline(t))
+	}
+	writer.Record(received.Add(200*time.Millisecond), []byte(`{`))
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var events atomic.Int32
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		assert.Equal(t, "1337", event.BroadcasterUserId)
+		events.Add(1)
+	})
+
+	clock := clocktest.NewClock(received)
+	var errs atomic.Int32
+	type result struct {
+		count int
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		count, err := recording.Replayer{
+			Client:  client,
+			Speed:   2,
+			Clock:   clock,
+			OnError: func(err error) { errs.Add(1) },
+		}.Replay(context.Background(), &buf)
+		done <- result{count, err}
+	}()
+
+	// Each 100ms of recording takes 50ms at double speed
+	clock.BlockUntil(1)
+	assert.Equal(t, int32(1), events.Load())
+	clock.Advance(50 * time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(t, int32(2), events.Load())
+	clock.Advance(50 * time.Millisecond)
+
+	select {
+	case result := <-done:
+		assert.NoError(t, result.err)
+		assert.Equal(t, 4, result.count)
+	case <-time.After(time.Second):
+		t.Fatal("replay did not finish")
+	}
+	assert.Equal(t, int32(3), events.Load())
+	assert.Equal(t, int32(1), errs.Load())
+}
+
+func TestReplayFramesCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count, err := recording.Replayer{Client: twitch.NewClient()}.ReplayFrames(ctx, []recording.Frame{{Data: []byte(`{}`)}})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, count)
+}
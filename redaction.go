@@ -0,0 +1,173 @@
+package twitch
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Redactor rewrites a notification, decoded as generic json with numbers as
+// json.Number, before it reaches a redacted client.
+type Redactor interface {
+	Redact(notification map[string]any)
+}
+
+type RedactorFunc func(notification map[string]any)
+
+func (f RedactorFunc) Redact(notification map[string]any) {
+	f(notification)
+}
+
+// NewRedactedClient returns a client that receives the notifications of
+// client after the redactors rewrote them. Sinks and listeners added to it
+// only see redacted events, while the handlers of client keep getting the
+// originals, for example to moderate users:
+//
+//	private := twitch.NewRedactedClient(client, twitch.HashUsers(key), twitch.StripMessageText())
+//	jsonl.NewSink(private, "logs/events.jsonl")
+//
+// The redacted client is not connected itself and errors go to the OnError
+// callback of client.
+func NewRedactedClient(client *Client, redactors ...Redactor) *Client {
+	redacted := NewClient(WithSyncDispatch())
+	redacted.OnError(func(err error) {
+		client.onError(err)
+	})
+
+	client.AddListener(func(message NotificationMessage, event any) {
+		data, err := redactNotification(message, redactors)
+		if err == nil {
+			err = redacted.HandleMessage(data)
+		}
+		if err != nil {
+			client.onError(fmt.Errorf("could not redact %s: %w", message.Metadata.MessageID, err))
+		}
+	})
+	return redacted
+}
+
+func redactNotification(message NotificationMessage, redactors []Redactor) ([]byte, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal notification: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var notification map[string]any
+	err = dec.Decode(&notification)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode notification: %w", err)
+	}
+
+	for _, redactor := range redactors {
+		redactor.Redact(notification)
+	}
+
+	data, err = json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal redacted notification: %w", err)
+	}
+	return data, nil
+}
+
+// RedactStrings replaces every string field anywhere in the notification
+// with what replace returns for its key and value.
+func RedactStrings(replace func(key, value string) string) Redactor {
+	return RedactorFunc(func(notification map[string]any) {
+		redactStrings(notification, replace)
+	})
+}
+
+func redactStrings(value any, replace func(key, value string) string) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if s, ok := field.(string); ok {
+				value[key] = replace(key, s)
+				continue
+			}
+			redactStrings(field, replace)
+		}
+	case []any:
+		for _, element := range value {
+			redactStrings(element, replace)
+		}
+	}
+}
+
+// HashUsers replaces the IDs, logins, and names of users other than
+// broadcasters with the hex HMAC-SHA256 of key and the value. The same user
+// always gets the same hashes, so users can still be counted and told apart.
+func HashUsers(key []byte) Redactor {
+	return RedactorFunc(func(notification map[string]any) {
+		replaceUsers(notification, func(value string) string {
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(value))
+			return hex.EncodeToString(mac.Sum(nil))
+		})
+	})
+}
+
+// replaceUsers replaces the IDs, logins, and names of users other than
+// broadcasters with what replace returns. The frame log uses the same rules.
+func replaceUsers(value any, replace func(value string) string) {
+	redactStrings(value, func(key, value string) string {
+		if value == "" || !isUserField(key) {
+			return value
+		}
+		return replace(value)
+	})
+}
+
+func isUserField(key string) bool {
+	if strings.Contains(key, "broadcaster") {
+		return false
+	}
+	return strings.HasSuffix(key, "user_id") ||
+		strings.HasSuffix(key, "user_login") ||
+		strings.HasSuffix(key, "user_name")
+}
+
+// StripMessageText empties the text users wrote, like cheer and resub
+// messages and the input of channel point redemptions.
+func StripMessageText() Redactor {
+	return RedactorFunc(func(notification map[string]any) {
+		replaceMessageText(notification, "")
+	})
+}
+
+// replaceMessageText replaces the text users wrote with replacement and drops
+// the emotes and fragments, which repeat it. The frame log uses it too.
+func replaceMessageText(value any, replacement string) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			switch key {
+			case "user_input":
+				value[key] = replacement
+				continue
+			case "message":
+				switch message := field.(type) {
+				case string:
+					value[key] = replacement
+				case map[string]any:
+					message["text"] = replacement
+					delete(message, "emotes")
+					delete(message, "fragments")
+				}
+				continue
+			}
+			replaceMessageText(field, replacement)
+		}
+	case []any:
+		for _, element := range value {
+			replaceMessageText(element, replacement)
+		}
+	}
+}
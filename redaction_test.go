@@ -0,0 +1,86 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedactedClient(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnError(func(err error) { t.Error(err) })
+	redacted := twitch.NewRedactedClient(client, twitch.HashUsers([]byte("key")), twitch.StripMessageText())
+
+	var original, received []any
+	var raw []string
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		original = append(original, event)
+	})
+	redacted.AddListener(func(message twitch.NotificationMessage, event any) {
+		received = append(received, event)
+		raw = append(raw, string(*message.RawEvent()))
+	})
+
+	user := twitch.User{UserID: "42", UserLogin: "viewer", UserName: "Viewer"}
+	broadcaster := twitch.Broadcaster{BroadcasterUserId: "1337"}
+	events := []any{
+		twitch.EventChannelCheer{User: user, Broadcaster: broadcaster, Message: "Cheer100 hello", Bits: 100},
+		twitch.EventChannelSubscriptionMessage{User: user, Broadcaster: broadcaster, Message: twitch.Message{Text: "12 months!"}},
+	}
+	for _, event := range events {
+		data, err := twitch.EncodeNotification(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	assert.Equal(t, events, original)
+	if assert.Len(t, received, 2) {
+		cheer := received[0].(twitch.EventChannelCheer)
+		assert.Len(t, cheer.UserID, 64)
+		assert.NotEqual(t, "42", cheer.UserID)
+		assert.Equal(t, "1337", cheer.BroadcasterUserId)
+		assert.Len(t, cheer.UserLogin, 64)
+		assert.Len(t, cheer.UserName, 64)
+		assert.NotEqual(t, cheer.UserLogin, cheer.UserName)
+		assert.NotContains(t, raw[0], "viewer")
+		assert.NotContains(t, raw[0], "Viewer")
+		assert.Empty(t, cheer.Message)
+		assert.Equal(t, 100, cheer.Bits)
+		assert.NotContains(t, raw[0], "hello")
+
+		resub := received[1].(twitch.EventChannelSubscriptionMessage)
+		assert.Equal(t, cheer.UserID, resub.UserID)
+		assert.Empty(t, resub.Message.Text)
+		assert.NotContains(t, raw[1], "12 months")
+	}
+}
+
+func TestRedactStrings(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	redacted := twitch.NewRedactedClient(client, twitch.RedactStrings(func(key, value string) string {
+		if key == "user_login" || key == "user_name" {
+			return "anonymous"
+		}
+		return value
+	}))
+
+	var follow twitch.EventChannelFollow
+	redacted.OnEventChannelFollow(func(event twitch.EventChannelFollow) {
+		follow = event
+	})
+
+	data, err := twitch.EncodeNotification(twitch.EventChannelFollow{User: twitch.User{UserID: "42", UserLogin: "viewer", UserName: "Viewer"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+
+	assert.Equal(t, twitch.User{UserID: "42", UserLogin: "anonymous", UserName: "anonymous"}, follow.User)
+}
@@ -0,0 +1,111 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const twitchRedemptionsUrl = "https://api.twitch.tv/helix/channel_points/custom_rewards/redemptions"
+
+var ErrInvalidRedemptionStatus = fmt.Errorf("redemption status must be fulfilled or canceled")
+
+// UpdateRedemptionStatusRequest marks unfulfilled redemptions of a reward as
+// fulfilled or canceled. Twitch only allows it for rewards created with the
+// same ClientID, with a user access token of the broadcaster.
+type UpdateRedemptionStatusRequest struct {
+	ClientID    string
+	AccessToken string
+	TokenSource TokenSource
+
+	BroadcasterUserID string
+	RewardID          string
+	IDs               []string
+	Status            RedemptionStatus
+}
+
+// StatusRequest returns a request that updates the redemption to status.
+// The credentials still need to be set.
+func (e EventChannelChannelPointsCustomRewardRedemptionAdd) StatusRequest(status RedemptionStatus) UpdateRedemptionStatusRequest {
+	return UpdateRedemptionStatusRequest{
+		BroadcasterUserID: e.BroadcasterUserId,
+		RewardID:          e.Reward.ID,
+		IDs:               []string{e.ID},
+		Status:            status,
+	}
+}
+
+func (e EventChannelChannelPointsCustomRewardRedemptionUpdate) StatusRequest(status RedemptionStatus) UpdateRedemptionStatusRequest {
+	return EventChannelChannelPointsCustomRewardRedemptionAdd(e).StatusRequest(status)
+}
+
+// OnRewardRedemption registers a listener for redemptions of one reward,
+// matched by its ID or case-insensitively by its title. Off removes it again.
+func OnRewardRedemption(client EventSubClient, reward string, callback func(event EventChannelChannelPointsCustomRewardRedemptionAdd)) HandlerID {
+	return On(client, func(event EventChannelChannelPointsCustomRewardRedemptionAdd) {
+		if event.Reward.ID == reward || strings.EqualFold(event.Reward.Title, reward) {
+			callback(event)
+		}
+	})
+}
+
+func UpdateRedemptionStatus(request UpdateRedemptionStatusRequest) error {
+	return UpdateRedemptionStatusUrlWithContext(context.Background(), request, twitchRedemptionsUrl)
+}
+
+func UpdateRedemptionStatusUrl(request UpdateRedemptionStatusRequest, url string) error {
+	return UpdateRedemptionStatusUrlWithContext(context.Background(), request, url)
+}
+
+func UpdateRedemptionStatusWithContext(ctx context.Context, request UpdateRedemptionStatusRequest) error {
+	return UpdateRedemptionStatusUrlWithContext(ctx, request, twitchRedemptionsUrl)
+}
+
+func UpdateRedemptionStatusUrlWithContext(ctx context.Context, request UpdateRedemptionStatusRequest, url string) error {
+	if request.Status != RedemptionStatusFulfilled && request.Status != RedemptionStatusCanceled {
+		return fmt.Errorf("%s: %w", request.Status, ErrInvalidRedemptionStatus)
+	}
+
+	b, err := json.Marshal(map[string]string{"status": strings.ToUpper(string(request.Status))})
+	if err != nil {
+		return fmt.Errorf("could not convert request to json: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("could not create new request: %w", err)
+	}
+
+	query := req.URL.Query()
+	query.Set("broadcaster_id", request.BroadcasterUserID)
+	query.Set("reward_id", request.RewardID)
+	for _, id := range request.IDs {
+		query.Add("id", id)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	accessToken, err := helixAccessToken(request.AccessToken, request.TokenSource)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Client-Id", request.ClientID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not update redemption status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("could not update redemption status: %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package twitch_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRedemptionStatus(t *testing.T) {
+	t.Parallel()
+
+	var method, body string
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, body, query = r.Method, string(data), r.URL.Query()
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	var redemption twitch.EventChannelChannelPointsCustomRewardRedemptionAdd
+	decodeTestEvent(t, twitch.SubChannelChannelPointsCustomRewardRedemptionAdd, &redemption)
+
+	request := redemption.StatusRequest(twitch.RedemptionStatusFulfilled)
+	request.ClientID, request.AccessToken = "client", "token"
+	assert.NoError(t, twitch.UpdateRedemptionStatusUrl(request, server.URL))
+
+	assert.Equal(t, http.MethodPatch, method)
+	assert.JSONEq(t, `{"status":"FULFILLED"}`, body)
+	assert.Equal(t, []string{redemption.BroadcasterUserId}, query["broadcaster_id"])
+	assert.Equal(t, []string{redemption.Reward.ID}, query["reward_id"])
+	assert.Equal(t, []string{redemption.ID}, query["id"])
+
+	request.Status = twitch.RedemptionStatusUnfulfilled
+	assert.ErrorIs(t, twitch.UpdateRedemptionStatusUrl(request, server.URL), twitch.ErrInvalidRedemptionStatus)
+}
+
+func TestOnRewardRedemption(t *testing.T) {
+	t.Parallel()
+
+	var redemption twitch.EventChannelChannelPointsCustomRewardRedemptionAdd
+	decodeTestEvent(t, twitch.SubChannelChannelPointsCustomRewardRedemptionAdd, &redemption)
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+
+	var byID, byTitle, other int
+	twitch.OnRewardRedemption(client, redemption.Reward.ID, func(event twitch.EventChannelChannelPointsCustomRewardRedemptionAdd) { byID++ })
+	id := twitch.OnRewardRedemption(client, strings.ToUpper(redemption.Reward.Title), func(event twitch.EventChannelChannelPointsCustomRewardRedemptionAdd) { byTitle++ })
+	twitch.OnRewardRedemption(client, "Hydrate!", func(event twitch.EventChannelChannelPointsCustomRewardRedemptionAdd) { other++ })
+
+	handleEvents(t, client, twitch.SubChannelChannelPointsCustomRewardRedemptionAdd)
+	assert.Equal(t, 1, byID)
+	assert.Equal(t, 1, byTitle)
+	assert.Equal(t, 0, other)
+
+	assert.True(t, client.Off(id))
+	handleEvents(t, client, twitch.SubChannelChannelPointsCustomRewardRedemptionAdd)
+	assert.Equal(t, 2, byID)
+	assert.Equal(t, 1, byTitle)
+}
@@ -0,0 +1,128 @@
+package twitch
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// WithReplayBuffer keeps the last size events of every subscription type, so
+// listeners added later with AddReplayListener or OnReplay get them first,
+// like a dashboard that opens after the stream went live.
+func WithReplayBuffer(size int) ClientOption {
+	return func(c *Client) {
+		c.replay = &replayBuffer{size: size, events: map[EventSubscription]*eventRing{}}
+	}
+}
+
+type replayBuffer struct {
+	size   int
+	next   uint64
+	events map[EventSubscription]*eventRing
+	mu     sync.Mutex
+}
+
+type bufferedEvent struct {
+	// seq orders the events of all types by arrival
+	seq     uint64
+	message NotificationMessage
+	event   any
+}
+
+// eventRing holds the last events of a type, the oldest at start once full.
+type eventRing struct {
+	events []bufferedEvent
+	start  int
+}
+
+// add keeps an event. mu must be held.
+func (b *replayBuffer) add(message NotificationMessage, event any) {
+	if b.size <= 0 {
+		return
+	}
+
+	subType := message.Payload.Subscription.Type
+	ring := b.events[subType]
+	if ring == nil {
+		ring = &eventRing{}
+		b.events[subType] = ring
+	}
+
+	buffered := bufferedEvent{seq: b.next, message: message, event: event}
+	b.next++
+	if len(ring.events) < b.size {
+		ring.events = append(ring.events, buffered)
+		return
+	}
+	ring.events[ring.start] = buffered
+	ring.start = (ring.start + 1) % len(ring.events)
+}
+
+// snapshot returns the kept events of all types in the order they arrived.
+// mu must be held.
+func (b *replayBuffer) snapshot() []bufferedEvent {
+	var events []bufferedEvent
+	for _, ring := range b.events {
+		events = append(events, ring.events...)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].seq < events[j].seq })
+	return events
+}
+
+// AddReplayListener is AddListener for listeners that are added after events
+// arrived. It first calls the listener with the events kept by
+// WithReplayBuffer, flagged as replayed, and then with every new event. New
+// events may reach it before the replayed ones are done unless the client
+// dispatches synchronously.
+func (c *Client) AddReplayListener(listener func(message NotificationMessage, event any, replayed bool)) HandlerID {
+	return c.addReplayListener(nil, listener)
+}
+
+// OnReplay is On for listeners that are added after events arrived, which
+// get the kept events of type T first, flagged as replayed.
+func OnReplay[T any](client *Client, callback func(event T, replayed bool)) HandlerID {
+	return client.addReplayListener(reflect.TypeOf((*T)(nil)).Elem(), func(message NotificationMessage, event any, replayed bool) {
+		if event, ok := event.(T); ok {
+			callback(event, replayed)
+		}
+	})
+}
+
+func (c *Client) addReplayListener(eventType reflect.Type, f func(message NotificationMessage, event any, replayed bool)) HandlerID {
+	l := listener{
+		f: func(ctx context.Context, message NotificationMessage, event any) {
+			f(message, event, false)
+		},
+		eventType: eventType,
+	}
+	if c.replay == nil {
+		return c.addListenerOf(l)
+	}
+
+	// Events are kept and dispatched to the listeners of the time under the
+	// lock, so each one either is replayed or reaches the new listener live
+	c.replay.mu.Lock()
+	events := c.replay.snapshot()
+	id := c.addListenerOf(l)
+	c.replay.mu.Unlock()
+
+	for _, buffered := range events {
+		f(buffered.message, buffered.event, true)
+	}
+	return id
+}
+
+// replayListeners keeps an event for replaying and returns the listeners it
+// goes to.
+func (c *Client) replayListeners(message NotificationMessage, event any) []listener {
+	if c.replay == nil {
+		return c.listenerSnapshot()
+	}
+
+	c.replay.mu.Lock()
+	defer c.replay.mu.Unlock()
+
+	c.replay.add(message, event)
+	return c.listenerSnapshot()
+}
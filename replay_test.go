@@ -0,0 +1,57 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnReplay(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithReplayBuffer(2))
+	handleTitles(t, client, "first", "second")
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{Type: "live"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	handleTitles(t, client, "third")
+
+	type titleEvent struct {
+		title    string
+		replayed bool
+	}
+	var titles []titleEvent
+	twitch.OnReplay(client, func(event twitch.EventChannelUpdate, replayed bool) {
+		titles = append(titles, titleEvent{event.Title, replayed})
+	})
+
+	var types []twitch.EventSubscription
+	client.AddReplayListener(func(message twitch.NotificationMessage, event any, replayed bool) {
+		if replayed {
+			types = append(types, message.Payload.Subscription.Type)
+		}
+	})
+	assert.Equal(t, []twitch.EventSubscription{twitch.SubChannelUpdate, twitch.SubStreamOnline, twitch.SubChannelUpdate}, types)
+
+	handleTitles(t, client, "fourth")
+	assert.Equal(t, []titleEvent{{"second", true}, {"third", true}, {"fourth", false}}, titles)
+}
+
+func TestOnReplayWithoutBuffer(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	handleTitles(t, client, "first")
+
+	var titles []string
+	twitch.OnReplay(client, func(event twitch.EventChannelUpdate, replayed bool) {
+		assert.False(t, replayed)
+		titles = append(titles, event.Title)
+	})
+	handleTitles(t, client, "second")
+	assert.Equal(t, []string{"second"}, titles)
+}
@@ -0,0 +1,105 @@
+package twitch
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RequiredSubscription is a subscription the handlers of a client need to
+// receive any events.
+type RequiredSubscription struct {
+	Type    EventSubscription
+	Version string
+	// Condition lists the required condition keys, with alternatives of
+	// which one is needed separated by |
+	Condition []string
+	Scopes    []string
+}
+
+// Request returns the request for the subscription with the keys of
+// condition it needs, taking the first one set of alternatives. The version
+// is only overridden when it is not the default one.
+func (r RequiredSubscription) Request(condition Condition) SubscribeRequest {
+	values := condition.Map()
+
+	request := SubscribeRequest{
+		Event:     r.Type,
+		Condition: map[string]string{},
+	}
+	if r.Version != subMetadata[r.Type].Version {
+		request.VersionOverride = r.Version
+	}
+	for _, required := range r.Condition {
+		for _, key := range strings.Split(required, "|") {
+			if values[key] != "" {
+				request.Condition[key] = values[key]
+				break
+			}
+		}
+	}
+	return request
+}
+
+// RequiredSubscriptions returns the subscriptions the OnEvent and OnAny
+// callbacks and the listeners added with On or Once need, sorted by type and
+// version, so no handler waits for events that were never subscribed to:
+//
+//	for _, required := range client.RequiredSubscriptions() {
+//		user.Subscriptions = append(user.Subscriptions, required.Request(twitch.Condition{BroadcasterUserID: userID}))
+//	}
+//
+// Listeners added with AddListener take every event and imply none.
+func (c *Client) RequiredSubscriptions() []RequiredSubscription {
+	var eventTypes []reflect.Type
+	for _, listener := range c.listenerSnapshot() {
+		// Listeners for any type take every event like AddListener does
+		eventType := listener.eventType
+		if eventType != nil && (eventType.Kind() != reflect.Interface || eventType.NumMethod() > 0) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	var required []RequiredSubscription
+	add := func(subType EventSubscription, metadata subscriptionMetadata) {
+		handled := metadata.Handler(c) != nil ||
+			c.eventHandlers.categoryHandler(reflect.New(metadata.Type).Elem().Interface()) != nil
+		for _, eventType := range eventTypes {
+			handled = handled || takesEvent(eventType, metadata.Type)
+		}
+		if !handled {
+			return
+		}
+
+		required = append(required, RequiredSubscription{
+			Type:      subType,
+			Version:   metadata.Version,
+			Condition: metadata.Condition,
+			Scopes:    metadata.Scopes,
+		})
+	}
+
+	for subType, metadata := range subMetadata {
+		add(subType, metadata)
+		for _, metadata := range subVersions[subType] {
+			add(subType, metadata)
+		}
+	}
+
+	sort.Slice(required, func(i, j int) bool {
+		if required[i].Type != required[j].Type {
+			return required[i].Type < required[j].Type
+		}
+		return required[i].Version < required[j].Version
+	})
+	return required
+}
+
+// takesEvent reports whether a listener for events of listenerType receives
+// events of eventType.
+func takesEvent(listenerType, eventType reflect.Type) bool {
+	if listenerType.Kind() == reflect.Interface {
+		return eventType.Implements(listenerType)
+	}
+	return listenerType == eventType
+}
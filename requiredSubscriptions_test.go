@@ -0,0 +1,43 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+	assert.Empty(t, client.RequiredSubscriptions())
+
+	client.OnEventChannelFollow(func(event twitch.EventChannelFollow) {})
+	client.OnAnyStreamEvent(func(event twitch.StreamEvent) {})
+	twitch.On(client, func(event twitch.EventChannelUpdateV1) {})
+	twitch.On(client, func(event any) {})
+	client.AddListener(func(message twitch.NotificationMessage, event any) {})
+
+	assert.Equal(t, []twitch.RequiredSubscription{
+		{Type: twitch.SubChannelFollow, Version: "2", Condition: []string{"broadcaster_user_id", "moderator_user_id"}, Scopes: []string{"moderator:read:followers"}},
+		{Type: twitch.SubChannelUpdate, Version: "1", Condition: []string{"broadcaster_user_id"}},
+		{Type: twitch.SubStreamOffline, Version: "1", Condition: []string{"broadcaster_user_id"}},
+		{Type: twitch.SubStreamOnline, Version: "1", Condition: []string{"broadcaster_user_id"}},
+	}, client.RequiredSubscriptions())
+}
+
+func TestRequiredSubscriptionRequest(t *testing.T) {
+	t.Parallel()
+
+	condition := twitch.Condition{BroadcasterUserID: "1337", ModeratorUserID: "1337"}
+
+	request := twitch.RequiredSubscription{Type: twitch.SubStreamOnline, Version: "1", Condition: []string{"broadcaster_user_id"}}.Request(condition)
+	assert.Equal(t, twitch.SubscribeRequest{Event: twitch.SubStreamOnline, Condition: map[string]string{"broadcaster_user_id": "1337"}}, request)
+
+	request = twitch.RequiredSubscription{Type: twitch.SubChannelUpdate, Version: "1", Condition: []string{"broadcaster_user_id"}}.Request(condition)
+	assert.Equal(t, "1", request.VersionOverride)
+
+	request = twitch.RequiredSubscription{Type: twitch.SubChannelRaid, Version: "1", Condition: []string{"from_broadcaster_user_id|to_broadcaster_user_id"}}.Request(twitch.Condition{ToBroadcasterUserID: "42"})
+	assert.Equal(t, map[string]string{"to_broadcaster_user_id": "42"}, request.Condition)
+}
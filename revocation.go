@@ -0,0 +1,105 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+)
+
+// revocationUserKeys are the condition keys of the user whose authorization a
+// subscription depends on, in order of preference.
+var revocationUserKeys = []string{
+	"moderator_user_id",
+	"user_id",
+	"broadcaster_user_id",
+	"to_broadcaster_user_id",
+	"from_broadcaster_user_id",
+}
+
+// Revocation describes a subscription that Twitch revoked.
+type Revocation struct {
+	Subscription PayloadSubscription
+	Reason       SubscriptionStatus
+	// UserID is the user the subscription was authorized by, taken from
+	// its condition.
+	UserID string
+	// Request is the request that created the subscription if it was
+	// created with Subscribe on this client, or nil.
+	Request *SubscribeRequest
+}
+
+func (c *Client) handleRevocation(message RevokeMessage) {
+	subscription := message.Payload.Subscription
+
+	revocation := Revocation{
+		Subscription: subscription,
+		Reason:       subscription.Status,
+	}
+	for _, key := range revocationUserKeys {
+		if id := subscription.Condition[key]; id != "" {
+			revocation.UserID = id
+			break
+		}
+	}
+
+	if request, ok := c.recordedSubscription(subscription, c.revocationRecovery); ok {
+		revocation.Request = &request
+	}
+
+	callFunc(c.onRevocation, revocation)
+
+	if c.revocationRecovery {
+		go c.recoverRevocation(revocation)
+	}
+}
+
+// recordedSubscription finds the recorded request of the subscription,
+// removing it from the recorded subscriptions if remove is set.
+func (c *Client) recordedSubscription(subscription PayloadSubscription, remove bool) (SubscribeRequest, bool) {
+	c.subscriptionsMu.Lock()
+	i := c.recordedIndex(SubscribeRequest{Event: subscription.Type, Condition: subscription.Condition})
+	if i < 0 {
+		c.subscriptionsMu.Unlock()
+		return SubscribeRequest{}, false
+	}
+
+	request := c.subscriptions[i]
+	if remove {
+		c.subscriptions = append(c.subscriptions[:i:i], c.subscriptions[i+1:]...)
+	}
+	c.subscriptionsMu.Unlock()
+
+	if remove {
+		c.saveSession()
+	}
+	return request, true
+}
+
+func equalConditions(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Client) recoverRevocation(revocation Revocation) {
+	if revocation.Request != nil {
+		err := DeleteSubscriptionUrlWithContext(context.Background(), DeleteSubscriptionRequest{
+			ClientID:    revocation.Request.ClientID,
+			AccessToken: revocation.Request.AccessToken,
+			TokenSource: revocation.Request.TokenSource,
+			ID:          revocation.Subscription.ID,
+		}, c.SubscriptionUrl)
+		if err != nil {
+			c.onError(fmt.Errorf("could not delete revoked %s subscription: %w", revocation.Subscription.Type, err))
+		}
+	}
+
+	if revocation.Reason == SubscriptionStatusAuthorizationRevoked && c.onReauth != nil {
+		c.onReauth(revocation)
+	}
+}
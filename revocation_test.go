@@ -0,0 +1,100 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func revocationMessage(t *testing.T, status twitch.SubscriptionStatus) []byte {
+	var message twitch.RevokeMessage
+	message.Metadata.MessageType = "revocation"
+	message.Payload.Subscription.ID = "sub-1"
+	message.Payload.Subscription.Type = twitch.SubChannelFollow
+	message.Payload.Subscription.Status = status
+	message.Payload.Subscription.Condition = map[string]string{"broadcaster_user_id": "1", "moderator_user_id": "2"}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestOnRevocation(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+
+	revocations := make(chan twitch.Revocation, 1)
+	client.OnRevocation(func(revocation twitch.Revocation) {
+		revocations <- revocation
+	})
+
+	assert.NoError(t, client.HandleMessage(revocationMessage(t, twitch.SubscriptionStatusUserRemoved)))
+
+	select {
+	case revocation := <-revocations:
+		assert.Equal(t, twitch.SubscriptionStatusUserRemoved, revocation.Reason)
+		assert.Equal(t, "2", revocation.UserID)
+		assert.Equal(t, "sub-1", revocation.Subscription.ID)
+		assert.Nil(t, revocation.Request)
+	case <-time.After(time.Second):
+		t.Fatal("revocation was not reported")
+	}
+}
+
+func TestWithRevocationRecovery(t *testing.T) {
+	t.Parallel()
+
+	deleted := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"data":[]}`))
+		case http.MethodDelete:
+			deleted <- r.URL.Query().Get("id")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	reauth := make(chan twitch.Revocation, 1)
+	client := twitch.NewClient(twitch.WithRevocationRecovery(func(revocation twitch.Revocation) {
+		reauth <- revocation
+	}))
+	client.SubscriptionUrl = server.URL
+	client.OnError(func(err error) {
+		t.Errorf("client registered an error: %v", err)
+	})
+
+	_, err := client.Subscribe(twitch.SubscribeRequest{
+		Event:     twitch.SubChannelFollow,
+		Condition: map[string]string{"broadcaster_user_id": "1", "moderator_user_id": "2"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.HandleMessage(revocationMessage(t, twitch.SubscriptionStatusAuthorizationRevoked)))
+
+	select {
+	case id := <-deleted:
+		assert.Equal(t, "sub-1", id)
+	case <-time.After(time.Second):
+		t.Fatal("revoked subscription was not deleted")
+	}
+
+	select {
+	case revocation := <-reauth:
+		if assert.NotNil(t, revocation.Request) {
+			assert.Equal(t, twitch.SubChannelFollow, revocation.Request.Event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("reauth was not called")
+	}
+}
@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+// Engine evaluates rules against events. Publisher is needed for publish
+// actions, HTTPClient defaults to http.DefaultClient for webhook actions, and
+// Clock to the system clock for windows and cooldowns.
+type Engine struct {
+	Publisher  twitch.Publisher
+	HTTPClient *http.Client
+	Clock      twitch.Clock
+
+	rules []*rule
+
+	mu        sync.Mutex
+	callbacks map[string]func(Match)
+	onError   func(err error)
+}
+
+// New checks and compiles the rules, returning ErrInvalidRule for the first
+// one that is not valid.
+func New(rules ...Rule) (*Engine, error) {
+	engine := &Engine{callbacks: map[string]func(Match){}}
+	for i, r := range rules {
+		compiled, err := compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d %q: %v", ErrInvalidRule, i, r.Name, err)
+		}
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, nil
+}
+
+// Handle registers the callback that callback actions refer to by name, so
+// rules loaded from json can call into code.
+func (e *Engine) Handle(name string, callback func(Match)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.callbacks[name] = callback
+}
+
+// OnError is called when an action fails.
+func (e *Engine) OnError(callback func(err error)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.onError = callback
+}
+
+// Attach evaluates every event of the client.
+func (e *Engine) Attach(client twitch.EventSubClient) twitch.HandlerID {
+	return client.AddListener(func(message twitch.NotificationMessage, event any) {
+		e.Evaluate(context.Background(), message, event)
+	})
+}
+
+// Evaluate runs the actions of every rule the event fires and returns the
+// matches. Failed actions are reported to OnError.
+func (e *Engine) Evaluate(ctx context.Context, message twitch.NotificationMessage, event any) []Match {
+	subType := message.Payload.Subscription.Type
+	fields := twitch.EventFields(event)
+
+	var matches []Match
+	for _, r := range e.rules {
+		if !r.matchesEvent(subType, fields) {
+			continue
+		}
+
+		count, ok := r.fire(e.now())
+		if !ok {
+			continue
+		}
+
+		match := Match{
+			Rule:      r.Name,
+			Type:      subType,
+			MessageID: message.Metadata.MessageID,
+			Count:     count,
+			Event:     event,
+		}
+		matches = append(matches, match)
+
+		for _, action := range r.Actions {
+			err := e.run(ctx, action, match)
+			if err != nil {
+				e.error(fmt.Errorf("could not run %s action of rule %q: %w", action.Type, r.Name, err))
+			}
+		}
+	}
+	return matches
+}
+
+func (e *Engine) now() time.Time {
+	if e.Clock != nil {
+		return e.Clock.Now()
+	}
+	return time.Now()
+}
+
+func (e *Engine) error(err error) {
+	e.mu.Lock()
+	onError := e.onError
+	e.mu.Unlock()
+
+	if onError != nil {
+		onError(err)
+	}
+}
+
+func (e *Engine) run(ctx context.Context, action Action, match Match) error {
+	switch action.Type {
+	case ActionCallback:
+		callback := action.Func
+		if callback == nil {
+			e.mu.Lock()
+			callback = e.callbacks[action.Callback]
+			e.mu.Unlock()
+		}
+		if callback == nil {
+			return fmt.Errorf("%w %q", ErrUnknownCallback, action.Callback)
+		}
+		callback(match)
+		return nil
+	case ActionPublish:
+		if e.Publisher == nil {
+			return ErrNoPublisher
+		}
+		payload, err := json.Marshal(match)
+		if err != nil {
+			return fmt.Errorf("could not marshal match: %w", err)
+		}
+		topic := action.Topic
+		if topic == "" {
+			topic = match.Rule
+		}
+		return e.Publisher.Publish(ctx, topic, payload)
+	case ActionWebhook:
+		return e.post(ctx, action.URL, match)
+	}
+	return fmt.Errorf("unknown action type %q", action.Type)
+}
+
+func (e *Engine) post(ctx context.Context, url string, match Match) error {
+	payload, err := json.Marshal(match)
+	if err != nil {
+		return fmt.Errorf("could not marshal match: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := e.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not post to %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s answered %s", ErrWebhookResponse, url, res.Status)
+	}
+	return nil
+}
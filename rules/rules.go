@@ -0,0 +1,352 @@
+// Package rules runs actions for events that match declared conditions, for
+// alerting and moderation automation without a handler per rule. Rules are
+// written in code or loaded from JSON:
+//
+//	[{
+//		"name": "big cheer",
+//		"event": "channel.cheer",
+//		"conditions": [{"field": "bits", "op": ">=", "value": 1000}],
+//		"actions": [{"type": "webhook", "url": "https://example.com/hooks/cheer"}]
+//	}]
+//
+// Conditions compare the fields of twitch.EventFields, with nested fields
+// separated by dots like "ban.user_login". A rule with a Threshold only fires
+// once that many events matched within its Window, like 20 follows in a
+// minute, and a Cooldown keeps it quiet for a while after firing.
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+var (
+	ErrInvalidRule     = fmt.Errorf("invalid rule")
+	ErrUnknownCallback = fmt.Errorf("unknown callback")
+	ErrNoPublisher     = fmt.Errorf("engine has no publisher")
+	ErrWebhookResponse = fmt.Errorf("webhook did not succeed")
+)
+
+type Operator string
+
+const (
+	OpEqual          Operator = "=="
+	OpNotEqual       Operator = "!="
+	OpGreater        Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+	OpLess           Operator = "<"
+	OpLessOrEqual    Operator = "<="
+	OpContains       Operator = "contains"
+	OpMatches        Operator = "matches"
+	OpExists         Operator = "exists"
+)
+
+type ActionType string
+
+const (
+	ActionCallback ActionType = "callback"
+	ActionPublish  ActionType = "publish"
+	ActionWebhook  ActionType = "webhook"
+)
+
+type Rule struct {
+	Name string `json:"name"`
+	// Event is the subscription type the rule applies to, or empty for all.
+	Event      twitch.EventSubscription `json:"event,omitempty"`
+	Conditions []Condition              `json:"conditions,omitempty"`
+
+	// Threshold is how many matching events within Window fire the rule.
+	// Zero and one fire on every match.
+	Threshold int      `json:"threshold,omitempty"`
+	Window    Duration `json:"window,omitempty"`
+	// Cooldown ignores matches for this long after the rule fired.
+	Cooldown Duration `json:"cooldown,omitempty"`
+
+	Actions []Action `json:"actions"`
+}
+
+// Condition compares a field of the event to Value. Numbers compare as
+// numbers, contains checks strings and lists, matches takes a regular
+// expression, and exists only needs the field to be set.
+type Condition struct {
+	Field string   `json:"field"`
+	Op    Operator `json:"op"`
+	Value any      `json:"value,omitempty"`
+}
+
+// Action is run when a rule fires. Callback actions call Func, or the
+// callback registered with Engine.Handle under Callback. Publish actions send
+// the Match as json to Topic, which defaults to the rule name, through the
+// engine's Publisher. Webhook actions POST it to URL.
+type Action struct {
+	Type     ActionType  `json:"type"`
+	Callback string      `json:"callback,omitempty"`
+	Func     func(Match) `json:"-"`
+	Topic    string      `json:"topic,omitempty"`
+	URL      string      `json:"url,omitempty"`
+}
+
+// Match is what actions receive when a rule fires.
+type Match struct {
+	Rule      string                   `json:"rule"`
+	Type      twitch.EventSubscription `json:"subscription_type"`
+	MessageID string                   `json:"message_id"`
+	// Count is how many events matched within the window, which is 1 for
+	// rules without a threshold.
+	Count int `json:"count"`
+	Event any `json:"event"`
+}
+
+// Duration is a time.Duration written as a string like "1m30s" in json.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return fmt.Errorf("could not read duration: %w", err)
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("could not parse duration: %w", err)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// Parse reads a json list of rules.
+func Parse(data []byte) ([]Rule, error) {
+	var rules []Rule
+	err := json.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rules: %w", err)
+	}
+	return rules, nil
+}
+
+type rule struct {
+	Rule
+
+	patterns map[int]*regexp.Regexp
+
+	mu        sync.Mutex
+	matches   []time.Time
+	lastFired time.Time
+}
+
+func compile(r Rule) (*rule, error) {
+	if r.Threshold > 1 && r.Window <= 0 {
+		return nil, fmt.Errorf("threshold needs a window")
+	}
+
+	compiled := &rule{Rule: r, patterns: map[int]*regexp.Regexp{}}
+	for i, condition := range r.Conditions {
+		switch condition.Op {
+		case OpEqual, OpNotEqual, OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual, OpContains, OpExists:
+		case OpMatches:
+			pattern, ok := condition.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("matches of %s needs a string", condition.Field)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("could not compile pattern of %s: %w", condition.Field, err)
+			}
+			compiled.patterns[i] = re
+		default:
+			return nil, fmt.Errorf("unknown operator %q", condition.Op)
+		}
+	}
+
+	for _, action := range r.Actions {
+		switch action.Type {
+		case ActionCallback:
+			if action.Func == nil && action.Callback == "" {
+				return nil, fmt.Errorf("callback action needs a Func or Callback name")
+			}
+		case ActionPublish:
+		case ActionWebhook:
+			if action.URL == "" {
+				return nil, fmt.Errorf("webhook action needs a url")
+			}
+		default:
+			return nil, fmt.Errorf("unknown action type %q", action.Type)
+		}
+	}
+	return compiled, nil
+}
+
+func (r *rule) matchesEvent(subType twitch.EventSubscription, fields map[string]any) bool {
+	if r.Event != "" && r.Event != subType {
+		return false
+	}
+
+	for i, condition := range r.Conditions {
+		value, ok := lookup(fields, condition.Field)
+		if condition.Op == OpExists {
+			if !ok || value == nil {
+				return false
+			}
+			continue
+		}
+		if !ok || !compare(condition, r.patterns[i], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// fire records a match at now and returns how many matches are in the
+// window once the rule fires.
+func (r *rule) fire(now time.Time) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Cooldown > 0 && !r.lastFired.IsZero() && now.Sub(r.lastFired) < time.Duration(r.Cooldown) {
+		return 0, false
+	}
+	if r.Threshold <= 1 {
+		r.lastFired = now
+		return 1, true
+	}
+
+	start := now.Add(-time.Duration(r.Window))
+	kept := r.matches[:0]
+	for _, match := range r.matches {
+		if match.After(start) {
+			kept = append(kept, match)
+		}
+	}
+	r.matches = append(kept, now)
+
+	count := len(r.matches)
+	if count < r.Threshold {
+		return 0, false
+	}
+	r.matches = nil
+	r.lastFired = now
+	return count, true
+}
+
+// lookup finds a field by its dotted path through nested maps and lists,
+// where list elements are addressed by their index.
+func lookup(fields map[string]any, path string) (any, bool) {
+	var value any = fields
+	for _, key := range strings.Split(path, ".") {
+		switch current := value.(type) {
+		case map[string]any:
+			next, ok := current[key]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []map[string]any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(current) {
+				return nil, false
+			}
+			value = current[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func compare(condition Condition, pattern *regexp.Regexp, value any) bool {
+	switch condition.Op {
+	case OpEqual:
+		return equal(value, condition.Value)
+	case OpNotEqual:
+		return !equal(value, condition.Value)
+	case OpContains:
+		return contains(value, condition.Value)
+	case OpMatches:
+		s, ok := value.(string)
+		return ok && pattern.MatchString(s)
+	}
+
+	a, aok := number(value)
+	b, bok := number(condition.Value)
+	if !aok || !bok {
+		return false
+	}
+	switch condition.Op {
+	case OpGreater:
+		return a > b
+	case OpGreaterOrEqual:
+		return a >= b
+	case OpLess:
+		return a < b
+	case OpLessOrEqual:
+		return a <= b
+	}
+	return false
+}
+
+func equal(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	if t, ok := a.(time.Time); ok {
+		s, ok := b.(string)
+		if !ok {
+			return false
+		}
+		other, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil && t.Equal(other)
+	}
+
+	switch a := a.(type) {
+	case string:
+		s, ok := b.(string)
+		return ok && a == s
+	case bool:
+		v, ok := b.(bool)
+		return ok && a == v
+	}
+	return false
+}
+
+func contains(value, needle any) bool {
+	switch value := value.(type) {
+	case string:
+		s, ok := needle.(string)
+		return ok && strings.Contains(value, s)
+	case []string:
+		for _, element := range value {
+			if element == needle {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func number(value any) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,204 @@
+package rules_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/joeyak/go-twitch-eventsub/v2/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func notification(subType twitch.EventSubscription, id string) twitch.NotificationMessage {
+	var message twitch.NotificationMessage
+	message.Metadata.MessageID = id
+	message.Payload.Subscription.Type = subType
+	return message
+}
+
+func TestConditions(t *testing.T) {
+	t.Parallel()
+
+	cheer := twitch.EventChannelCheer{User: twitch.User{UserLogin: "alice"}, Message: "Cheer1000 hype", Bits: 1000}
+	reason := "spam links"
+	moderate := twitch.EventChannelModerate{Action: "ban", Ban: &twitch.Ban{User: twitch.User{UserLogin: "bob"}, Reason: &reason}}
+
+	testCases := []struct {
+		Name      string
+		Event     any
+		Type      twitch.EventSubscription
+		Condition rules.Condition
+		Expected  bool
+	}{
+		{"greater", cheer, twitch.SubChannelCheer, rules.Condition{Field: "bits", Op: rules.OpGreaterOrEqual, Value: 1000.0}, true},
+		{"less", cheer, twitch.SubChannelCheer, rules.Condition{Field: "bits", Op: rules.OpLess, Value: 1000}, false},
+		{"equal", cheer, twitch.SubChannelCheer, rules.Condition{Field: "user_login", Op: rules.OpEqual, Value: "alice"}, true},
+		{"not equal", cheer, twitch.SubChannelCheer, rules.Condition{Field: "user_login", Op: rules.OpNotEqual, Value: "alice"}, false},
+		{"contains", cheer, twitch.SubChannelCheer, rules.Condition{Field: "message", Op: rules.OpContains, Value: "hype"}, true},
+		{"matches", cheer, twitch.SubChannelCheer, rules.Condition{Field: "message", Op: rules.OpMatches, Value: `(?i)^cheer\d+`}, true},
+		{"nested", moderate, twitch.SubChannelModerate, rules.Condition{Field: "ban.reason", Op: rules.OpContains, Value: "spam"}, true},
+		{"exists", moderate, twitch.SubChannelModerate, rules.Condition{Field: "ban", Op: rules.OpExists}, true},
+		{"missing", moderate, twitch.SubChannelModerate, rules.Condition{Field: "timeout", Op: rules.OpExists}, false},
+		{"not a number", cheer, twitch.SubChannelCheer, rules.Condition{Field: "user_login", Op: rules.OpGreater, Value: 1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			engine, err := rules.New(rules.Rule{
+				Name:       tc.Name,
+				Event:      tc.Type,
+				Conditions: []rules.Condition{tc.Condition},
+				Actions:    []rules.Action{{Type: rules.ActionCallback, Func: func(rules.Match) {}}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			matches := engine.Evaluate(context.Background(), notification(tc.Type, "a"), tc.Event)
+			assert.Equal(t, tc.Expected, len(matches) == 1)
+		})
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	t.Parallel()
+
+	for _, rule := range []rules.Rule{
+		{Conditions: []rules.Condition{{Field: "bits", Op: "~"}}},
+		{Conditions: []rules.Condition{{Field: "message", Op: rules.OpMatches, Value: "("}}},
+		{Actions: []rules.Action{{Type: "email"}}},
+		{Actions: []rules.Action{{Type: rules.ActionWebhook}}},
+		{Threshold: 5},
+	} {
+		_, err := rules.New(rule)
+		assert.ErrorIs(t, err, rules.ErrInvalidRule)
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	t.Parallel()
+
+	clock := clocktest.NewClock(time.Unix(0, 0))
+	engine, err := rules.New(rules.Rule{
+		Name:      "follow bots",
+		Event:     twitch.SubChannelFollow,
+		Threshold: 3,
+		Window:    rules.Duration(time.Minute),
+		Cooldown:  rules.Duration(10 * time.Minute),
+		Actions:   []rules.Action{{Type: rules.ActionCallback, Callback: "alert"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.Clock = clock
+
+	var matches []rules.Match
+	engine.Handle("alert", func(match rules.Match) { matches = append(matches, match) })
+
+	follow := func() {
+		engine.Evaluate(context.Background(), notification(twitch.SubChannelFollow, "a"), twitch.EventChannelFollow{})
+	}
+
+	follow()
+	follow()
+	clock.Advance(2 * time.Minute)
+	follow()
+	follow()
+	assert.Empty(t, matches)
+
+	follow()
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, 3, matches[0].Count)
+		assert.Equal(t, "follow bots", matches[0].Rule)
+	}
+
+	follow()
+	follow()
+	follow()
+	assert.Len(t, matches, 1, "rule fired during its cooldown")
+
+	clock.Advance(11 * time.Minute)
+	follow()
+	follow()
+	follow()
+	assert.Len(t, matches, 2)
+}
+
+type publisher struct {
+	topics   []string
+	payloads [][]byte
+}
+
+func (p *publisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.topics = append(p.topics, topic)
+	p.payloads = append(p.payloads, payload)
+	return nil
+}
+
+func TestActionsFromJSON(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	parsed, err := rules.Parse([]byte(`[{
+		"name": "big cheer",
+		"event": "channel.cheer",
+		"conditions": [{"field": "bits", "op": ">=", "value": 1000}],
+		"window": "1m",
+		"actions": [
+			{"type": "publish", "topic": "alerts"},
+			{"type": "webhook", "url": "` + server.URL + `"},
+			{"type": "callback", "callback": "missing"}
+		]
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rules.Duration(time.Minute), parsed[0].Window)
+
+	engine, err := rules.New(parsed...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &publisher{}
+	engine.Publisher = pub
+
+	var errs []error
+	engine.OnError(func(err error) { errs = append(errs, err) })
+
+	engine.Evaluate(context.Background(), notification(twitch.SubChannelCheer, "small"), twitch.EventChannelCheer{Bits: 10})
+	assert.Empty(t, pub.topics)
+
+	engine.Evaluate(context.Background(), notification(twitch.SubChannelCheer, "big"), twitch.EventChannelCheer{Bits: 5000})
+	assert.Equal(t, []string{"alerts"}, pub.topics)
+
+	var match struct {
+		Rule      string         `json:"rule"`
+		MessageID string         `json:"message_id"`
+		Event     map[string]any `json:"event"`
+	}
+	select {
+	case body := <-received:
+		assert.NoError(t, json.Unmarshal(body, &match))
+		assert.Equal(t, "big cheer", match.Rule)
+		assert.Equal(t, "big", match.MessageID)
+		assert.Equal(t, 5000.0, match.Event["bits"])
+	case <-time.After(time.Second):
+		t.Fatal("webhook was not called")
+	}
+
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], rules.ErrUnknownCallback)
+	}
+}
@@ -0,0 +1,139 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const maxRunBackoff = 30 * time.Second
+
+var (
+	keepaliveCheckInterval = time.Second
+
+	ErrKeepaliveTimeout = fmt.Errorf("no message was received within the keepalive timeout")
+	ErrReconnectLimit   = fmt.Errorf("gave up reconnecting")
+)
+
+// Run connects the client and keeps it connected until ctx is done or Close
+// is called, returning nil in both cases so it fits into an errgroup.Group.
+// Lost connections, including ones that Twitch closed or that stay silent past
+// the keepalive timeout, are reported to OnError and reconnected to the original address
+// with backoff, after which recorded subscriptions are recreated. Errors that
+// WithErrorPolicy made fatal are returned. With
+// WithReconnectLimit, Run gives up once the limits are reached, calls OnGiveUp,
+// and returns ErrReconnectLimit.
+func (c *Client) Run(ctx context.Context) error {
+	address := c.Address
+	c.gaveUp.Store(nil)
+	defer c.retrying.Store(false)
+
+	failures := 0
+	var firstFailure time.Time
+	for {
+		c.Address = address
+		err := c.runConnection(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if errors.Is(err, ErrNilOnWelcome) || errors.Is(err, ErrFatal) {
+			return err
+		}
+
+		if errors.Is(err, ErrClosedByUser) {
+			return nil
+		}
+		c.onError(fmt.Errorf("connection lost: %w", err))
+		c.stats.reconnect()
+
+		if c.SessionID() != "" {
+			failures = 0
+		}
+		if failures == 0 {
+			firstFailure = c.clock.Now()
+		}
+
+		if c.reconnectLimitReached(failures+1, c.clock.Now().Sub(firstFailure)) {
+			err = fmt.Errorf("%w after %d failed connections: %v", ErrReconnectLimit, failures+1, err)
+			c.gaveUp.Store(&err)
+			if c.onGiveUp != nil {
+				c.onGiveUp(err)
+			}
+			return err
+		}
+
+		c.retrying.Store(true)
+		backoff := runBackoff(failures)
+		failures++
+
+		timer := c.clock.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C():
+		}
+	}
+}
+
+func (c *Client) reconnectLimitReached(failures int, elapsed time.Duration) bool {
+	if c.maxReconnects > 0 && failures > c.maxReconnects {
+		return true
+	}
+	return c.maxReconnectDuration > 0 && elapsed >= c.maxReconnectDuration
+}
+
+// runBackoff waits nothing before the first retry and then doubles from a
+// second up to maxRunBackoff.
+func runBackoff(failures int) time.Duration {
+	if failures == 0 {
+		return 0
+	}
+
+	backoff := time.Second << (failures - 1)
+	if backoff <= 0 || backoff > maxRunBackoff {
+		return maxRunBackoff
+	}
+	return backoff
+}
+
+func (c *Client) runConnection(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var expired atomic.Bool
+	goLabeled(ctx, roleWatchdog, func() {
+		ticker := c.clock.NewTicker(keepaliveCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				if c.keepaliveExpired() {
+					expired.Store(true)
+					cancel()
+					return
+				}
+			}
+		}
+	})
+
+	err := c.ConnectWithContext(ctx)
+	if expired.Load() {
+		return ErrKeepaliveTimeout
+	}
+	return err
+}
+
+func (c *Client) keepaliveExpired() bool {
+	timeout := time.Duration(c.keepaliveTimeout.Load())
+	if timeout == 0 {
+		return false
+	}
+	return c.clock.Now().Sub(time.Unix(0, c.lastMessage.Load())) > timeout
+}
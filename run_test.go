@@ -0,0 +1,160 @@
+package twitch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+	"nhooyr.io/websocket"
+)
+
+func TestRunNoWelcome(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClientWithUrl("")
+	err := client.Run(context.Background())
+	assert.ErrorIs(t, err, twitch.ErrNilOnWelcome)
+}
+
+func TestRunCanceled(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, noDataGen)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		cancel()
+	})
+
+	err := client.Run(ctx)
+	assert.NoError(t, err)
+}
+
+func TestRunClosed(t *testing.T) {
+	t.Parallel()
+
+	client := newClient(t, noDataGen)
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		client.Close()
+	})
+
+	err := client.Run(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRunKeepaliveTimeout(t *testing.T) {
+	t.Parallel()
+
+	// The server welcomes with a one second keepalive and then stays silent
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		connections.Add(1)
+
+		welcome := twitch.WelcomeMessage{Metadata: newMetadata("session_welcome")}
+		welcome.Payload.Session.ID = "session"
+		welcome.Payload.Session.KeepaliveTimeoutSeconds = 1
+		data, _ := json.Marshal(welcome)
+		conn.Write(r.Context(), websocket.MessageText, data)
+
+		conn.Read(r.Context())
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clock := clocktest.NewClock(time.Now())
+
+	var errs []error
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithClock(clock))
+	client.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {
+		if connections.Load() == 2 {
+			cancel()
+			return
+		}
+
+		// Wait for the keepalive ticker and then let the session go silent
+		clock.BlockUntil(1)
+		clock.Advance(2 * time.Second)
+	})
+
+	err := client.Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), connections.Load())
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], twitch.ErrKeepaliveTimeout)
+	}
+}
+
+func TestRunReconnectLimit(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections.Add(1)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithReconnectLimit(1, 0))
+	client.OnError(func(err error) {})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	var gaveUp error
+	client.OnGiveUp(func(err error) {
+		gaveUp = err
+	})
+
+	err := client.Run(context.Background())
+	assert.ErrorIs(t, err, twitch.ErrReconnectLimit)
+	assert.Equal(t, err, gaveUp)
+	assert.Equal(t, int32(2), connections.Load())
+	assert.ErrorIs(t, client.Healthy(), twitch.ErrReconnectLimit)
+}
+
+func TestRunReconnectDuration(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connections.Add(1)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	clock := clocktest.NewClock(time.Now())
+	client := twitch.NewClientWithUrl(server.URL, twitch.WithClock(clock), twitch.WithReconnectLimit(0, 500*time.Millisecond))
+	client.OnError(func(err error) {})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Run(context.Background())
+	}()
+
+	// Let the backoff pass until the retries have been failing for long enough
+	for {
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, twitch.ErrReconnectLimit)
+			assert.GreaterOrEqual(t, connections.Load(), int32(2))
+			return
+		case <-time.After(time.Millisecond):
+			clock.Advance(100 * time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const twitchValidateUrl = "https://id.twitch.tv/oauth2/validate"
+
+var ErrMissingScopes = fmt.Errorf("missing scopes")
+
+// RequiredScopes returns the scopes a token needs to subscribe to the
+// subscription type. Where Twitch accepts a read or a manage scope the read
+// scope is returned, but MissingScopes accepts either.
+func RequiredScopes(subType EventSubscription) []string {
+	var scopes []string
+	for _, required := range subMetadata[subType].Scopes {
+		scope, _, _ := strings.Cut(required, "|")
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// MissingScopes returns the required scopes of the subscription types that
+// are not in scopes.
+func MissingScopes(scopes []string, subTypes []EventSubscription) []string {
+	granted := map[string]bool{}
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	var missing []string
+	seen := map[string]bool{}
+	for _, subType := range subTypes {
+		for _, required := range subMetadata[subType].Scopes {
+			alternatives := strings.Split(required, "|")
+			if seen[alternatives[0]] || hasAnyScope(granted, alternatives) {
+				continue
+			}
+			seen[alternatives[0]] = true
+			missing = append(missing, alternatives[0])
+		}
+	}
+	return missing
+}
+
+func hasAnyScope(granted map[string]bool, scopes []string) bool {
+	for _, scope := range scopes {
+		if granted[scope] {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenInfo is the response of the Twitch token validation endpoint.
+type TokenInfo struct {
+	ClientID  string   `json:"client_id"`
+	Login     string   `json:"login"`
+	UserID    string   `json:"user_id"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn int      `json:"expires_in"`
+}
+
+// ValidateScopes checks the access token with Twitch and returns an error
+// wrapping ErrMissingScopes if it lacks scopes for any of the subscription
+// types, so auth problems show up before connecting.
+func ValidateScopes(ctx context.Context, accessToken string, subTypes []EventSubscription) (TokenInfo, error) {
+	return ValidateScopesUrl(ctx, accessToken, subTypes, twitchValidateUrl)
+}
+
+func ValidateScopesUrl(ctx context.Context, accessToken string, subTypes []EventSubscription, url string) (TokenInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not create new request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("OAuth %s", accessToken))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not validate token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return TokenInfo{}, fmt.Errorf("could not validate token: %s: %s", resp.Status, string(body))
+	}
+
+	var info TokenInfo
+	err = json.Unmarshal(body, &info)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not unmarshal token info: %w", err)
+	}
+
+	missing := MissingScopes(info.Scopes, subTypes)
+	if len(missing) > 0 {
+		return info, fmt.Errorf("token needs %s: %w", strings.Join(missing, ", "), ErrMissingScopes)
+	}
+	return info, nil
+}
@@ -0,0 +1,55 @@
+package twitch_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"bits:read"}, twitch.RequiredScopes(twitch.SubChannelCheer))
+	assert.Equal(t, []string{"channel:read:polls"}, twitch.RequiredScopes(twitch.SubChannelPollBegin))
+	assert.Empty(t, twitch.RequiredScopes(twitch.SubStreamOnline))
+}
+
+func TestMissingScopes(t *testing.T) {
+	t.Parallel()
+
+	events := []twitch.EventSubscription{twitch.SubChannelCheer, twitch.SubChannelPollBegin, twitch.SubChannelPollEnd, twitch.SubChannelFollow}
+
+	missing := twitch.MissingScopes([]string{"bits:read", "channel:manage:polls"}, events)
+	assert.Equal(t, []string{"moderator:read:followers"}, missing)
+
+	missing = twitch.MissingScopes(nil, events)
+	assert.Equal(t, []string{"bits:read", "channel:read:polls", "moderator:read:followers"}, missing)
+}
+
+func TestValidateScopes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "OAuth token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"client_id":"client","login":"user","user_id":"1337","scopes":["bits:read"],"expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	info, err := twitch.ValidateScopesUrl(context.Background(), "token", []twitch.EventSubscription{twitch.SubChannelCheer}, server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "1337", info.UserID)
+
+	_, err = twitch.ValidateScopesUrl(context.Background(), "token", []twitch.EventSubscription{twitch.SubChannelFollow}, server.URL)
+	assert.ErrorIs(t, err, twitch.ErrMissingScopes)
+
+	_, err = twitch.ValidateScopesUrl(context.Background(), "expired", nil, server.URL)
+	assert.Error(t, err)
+}
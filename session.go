@@ -0,0 +1,194 @@
+package twitch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	sessionMessageIDs   = 1024
+	sessionSaveInterval = 5 * time.Second
+)
+
+// SessionStore persists a client's subscriptions and the IDs of recently
+// handled messages, so a restarted process can pick up where it left off.
+// The sessionstore package has file and key-value store implementations.
+type SessionStore interface {
+	// Load returns the saved state, or an empty state if nothing was saved.
+	Load() (SessionState, error)
+	Save(state SessionState) error
+}
+
+// StoredSubscription is a subscription request without its credentials.
+type StoredSubscription struct {
+	Event           EventSubscription      `json:"event"`
+	VersionOverride string                 `json:"version_override,omitempty"`
+	Condition       map[string]string      `json:"condition"`
+	Transport       *SubscriptionTransport `json:"transport,omitempty"`
+	// LastNotification is the timestamp of the last notification of the
+	// subscription, if any.
+	LastNotification time.Time `json:"last_notification"`
+}
+
+type SessionState struct {
+	Subscriptions []StoredSubscription `json:"subscriptions"`
+	MessageIDs    []string             `json:"message_ids"`
+	LastMessage   time.Time            `json:"last_message"`
+}
+
+// messageIDs remembers the most recent message IDs to skip redeliveries.
+type messageIDs struct {
+	ids   []string
+	index map[string]struct{}
+	next  int
+	mu    sync.Mutex
+}
+
+func newMessageIDs(capacity int) *messageIDs {
+	return &messageIDs{
+		ids:   make([]string, 0, capacity),
+		index: map[string]struct{}{},
+	}
+}
+
+// add returns false if the ID was already added.
+func (m *messageIDs) add(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.index[id]; ok {
+		return false
+	}
+
+	if len(m.ids) < cap(m.ids) {
+		m.ids = append(m.ids, id)
+	} else {
+		delete(m.index, m.ids[m.next])
+		m.ids[m.next] = id
+		m.next = (m.next + 1) % len(m.ids)
+	}
+	m.index[id] = struct{}{}
+	return true
+}
+
+// list returns the IDs from oldest to newest.
+func (m *messageIDs) list() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, 0, len(m.ids))
+	ids = append(ids, m.ids[m.next:]...)
+	return append(ids, m.ids[:m.next]...)
+}
+
+// OnRestore is called after the first welcome of a client whose state was
+// loaded from its SessionStore, with the time of the last message before the
+// restart and the time of the welcome. Events in between were missed.
+func (c *Client) OnRestore(callback func(from, to time.Time)) {
+	c.onRestore = callback
+}
+
+// redelivered reports whether the notification was handled before, when the
+// client has a SessionStore.
+func (c *Client) redelivered(message NotificationMessage) bool {
+	if c.messageIDs == nil {
+		return false
+	}
+	if !c.messageIDs.add(message.Metadata.MessageID) {
+		return true
+	}
+	c.saveSessionAfterInterval()
+	return false
+}
+
+func (c *Client) loadSession() error {
+	c.sessionLoad.Do(func() {
+		state, err := c.sessionStore.Load()
+		if err != nil {
+			c.sessionLoadErr = fmt.Errorf("could not load session: %w", err)
+			return
+		}
+
+		for _, id := range state.MessageIDs {
+			c.messageIDs.add(id)
+		}
+
+		c.subscriptionsMu.Lock()
+		for _, stored := range state.Subscriptions {
+			request := c.sessionCredentials
+			request.SessionID = ""
+			request.Event = stored.Event
+			request.VersionOverride = stored.VersionOverride
+			request.Condition = stored.Condition
+			request.Transport = stored.Transport
+			if c.recordedIndex(request) < 0 {
+				c.subscriptions = append(c.subscriptions, request)
+			}
+
+			if !stored.LastNotification.IsZero() {
+				c.trackNotification(PayloadSubscription{SubscriptionRequest: SubscriptionRequest{
+					Type:      stored.Event,
+					Condition: stored.Condition,
+				}}, stored.LastNotification)
+			}
+		}
+		c.subscriptionsMu.Unlock()
+
+		c.restoredFrom = state.LastMessage
+		if !state.LastMessage.IsZero() && c.gapStart.Load() == 0 {
+			c.gapStart.Store(state.LastMessage.UnixNano())
+		}
+	})
+	return c.sessionLoadErr
+}
+
+func (c *Client) saveSession() {
+	if c.sessionStore == nil {
+		return
+	}
+
+	c.sessionSaveMu.Lock()
+	defer c.sessionSaveMu.Unlock()
+
+	var state SessionState
+	c.subscriptionsMu.Lock()
+	for _, request := range c.subscriptions {
+		state.Subscriptions = append(state.Subscriptions, StoredSubscription{
+			Event:           request.Event,
+			VersionOverride: request.VersionOverride,
+			Condition:       request.Condition,
+			Transport:       request.Transport,
+
+			LastNotification: c.lastNotification(request.Event, request.Condition),
+		})
+	}
+	c.subscriptionsMu.Unlock()
+
+	state.MessageIDs = c.messageIDs.list()
+	if last := c.lastMessage.Load(); last != 0 {
+		state.LastMessage = time.Unix(0, last).UTC()
+	}
+
+	err := c.sessionStore.Save(state)
+	if err != nil {
+		c.onError(fmt.Errorf("could not save session: %w", err))
+	}
+	c.lastSessionSave = c.clock.Now()
+}
+
+// saveSessionAfterInterval saves the session in the background if the last
+// save is more than sessionSaveInterval ago.
+func (c *Client) saveSessionAfterInterval() {
+	if c.sessionStore == nil {
+		return
+	}
+
+	c.sessionSaveMu.Lock()
+	due := c.clock.Now().Sub(c.lastSessionSave) >= sessionSaveInterval
+	c.sessionSaveMu.Unlock()
+
+	if due {
+		go c.saveSession()
+	}
+}
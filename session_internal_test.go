@@ -0,0 +1,22 @@
+package twitch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageIDs(t *testing.T) {
+	t.Parallel()
+
+	ids := newMessageIDs(3)
+	for _, id := range []string{"a", "b", "c", "d"} {
+		assert.True(t, ids.add(id))
+	}
+	assert.False(t, ids.add("d"))
+	assert.Equal(t, []string{"b", "c", "d"}, ids.list())
+
+	// a was evicted
+	assert.True(t, ids.add("a"))
+	assert.Equal(t, []string{"c", "d", "a"}, ids.list())
+}
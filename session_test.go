@@ -0,0 +1,113 @@
+package twitch_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	state twitch.SessionState
+	mu    sync.Mutex
+}
+
+func (s *memoryStore) Load() (twitch.SessionState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+func (s *memoryStore) Save(state twitch.SessionState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	return nil
+}
+
+func TestWithSessionStore(t *testing.T) {
+	t.Parallel()
+
+	server, err := newTestServer(getTestEventData(twitch.SubStreamOnline))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastMessage := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryStore{state: twitch.SessionState{
+		Subscriptions: []twitch.StoredSubscription{{
+			Event:     twitch.SubStreamOnline,
+			Condition: testCondition,
+		}},
+		LastMessage: lastMessage,
+	}}
+
+	client := twitch.NewClientWithUrl(fmt.Sprintf("http://%s/ws", server.Address), twitch.WithSessionStore(store, twitch.SubscribeRequest{ClientID: "client"}))
+	client.SubscriptionUrl = fmt.Sprintf("http://%s/subscriptions", server.Address)
+	client.OnError(func(err error) {
+		t.Errorf("client registered an error: %v", err)
+	})
+	client.OnWelcome(func(message twitch.WelcomeMessage) {})
+
+	restored := make(chan time.Time, 1)
+	client.OnRestore(func(from, to time.Time) {
+		restored <- from
+	})
+
+	online := make(chan struct{})
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		close(online)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		connect(t, client)
+		close(done)
+	}()
+
+	select {
+	case from := <-restored:
+		assert.Equal(t, lastMessage, from)
+	case <-time.After(time.Second):
+		t.Fatal("restore was not reported")
+	}
+
+	select {
+	case <-online:
+	case <-time.After(time.Second):
+		t.Fatal("restored subscription was not recreated")
+	}
+
+	client.Close()
+	<-done
+
+	state, _ := store.Load()
+	if assert.Len(t, state.Subscriptions, 1) {
+		assert.Equal(t, twitch.SubStreamOnline, state.Subscriptions[0].Event)
+	}
+	assert.Len(t, state.MessageIDs, 1)
+	assert.True(t, state.LastMessage.After(lastMessage))
+}
+
+func TestSessionStoreSkipsRedeliveries(t *testing.T) {
+	t.Parallel()
+
+	data, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithSessionStore(&memoryStore{}, twitch.SubscribeRequest{}))
+
+	var handled int
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		handled++
+	})
+
+	assert.NoError(t, client.HandleMessage(data[0]))
+	assert.NoError(t, client.HandleMessage(data[0]))
+	assert.Equal(t, 1, handled)
+}
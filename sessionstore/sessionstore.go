@@ -0,0 +1,130 @@
+// Package sessionstore implements twitch.SessionStore.
+//
+// File keeps the state in a JSON file. KV keeps it under a key of any
+// key-value store without importing its client, for example Redis:
+//
+//	store := sessionstore.KV{
+//		Key: "eventsub:session",
+//		Get: func(key string) ([]byte, error) {
+//			data, err := rdb.Get(ctx, key).Bytes()
+//			if errors.Is(err, redis.Nil) {
+//				return nil, nil
+//			}
+//			return data, err
+//		},
+//		Put: func(key string, value []byte) error {
+//			return rdb.Set(ctx, key, value, 0).Err()
+//		},
+//	}
+//
+// or a BoltDB bucket with Get and Put running in db.View and db.Update.
+package sessionstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+var ErrNoKVFuncs = fmt.Errorf("no Get or Put function was set")
+
+// File stores the state as JSON at Path. Saves write a temporary file and
+// rename it, so a crash never leaves a partial state behind.
+type File struct {
+	Path string
+}
+
+func (f File) Load() (twitch.SessionState, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return twitch.SessionState{}, nil
+	}
+	if err != nil {
+		return twitch.SessionState{}, fmt.Errorf("could not read session file: %w", err)
+	}
+	return decode(data)
+}
+
+func (f File) Save(state twitch.SessionState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not marshal session: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("could not create session file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write session file: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("could not write session file: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), f.Path)
+	if err != nil {
+		return fmt.Errorf("could not replace session file: %w", err)
+	}
+	return nil
+}
+
+// KV stores the state as JSON under Key. Get returns nil when the key does
+// not exist.
+type KV struct {
+	Key string
+	Get func(key string) ([]byte, error)
+	Put func(key string, value []byte) error
+}
+
+func (kv KV) Load() (twitch.SessionState, error) {
+	if kv.Get == nil {
+		return twitch.SessionState{}, ErrNoKVFuncs
+	}
+
+	data, err := kv.Get(kv.Key)
+	if err != nil {
+		return twitch.SessionState{}, fmt.Errorf("could not get session: %w", err)
+	}
+	if data == nil {
+		return twitch.SessionState{}, nil
+	}
+	return decode(data)
+}
+
+func (kv KV) Save(state twitch.SessionState) error {
+	if kv.Put == nil {
+		return ErrNoKVFuncs
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not marshal session: %w", err)
+	}
+
+	err = kv.Put(kv.Key, data)
+	if err != nil {
+		return fmt.Errorf("could not put session: %w", err)
+	}
+	return nil
+}
+
+func decode(data []byte) (twitch.SessionState, error) {
+	var state twitch.SessionState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return twitch.SessionState{}, fmt.Errorf("could not unmarshal session: %w", err)
+	}
+	return state, nil
+}
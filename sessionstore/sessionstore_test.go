@@ -0,0 +1,60 @@
+package sessionstore_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sessionstore"
+	"github.com/stretchr/testify/assert"
+)
+
+var state = twitch.SessionState{
+	Subscriptions: []twitch.StoredSubscription{{
+		Event:     twitch.SubStreamOnline,
+		Condition: map[string]string{"broadcaster_user_id": "1337"},
+	}},
+	MessageIDs:  []string{"a", "b"},
+	LastMessage: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestFile(t *testing.T) {
+	store := sessionstore.File{Path: filepath.Join(t.TempDir(), "session.json")}
+
+	loaded, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, loaded.Subscriptions)
+
+	assert.NoError(t, store.Save(state))
+
+	loaded, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, state, loaded)
+}
+
+func TestKV(t *testing.T) {
+	values := map[string][]byte{}
+	store := sessionstore.KV{
+		Key: "session",
+		Get: func(key string) ([]byte, error) { return values[key], nil },
+		Put: func(key string, value []byte) error {
+			values[key] = value
+			return nil
+		},
+	}
+
+	loaded, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, loaded.Subscriptions)
+
+	assert.NoError(t, store.Save(state))
+	assert.Contains(t, values, "session")
+
+	loaded, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, state, loaded)
+
+	_, err = sessionstore.KV{}.Load()
+	assert.ErrorIs(t, err, sessionstore.ErrNoKVFuncs)
+}
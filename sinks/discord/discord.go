@@ -0,0 +1,221 @@
+// Package discord posts chosen events to a Discord channel as webhook embeds:
+//
+//	notifier := discord.NewNotifier(client, "https://discord.com/api/webhooks/...")
+//	notifier.Formatters[twitch.SubChannelFollow] = discord.Format(func(event twitch.EventChannelFollow) discord.Embed {
+//		return discord.Embed{Description: event.UserName + " joined the crew!"}
+//	})
+//	delete(notifier.Formatters, twitch.SubChannelCheer)
+//
+// Formatters starts out with DefaultFormatters, which cover follows,
+// subscriptions, resubscriptions, gifted subscriptions, cheers, raids, and
+// stream.online. Events without a formatter are not posted.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+// ColorTwitch is the purple of the default embeds.
+const ColorTwitch = 0x9146FF
+
+const maxRateLimitWait = time.Minute
+
+var ErrRejected = fmt.Errorf("discord rejected the webhook message")
+
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Timestamp   *time.Time   `json:"timestamp,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Thumbnail   *EmbedImage  `json:"thumbnail,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type EmbedImage struct {
+	URL string `json:"url"`
+}
+
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// WebhookMessage is the body of a Discord webhook request.
+type WebhookMessage struct {
+	Content   string  `json:"content,omitempty"`
+	Username  string  `json:"username,omitempty"`
+	AvatarURL string  `json:"avatar_url,omitempty"`
+	Embeds    []Embed `json:"embeds"`
+}
+
+// Formatter turns an event into an embed. Returning false skips the event.
+type Formatter func(event any) (Embed, bool)
+
+// Format wraps a formatter of one event type.
+func Format[T any](format func(event T) Embed) Formatter {
+	return func(event any) (Embed, bool) {
+		typed, ok := event.(T)
+		if !ok {
+			return Embed{}, false
+		}
+		return format(typed), true
+	}
+}
+
+// Notifier posts the events that have a formatter to a Discord webhook URL.
+// Username and AvatarURL override the webhook's own. Messages Discord rate
+// limits are retried after the time it asks for, up to MaxRetries times.
+type Notifier struct {
+	URL        string
+	Username   string
+	AvatarURL  string
+	Formatters map[twitch.EventSubscription]Formatter
+
+	HTTPClient *http.Client
+	MaxRetries int
+
+	onError func(err error)
+}
+
+// NewNotifier posts the events of the client that have a formatter. Posts
+// run in the background so Discord's rate limits don't hold up the client.
+func NewNotifier(client *twitch.Client, url string) *Notifier {
+	n := &Notifier{
+		URL:        url,
+		Formatters: DefaultFormatters(),
+		MaxRetries: 3,
+		onError:    func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	}
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		if _, ok := n.Formatters[message.Payload.Subscription.Type]; !ok {
+			return
+		}
+
+		go func() {
+			err := n.Notify(context.Background(), message, event)
+			if err != nil {
+				n.onError(fmt.Errorf("could not post %s to discord: %w", message.Metadata.MessageID, err))
+			}
+		}()
+	})
+	return n
+}
+
+func (n *Notifier) OnError(callback func(err error)) {
+	n.onError = callback
+}
+
+// Notify formats the event with the formatter of its subscription type and
+// posts it. Events without a formatter are skipped.
+func (n *Notifier) Notify(ctx context.Context, message twitch.NotificationMessage, event any) error {
+	format, ok := n.Formatters[message.Payload.Subscription.Type]
+	if !ok {
+		return nil
+	}
+
+	embed, ok := format(event)
+	if !ok {
+		return nil
+	}
+	if embed.Color == 0 {
+		embed.Color = ColorTwitch
+	}
+
+	return n.Post(ctx, WebhookMessage{
+		Username:  n.Username,
+		AvatarURL: n.AvatarURL,
+		Embeds:    []Embed{embed},
+	})
+}
+
+// Post sends a webhook message, waiting out rate limits.
+func (n *Notifier) Post(ctx context.Context, message WebhookMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("could not marshal webhook message: %w", err)
+	}
+
+	for retries := 0; ; retries++ {
+		wait, err := n.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if wait == 0 || retries >= n.MaxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// post returns how long to wait before retrying a rate limited message.
+func (n *Notifier) post(ctx context.Context, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("could not post webhook message: %w", err)
+	}
+	defer res.Body.Close()
+
+	data, _ := io.ReadAll(res.Body)
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return 0, nil
+	}
+
+	err = fmt.Errorf("%w: %s: %s", ErrRejected, res.Status, data)
+	if res.StatusCode != http.StatusTooManyRequests {
+		return 0, err
+	}
+	return retryAfter(res.Header, data), err
+}
+
+// retryAfter reads the seconds to wait from a rate limit response, preferring
+// the more precise retry_after of the body.
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var rateLimit struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	seconds := 1.0
+	if json.Unmarshal(body, &rateLimit) == nil && rateLimit.RetryAfter > 0 {
+		seconds = rateLimit.RetryAfter
+	} else if value, err := strconv.ParseFloat(header.Get("Retry-After"), 64); err == nil && value > 0 {
+		seconds = value
+	}
+
+	wait := time.Duration(seconds * float64(time.Second))
+	if wait > maxRateLimitWait {
+		return maxRateLimitWait
+	}
+	return wait
+}
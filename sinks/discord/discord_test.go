@@ -0,0 +1,126 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatters(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Embed    discord.Embed
+		Expected string
+	}{
+		{
+			"Follow",
+			discord.FormatFollow(twitch.EventChannelFollow{User: twitch.User{UserName: "Alice"}, Broadcaster: twitch.Broadcaster{BroadcasterUserName: "Bob"}}),
+			"**Alice** followed Bob",
+		},
+		{
+			"Subscribe",
+			discord.FormatSubscribe(twitch.EventChannelSubscribe{User: twitch.User{UserName: "Alice"}, Tier: twitch.Tier2, IsGift: true}),
+			"**Alice** subscribed at Tier 2 with a gifted subscription",
+		},
+		{
+			"AnonymousGift",
+			discord.FormatSubscriptionGift(twitch.EventChannelSubscriptionGift{Total: 1, Tier: twitch.Tier1, IsAnonymous: true}),
+			"An anonymous gifter gifted 1 Tier 1 subscription",
+		},
+		{
+			"Cheer",
+			discord.FormatCheer(twitch.EventChannelCheer{User: twitch.User{UserName: "Alice"}, Bits: 100}),
+			"**Alice** cheered 100 bits",
+		},
+		{
+			"Raid",
+			discord.FormatRaid(twitch.EventChannelRaid{FromBroadcasterUserName: "Alice", ToBroadcasterUserName: "Bob", Viewers: 42}),
+			"**Alice** raided Bob with 42 viewers",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Embed.Description)
+		})
+	}
+
+	embed := discord.FormatStreamOnline(twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserLogin: "bob", BroadcasterUserName: "Bob"}})
+	assert.Equal(t, "https://www.twitch.tv/bob", embed.URL)
+	assert.Nil(t, embed.Timestamp)
+}
+
+func TestNotifier(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	messages := make(chan discord.WebhookMessage, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.01, "global": false}`))
+			return
+		}
+
+		var message discord.WebhookMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		messages <- message
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	notifier := discord.NewNotifier(client, server.URL)
+	notifier.Username = "Twitch"
+	notifier.OnError(func(err error) { t.Error(err) })
+	delete(notifier.Formatters, twitch.SubStreamOnline)
+
+	for _, event := range []any{
+		twitch.EventStreamOnline{},
+		twitch.EventChannelFollow{User: twitch.User{UserName: "Alice"}, Broadcaster: twitch.Broadcaster{BroadcasterUserName: "Bob"}},
+	} {
+		data, err := twitch.EncodeNotification(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	select {
+	case message := <-messages:
+		assert.Equal(t, "Twitch", message.Username)
+		if assert.Len(t, message.Embeds, 1) {
+			assert.Equal(t, "**Alice** followed Bob", message.Embeds[0].Description)
+			assert.Equal(t, discord.ColorTwitch, message.Embeds[0].Color)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("follow was not posted")
+	}
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestNotifierRejected(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Invalid Webhook Token"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	notifier := discord.NewNotifier(twitch.NewClient(), server.URL)
+	message, err := twitch.NewNotification(twitch.EventChannelFollow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = notifier.Notify(context.Background(), message, twitch.EventChannelFollow{})
+	assert.ErrorIs(t, err, discord.ErrRejected)
+}
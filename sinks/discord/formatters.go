@@ -0,0 +1,119 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+// DefaultFormatters returns the formatters a Notifier starts with.
+func DefaultFormatters() map[twitch.EventSubscription]Formatter {
+	return map[twitch.EventSubscription]Formatter{
+		twitch.SubChannelFollow:              Format(FormatFollow),
+		twitch.SubChannelSubscribe:           Format(FormatSubscribe),
+		twitch.SubChannelSubscriptionMessage: Format(FormatSubscriptionMessage),
+		twitch.SubChannelSubscriptionGift:    Format(FormatSubscriptionGift),
+		twitch.SubChannelCheer:               Format(FormatCheer),
+		twitch.SubChannelRaid:                Format(FormatRaid),
+		twitch.SubStreamOnline:               Format(FormatStreamOnline),
+	}
+}
+
+func FormatFollow(event twitch.EventChannelFollow) Embed {
+	return Embed{
+		Title:       "New follower",
+		Description: fmt.Sprintf("**%s** followed %s", event.UserName, event.BroadcasterUserName),
+		Timestamp:   timestamp(event.FollowedAt),
+	}
+}
+
+func FormatSubscribe(event twitch.EventChannelSubscribe) Embed {
+	description := fmt.Sprintf("**%s** subscribed at %s", event.UserName, tierName(event.Tier))
+	if event.IsGift {
+		description += " with a gifted subscription"
+	}
+	return Embed{Title: "New subscriber", Description: description}
+}
+
+func FormatSubscriptionMessage(event twitch.EventChannelSubscriptionMessage) Embed {
+	embed := Embed{
+		Title:       "Resubscription",
+		Description: fmt.Sprintf("**%s** resubscribed at %s for %d months", event.UserName, tierName(event.Tier), event.CumulativeMonths),
+	}
+	if event.Message.Text != "" {
+		embed.Fields = []EmbedField{{Name: "Message", Value: event.Message.Text}}
+	}
+	return embed
+}
+
+func FormatSubscriptionGift(event twitch.EventChannelSubscriptionGift) Embed {
+	gifter := "An anonymous gifter"
+	if user := event.Gifter(); user != nil {
+		gifter = "**" + user.UserName + "**"
+	}
+
+	subs := "subscriptions"
+	if event.Total == 1 {
+		subs = "subscription"
+	}
+	return Embed{
+		Title:       "Gifted subscriptions",
+		Description: fmt.Sprintf("%s gifted %d %s %s", gifter, event.Total, tierName(event.Tier), subs),
+	}
+}
+
+func FormatCheer(event twitch.EventChannelCheer) Embed {
+	cheerer := "An anonymous cheerer"
+	if user := event.Cheerer(); user != nil {
+		cheerer = "**" + user.UserName + "**"
+	}
+
+	embed := Embed{
+		Title:       "Cheer",
+		Description: fmt.Sprintf("%s cheered %d bits", cheerer, event.Bits),
+	}
+	if event.Message != "" {
+		embed.Fields = []EmbedField{{Name: "Message", Value: event.Message}}
+	}
+	return embed
+}
+
+func FormatRaid(event twitch.EventChannelRaid) Embed {
+	return Embed{
+		Title:       "Raid",
+		Description: fmt.Sprintf("**%s** raided %s with %d viewers", event.FromBroadcasterUserName, event.ToBroadcasterUserName, event.Viewers),
+		URL:         channelURL(event.FromBroadcasterUserLogin),
+	}
+}
+
+func FormatStreamOnline(event twitch.EventStreamOnline) Embed {
+	return Embed{
+		Title:       event.BroadcasterUserName + " is live",
+		Description: fmt.Sprintf("%s went live on Twitch", event.BroadcasterUserName),
+		URL:         channelURL(event.BroadcasterUserLogin),
+		Timestamp:   timestamp(event.StartedAt),
+	}
+}
+
+func tierName(tier twitch.Tier) string {
+	if level := tier.Level(); level > 0 {
+		return fmt.Sprintf("Tier %d", level)
+	}
+	return string(tier)
+}
+
+// timestamp leaves zero times out of embeds.
+func timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func channelURL(login string) string {
+	if login == "" {
+		return ""
+	}
+	return "https://www.twitch.tv/" + login
+}
@@ -0,0 +1,246 @@
+// Package jsonl writes every event to a file as one json object per line, for
+// a durable log of everything a channel receives:
+//
+//	sink := jsonl.NewSink(client, "logs/events.jsonl")
+//	sink.MaxSize = 100 << 20
+//	sink.MaxAge = 24 * time.Hour
+//	defer sink.Close()
+//
+// Once the file grows past MaxSize or is older than MaxAge, it is renamed
+// with the time of the rotation, like events-20230101T000000.000000000.jsonl,
+// and gzipped unless Compress is turned off.
+package jsonl
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const rotationFormat = "20060102T150405.000000000"
+
+// Line is the json object written for an event.
+type Line struct {
+	MessageID         string                   `json:"message_id"`
+	Timestamp         time.Time                `json:"timestamp"`
+	SubscriptionType  twitch.EventSubscription `json:"subscription_type"`
+	Version           string                   `json:"version"`
+	BroadcasterUserID string                   `json:"broadcaster_user_id,omitempty"`
+	Event             any                      `json:"event"`
+}
+
+func NewLine(message twitch.NotificationMessage, event any) Line {
+	return Line{
+		MessageID:         message.Metadata.MessageID,
+		Timestamp:         message.Metadata.MessageTimestamp,
+		SubscriptionType:  message.Payload.Subscription.Type,
+		Version:           message.Payload.Subscription.Version,
+		BroadcasterUserID: twitch.BroadcasterUserID(message, event),
+		Event:             event,
+	}
+}
+
+// Sink appends events to the file at Path, creating it and its directory when
+// needed. MaxSize in bytes and MaxAge turn on rotation, which is off when
+// they are zero. Clock defaults to the system clock.
+type Sink struct {
+	Path     string
+	MaxSize  int64
+	MaxAge   time.Duration
+	Compress bool
+	Clock    twitch.Clock
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	onError func(err error)
+}
+
+// NewSink writes every event of the client to path. The file is opened with
+// the first event.
+func NewSink(client *twitch.Client, path string) *Sink {
+	s := &Sink{
+		Path:     path,
+		Compress: true,
+		onError:  func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	}
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		err := s.Write(message, event)
+		if err != nil {
+			s.onError(fmt.Errorf("could not write %s: %w", message.Metadata.MessageID, err))
+		}
+	})
+	return s
+}
+
+func (s *Sink) OnError(callback func(err error)) {
+	s.onError = callback
+}
+
+func (s *Sink) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock.Now()
+	}
+	return time.Now()
+}
+
+// Write appends one event, rotating the file first when it is too old or
+// the line would make it too large.
+func (s *Sink) Write(message twitch.NotificationMessage, event any) error {
+	data, err := json.Marshal(NewLine(message, event))
+	if err != nil {
+		return fmt.Errorf("could not marshal line: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file != nil && s.due(int64(len(data))) {
+		err = s.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.file == nil {
+		err = s.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("could not write line: %w", err)
+	}
+	return nil
+}
+
+func (s *Sink) due(next int64) bool {
+	if s.size == 0 {
+		return false
+	}
+	if s.MaxSize > 0 && s.size+next > s.MaxSize {
+		return true
+	}
+	return s.MaxAge > 0 && s.now().Sub(s.openedAt) >= s.MaxAge
+}
+
+func (s *Sink) open() error {
+	err := os.MkdirAll(filepath.Dir(s.Path), 0o755)
+	if err != nil {
+		return fmt.Errorf("could not create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not open log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not stat log: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	s.openedAt = s.now()
+	return nil
+}
+
+// Rotate moves the current file aside now, for rotating on a signal.
+func (s *Sink) Rotate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	return s.rotate()
+}
+
+func (s *Sink) rotate() error {
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return fmt.Errorf("could not close log: %w", err)
+	}
+
+	ext := filepath.Ext(s.Path)
+	rotated := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(s.Path, ext), s.now().UTC().Format(rotationFormat), ext)
+	err = os.Rename(s.Path, rotated)
+	if err != nil {
+		return fmt.Errorf("could not rotate log: %w", err)
+	}
+
+	if s.Compress {
+		err = compress(rotated)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compress replaces path with path.gz.
+func compress(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open rotated log: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return fmt.Errorf("could not create compressed log: %w", err)
+	}
+
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + ".gz")
+		return fmt.Errorf("could not compress rotated log: %w", err)
+	}
+
+	src.Close()
+	err = os.Remove(path)
+	if err != nil {
+		return fmt.Errorf("could not remove rotated log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the current file. Later events open it again.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return fmt.Errorf("could not close log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package jsonl_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/jsonl"
+	"github.com/stretchr/testify/assert"
+)
+
+func handle(t *testing.T, client *twitch.Client, event any) {
+	data, err := twitch.EncodeNotification(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+}
+
+func readLines(t *testing.T, path string) []jsonl.Line {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var scanner *bufio.Scanner
+	if filepath.Ext(path) == ".gz" {
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner = bufio.NewScanner(zr)
+	} else {
+		scanner = bufio.NewScanner(file)
+	}
+
+	var lines []jsonl.Line
+	for scanner.Scan() {
+		var line jsonl.Line
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	assert.NoError(t, scanner.Err())
+	return lines
+}
+
+func rotated(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "events-*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+func TestSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "events.jsonl")
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	sink := jsonl.NewSink(client, path)
+	sink.OnError(func(err error) { t.Error(err) })
+
+	handle(t, client, twitch.EventChannelFollow{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}})
+	handle(t, client, twitch.EventStreamOnline{})
+	assert.NoError(t, sink.Close())
+
+	lines := readLines(t, path)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, twitch.SubChannelFollow, lines[0].SubscriptionType)
+		assert.Equal(t, "1337", lines[0].BroadcasterUserID)
+		assert.NotEmpty(t, lines[0].MessageID)
+		assert.Equal(t, twitch.SubStreamOnline, lines[1].SubscriptionType)
+	}
+}
+
+func TestSinkRotateSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	sink := jsonl.NewSink(client, path)
+	sink.MaxSize = 1
+	sink.OnError(func(err error) { t.Error(err) })
+
+	handle(t, client, twitch.EventChannelFollow{})
+	handle(t, client, twitch.EventChannelCheer{})
+	handle(t, client, twitch.EventChannelRaid{})
+	assert.NoError(t, sink.Close())
+
+	files := rotated(t, dir)
+	if assert.Len(t, files, 2) {
+		assert.Equal(t, ".gz", filepath.Ext(files[0]))
+
+		var types []twitch.EventSubscription
+		for _, file := range append(files, path) {
+			for _, line := range readLines(t, file) {
+				types = append(types, line.SubscriptionType)
+			}
+		}
+		assert.ElementsMatch(t, []twitch.EventSubscription{twitch.SubChannelFollow, twitch.SubChannelCheer, twitch.SubChannelRaid}, types)
+	}
+	assert.Len(t, readLines(t, path), 1)
+}
+
+func TestSinkRotateAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	clock := clocktest.NewClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	sink := jsonl.NewSink(client, path)
+	sink.MaxAge = time.Hour
+	sink.Compress = false
+	sink.Clock = clock
+	sink.OnError(func(err error) { t.Error(err) })
+
+	handle(t, client, twitch.EventChannelFollow{})
+	clock.Advance(30 * time.Minute)
+	handle(t, client, twitch.EventChannelFollow{})
+	assert.Empty(t, rotated(t, dir))
+
+	clock.Advance(30 * time.Minute)
+	handle(t, client, twitch.EventChannelFollow{})
+	assert.NoError(t, sink.Close())
+
+	assert.Equal(t, []string{filepath.Join(dir, "events-20230101T010000.000000000.jsonl")}, rotated(t, dir))
+	assert.Len(t, readLines(t, rotated(t, dir)[0]), 2)
+	assert.Len(t, readLines(t, path), 1)
+}
@@ -0,0 +1,111 @@
+// Package kafka writes EventSub notifications to a Kafka topic. Each record
+// is keyed by the message ID so duplicates can be compacted, and carries the
+// notification metadata as headers.
+//
+// The package does not import a Kafka client. Writer can wrap any producer,
+// for example a kafka-go writer:
+//
+//	writer := kafka.WriterFunc(func(ctx context.Context, message kafka.Message) error {
+//		headers := make([]segmentio.Header, len(message.Headers))
+//		for i, header := range message.Headers {
+//			headers[i] = segmentio.Header{Key: header.Key, Value: header.Value}
+//		}
+//		return w.WriteMessages(ctx, segmentio.Message{
+//			Topic:   message.Topic,
+//			Key:     message.Key,
+//			Value:   message.Value,
+//			Headers: headers,
+//		})
+//	})
+//	kafka.NewBridge(client, writer, "eventsub")
+//
+// Records are written one at a time in the order the notifications arrived,
+// so a partition sees them in order.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const (
+	HeaderMessageID           = "eventsub-message-id"
+	HeaderMessageTimestamp    = "eventsub-message-timestamp"
+	HeaderSubscriptionID      = "eventsub-subscription-id"
+	HeaderSubscriptionType    = "eventsub-subscription-type"
+	HeaderSubscriptionVersion = "eventsub-subscription-version"
+	HeaderBroadcasterUserID   = "eventsub-broadcaster-user-id"
+)
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers []Header
+}
+
+type Writer interface {
+	WriteMessage(ctx context.Context, message Message) error
+}
+
+type WriterFunc func(ctx context.Context, message Message) error
+
+func (f WriterFunc) WriteMessage(ctx context.Context, message Message) error {
+	return f(ctx, message)
+}
+
+// Publisher implements twitch.NotificationPublisher by writing records
+// keyed by message ID with the notification metadata as headers.
+type Publisher struct {
+	Writer Writer
+}
+
+func (p Publisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	return p.Writer.WriteMessage(ctx, Message{Topic: topic, Value: payload})
+}
+
+func (p Publisher) PublishNotification(ctx context.Context, topic string, payload []byte, message twitch.NotificationMessage, event any) error {
+	return p.Writer.WriteMessage(ctx, newMessage(topic, payload, message, event))
+}
+
+// NewBridge writes every notification of the client to topic as JSON.
+func NewBridge(client *twitch.Client, writer Writer, topic string) *twitch.PublisherBridge {
+	bridge := twitch.NewPublisherBridge(client, Publisher{Writer: writer})
+	bridge.Topic = func(twitch.NotificationMessage, any) string { return topic }
+	return bridge
+}
+
+// NewMessage builds the record written for a notification.
+func NewMessage(topic string, message twitch.NotificationMessage, event any) (Message, error) {
+	value, err := json.Marshal(message)
+	if err != nil {
+		return Message{}, fmt.Errorf("could not marshal notification: %w", err)
+	}
+	return newMessage(topic, value, message, event), nil
+}
+
+func newMessage(topic string, value []byte, message twitch.NotificationMessage, event any) Message {
+	subscription := message.Payload.Subscription
+	return Message{
+		Topic: topic,
+		Key:   []byte(message.Metadata.MessageID),
+		Value: value,
+		Headers: []Header{
+			{HeaderMessageID, []byte(message.Metadata.MessageID)},
+			{HeaderMessageTimestamp, []byte(message.Metadata.MessageTimestamp.Format(time.RFC3339Nano))},
+			{HeaderSubscriptionID, []byte(subscription.ID)},
+			{HeaderSubscriptionType, []byte(subscription.Type)},
+			{HeaderSubscriptionVersion, []byte(subscription.Version)},
+			{HeaderBroadcasterUserID, []byte(twitch.BroadcasterUserID(message, event))},
+		},
+	}
+}
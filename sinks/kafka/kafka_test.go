@@ -0,0 +1,91 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMessage(t *testing.T) {
+	var message twitch.NotificationMessage
+	message.Metadata.MessageID = "befa7b53-d79d-478f-86b9-120f112b044e"
+	message.Payload.Subscription.Type = twitch.SubStreamOnline
+	message.Payload.Subscription.Version = "1"
+
+	event := twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}}
+
+	record, err := kafka.NewMessage("eventsub", message, event)
+	assert.NoError(t, err)
+	assert.Equal(t, "eventsub", record.Topic)
+	assert.Equal(t, []byte(message.Metadata.MessageID), record.Key)
+
+	headers := map[string]string{}
+	for _, header := range record.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	assert.Equal(t, "stream.online", headers[kafka.HeaderSubscriptionType])
+	assert.Equal(t, "1", headers[kafka.HeaderSubscriptionVersion])
+	assert.Equal(t, "1337", headers[kafka.HeaderBroadcasterUserID])
+
+	var decoded twitch.NotificationMessage
+	err = json.Unmarshal(record.Value, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, message.Metadata.MessageID, decoded.Metadata.MessageID)
+}
+
+func TestNewBridge(t *testing.T) {
+	client := twitch.NewClient()
+	client.OnError(func(err error) { t.Errorf("client registered an error: %v", err) })
+
+	var (
+		mu      sync.Mutex
+		records []kafka.Message
+	)
+	done := make(chan struct{})
+	kafka.NewBridge(client, kafka.WriterFunc(func(ctx context.Context, message kafka.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		records = append(records, message)
+		if len(records) == 100 {
+			close(done)
+		}
+		return nil
+	}), "eventsub")
+
+	for i := 0; i < 100; i++ {
+		data, err := twitch.EncodeNotification(twitch.EventStreamOnline{Id: strconv.Itoa(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("records were not written")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, record := range records {
+		assert.Equal(t, "eventsub", record.Topic)
+		assert.Len(t, record.Headers, 6)
+
+		var message struct {
+			Payload struct {
+				Event twitch.EventStreamOnline `json:"event"`
+			} `json:"payload"`
+		}
+		assert.NoError(t, json.Unmarshal(record.Value, &message))
+		assert.Equal(t, strconv.Itoa(i), message.Payload.Event.Id, "records are written in order")
+	}
+}
@@ -0,0 +1,105 @@
+// Package mqtt publishes EventSub events to MQTT topics of the form
+// twitch/eventsub/<type>/<broadcaster_id>, for home automation that reacts to
+// follows or channel point redemptions.
+//
+// Messages are published one at a time in the order the events arrived, and
+// Conn takes the same arguments as a paho client:
+//
+//	conn := mqtt.PublishFunc(func(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error {
+//		token := c.Publish(topic, qos, retained, payload)
+//		token.Wait()
+//		return token.Error()
+//	})
+//	mqtt.NewBridge(client, mqtt.Publisher{
+//		Conn:   conn,
+//		QoS:    1,
+//		Retain: mqtt.RetainTypes(twitch.SubStreamOnline, twitch.SubStreamOffline),
+//	})
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const TopicPrefix = "twitch/eventsub"
+
+var ErrInvalidQoS = fmt.Errorf("qos must be 0, 1, or 2")
+
+type Conn interface {
+	Publish(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error
+}
+
+type PublishFunc func(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error
+
+func (f PublishFunc) Publish(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error {
+	return f(ctx, topic, qos, retained, payload)
+}
+
+// Topic returns twitch/eventsub/<type>/<broadcaster_id>, leaving out the
+// broadcaster for events that do not belong to one.
+func Topic(message twitch.NotificationMessage, event any) string {
+	parts := []string{TopicPrefix, string(message.Payload.Subscription.Type)}
+
+	broadcasterID := twitch.BroadcasterUserID(message, event)
+	if broadcasterID != "" {
+		parts = append(parts, broadcasterID)
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// RetainTypes retains the last message of the given subscription types, so
+// devices that connect later see states like whether the stream is live.
+func RetainTypes(types ...twitch.EventSubscription) func(message twitch.NotificationMessage) bool {
+	return func(message twitch.NotificationMessage) bool {
+		for _, subType := range types {
+			if message.Payload.Subscription.Type == subType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Publisher implements twitch.NotificationPublisher by publishing with QoS.
+// Retain decides which messages the broker keeps for new subscribers, none by
+// default.
+type Publisher struct {
+	Conn   Conn
+	QoS    byte
+	Retain func(message twitch.NotificationMessage) bool
+}
+
+func (p Publisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	if p.QoS > 2 {
+		return fmt.Errorf("%w, not %d", ErrInvalidQoS, p.QoS)
+	}
+	return p.Conn.Publish(ctx, topic, p.QoS, false, payload)
+}
+
+func (p Publisher) PublishNotification(ctx context.Context, topic string, payload []byte, message twitch.NotificationMessage, event any) error {
+	if p.QoS > 2 {
+		return fmt.Errorf("%w, not %d", ErrInvalidQoS, p.QoS)
+	}
+
+	retained := p.Retain != nil && p.Retain(message)
+	return p.Conn.Publish(ctx, topic, p.QoS, retained, payload)
+}
+
+// NewBridge publishes the events of the client to Topic. The payload is the
+// event as JSON, without the notification around it.
+func NewBridge(client *twitch.Client, publisher Publisher) *twitch.PublisherBridge {
+	bridge := twitch.NewPublisherBridge(client, publisher)
+	bridge.Topic = Topic
+	bridge.Payload = eventPayload
+	return bridge
+}
+
+func eventPayload(message twitch.NotificationMessage, event any) ([]byte, error) {
+	return json.Marshal(event)
+}
@@ -0,0 +1,94 @@
+package mqtt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/mqtt"
+	"github.com/stretchr/testify/assert"
+)
+
+type published struct {
+	topic    string
+	qos      byte
+	retained bool
+	payload  string
+}
+
+func newMessage(event twitch.EventSubscription, condition map[string]string) twitch.NotificationMessage {
+	var message twitch.NotificationMessage
+	message.Payload.Subscription.Type = event
+	message.Payload.Subscription.Condition = condition
+	return message
+}
+
+func TestTopic(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Message  twitch.NotificationMessage
+		Event    any
+		Expected string
+	}{
+		{
+			"Broadcaster",
+			newMessage(twitch.SubStreamOnline, nil),
+			twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}},
+			"twitch/eventsub/stream.online/1337",
+		},
+		{
+			"NoBroadcaster",
+			newMessage(twitch.SubUserUpdate, nil),
+			twitch.EventUserUpdate{},
+			"twitch/eventsub/user.update",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, mqtt.Topic(tc.Message, tc.Event))
+		})
+	}
+}
+
+func TestBridge(t *testing.T) {
+	var messages []published
+	conn := mqtt.PublishFunc(func(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error {
+		messages = append(messages, published{topic, qos, retained, string(payload)})
+		return nil
+	})
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnError(func(err error) { t.Error(err) })
+	mqtt.NewBridge(client, mqtt.Publisher{
+		Conn:   conn,
+		QoS:    1,
+		Retain: mqtt.RetainTypes(twitch.SubStreamOnline),
+	})
+
+	for _, event := range []any{
+		twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}},
+		twitch.EventChannelFollow{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}},
+	} {
+		data, err := twitch.EncodeNotification(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "twitch/eventsub/stream.online/1337", messages[0].topic)
+		assert.Equal(t, byte(1), messages[0].qos)
+		assert.True(t, messages[0].retained)
+		assert.Contains(t, messages[0].payload, `"broadcaster_user_id":"1337"`)
+
+		assert.Equal(t, "twitch/eventsub/channel.follow/1337", messages[1].topic)
+		assert.False(t, messages[1].retained)
+	}
+
+	publisher := mqtt.Publisher{Conn: conn, QoS: 3}
+	message, _ := twitch.NewNotification(twitch.EventStreamOnline{})
+	err := publisher.PublishNotification(context.Background(), "topic", nil, message, twitch.EventStreamOnline{})
+	assert.ErrorIs(t, err, mqtt.ErrInvalidQoS)
+}
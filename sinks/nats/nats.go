@@ -0,0 +1,69 @@
+// Package nats publishes EventSub notifications to NATS subjects of the form
+// twitch.eventsub.<type>.<broadcaster_id>.
+//
+// The package does not import the NATS client. Conn is satisfied by
+// *nats.Conn and JetStream can wrap a jetstream.JetStream:
+//
+//	publisher := nats.Publisher{
+//		Conn: nc,
+//		JetStream: func(ctx context.Context, subject string, data []byte) error {
+//			_, err := js.Publish(ctx, subject, data)
+//			return err
+//		},
+//	}
+//	nats.NewBridge(client, publisher)
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const SubjectPrefix = "twitch.eventsub"
+
+var ErrNoConn = fmt.Errorf("no NATS connection or JetStream publisher was set")
+
+type Conn interface {
+	Publish(subject string, data []byte) error
+}
+
+// Publisher implements twitch.Publisher. When JetStream is set messages are
+// published through it so they are persisted, otherwise Conn is used.
+type Publisher struct {
+	Conn      Conn
+	JetStream func(ctx context.Context, subject string, data []byte) error
+}
+
+func (p Publisher) Publish(ctx context.Context, subject string, payload []byte) error {
+	if p.JetStream != nil {
+		return p.JetStream(ctx, subject, payload)
+	}
+
+	if p.Conn == nil {
+		return ErrNoConn
+	}
+	return p.Conn.Publish(subject, payload)
+}
+
+// Subject returns twitch.eventsub.<type>.<broadcaster_id>, leaving out the
+// broadcaster for events that do not belong to one.
+func Subject(message twitch.NotificationMessage, event any) string {
+	parts := []string{SubjectPrefix, string(message.Payload.Subscription.Type)}
+
+	broadcasterID := twitch.BroadcasterUserID(message, event)
+	if broadcasterID != "" {
+		parts = append(parts, broadcasterID)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// NewBridge forwards every notification of the client to NATS as JSON.
+func NewBridge(client *twitch.Client, publisher Publisher) *twitch.PublisherBridge {
+	bridge := twitch.NewPublisherBridge(client, publisher)
+	bridge.Topic = Subject
+	return bridge
+}
@@ -0,0 +1,81 @@
+package nats_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/nats"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConn struct {
+	subject string
+}
+
+func (c *testConn) Publish(subject string, data []byte) error {
+	c.subject = subject
+	return nil
+}
+
+func newMessage(event twitch.EventSubscription, condition map[string]string) twitch.NotificationMessage {
+	var message twitch.NotificationMessage
+	message.Payload.Subscription.Type = event
+	message.Payload.Subscription.Condition = condition
+	return message
+}
+
+func TestSubject(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Message  twitch.NotificationMessage
+		Event    any
+		Expected string
+	}{
+		{
+			"Broadcaster",
+			newMessage(twitch.SubStreamOnline, nil),
+			twitch.EventStreamOnline{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}},
+			"twitch.eventsub.stream.online.1337",
+		},
+		{
+			"Condition",
+			newMessage(twitch.SubChannelRaid, map[string]string{"to_broadcaster_user_id": "42"}),
+			twitch.EventChannelRaid{},
+			"twitch.eventsub.channel.raid.42",
+		},
+		{
+			"NoBroadcaster",
+			newMessage(twitch.SubUserUpdate, nil),
+			twitch.EventUserUpdate{},
+			"twitch.eventsub.user.update",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, nats.Subject(tc.Message, tc.Event))
+		})
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	conn := &testConn{}
+	err := nats.Publisher{Conn: conn}.Publish(context.Background(), "subject", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "subject", conn.subject)
+
+	var jetStreamSubject string
+	err = nats.Publisher{
+		Conn: conn,
+		JetStream: func(ctx context.Context, subject string, data []byte) error {
+			jetStreamSubject = subject
+			return nil
+		},
+	}.Publish(context.Background(), "persisted", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "persisted", jetStreamSubject)
+
+	err = nats.Publisher{}.Publish(context.Background(), "subject", nil)
+	assert.ErrorIs(t, err, nats.ErrNoConn)
+}
@@ -0,0 +1,122 @@
+// Package redis adds EventSub notifications to Redis Streams with XADD, so
+// several workers can share them through a consumer group. Entries are added
+// one at a time in the order the notifications arrived, so stream IDs follow
+// that order. Every entry has the message ID, subscription type, and
+// broadcaster as fields next to the notification, so workers can skip
+// duplicates and route without decoding.
+//
+// An Entry holds the XADD arguments, and with go-redis the writer is:
+//
+//	writer := redis.WriterFunc(func(ctx context.Context, entry redis.Entry) error {
+//		return rdb.XAdd(ctx, &goredis.XAddArgs{
+//			Stream: entry.Stream,
+//			MaxLen: entry.MaxLen,
+//			Approx: entry.MaxLen > 0,
+//			Values: entry.Values,
+//		}).Err()
+//	})
+//	redis.NewBridge(client, redis.Publisher{Writer: writer, MaxLen: 100000})
+//
+// Workers then read with XREADGROUP GROUP <group> <consumer> STREAMS
+// twitch:eventsub >.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const DefaultStream = "twitch:eventsub"
+
+const (
+	FieldMessageID           = "message_id"
+	FieldMessageTimestamp    = "message_timestamp"
+	FieldSubscriptionID      = "subscription_id"
+	FieldSubscriptionType    = "subscription_type"
+	FieldSubscriptionVersion = "subscription_version"
+	FieldBroadcasterUserID   = "broadcaster_user_id"
+	FieldNotification        = "notification"
+)
+
+// Entry is one XADD. MaxLen trims the stream to about that many entries when
+// it is not zero.
+type Entry struct {
+	Stream string
+	MaxLen int64
+	Values map[string]any
+}
+
+type Writer interface {
+	XAdd(ctx context.Context, entry Entry) error
+}
+
+type WriterFunc func(ctx context.Context, entry Entry) error
+
+func (f WriterFunc) XAdd(ctx context.Context, entry Entry) error {
+	return f(ctx, entry)
+}
+
+// StreamPerType returns twitch:eventsub:<type>, for consumer groups that only
+// handle some subscription types.
+func StreamPerType(message twitch.NotificationMessage, event any) string {
+	return DefaultStream + ":" + string(message.Payload.Subscription.Type)
+}
+
+// Publisher implements twitch.NotificationPublisher by adding an entry to
+// the stream named by the topic. MaxLen trims the stream to about that many
+// entries when it is not zero.
+type Publisher struct {
+	Writer Writer
+	MaxLen int64
+}
+
+func (p Publisher) Publish(ctx context.Context, stream string, payload []byte) error {
+	return p.Writer.XAdd(ctx, Entry{
+		Stream: stream,
+		MaxLen: p.MaxLen,
+		Values: map[string]any{FieldNotification: string(payload)},
+	})
+}
+
+func (p Publisher) PublishNotification(ctx context.Context, stream string, payload []byte, message twitch.NotificationMessage, event any) error {
+	entry := newEntry(stream, payload, message, event)
+	entry.MaxLen = p.MaxLen
+	return p.Writer.XAdd(ctx, entry)
+}
+
+// NewBridge adds every notification of the client to the twitch:eventsub
+// stream. Setting the Topic of the bridge to StreamPerType splits them up.
+func NewBridge(client *twitch.Client, publisher Publisher) *twitch.PublisherBridge {
+	bridge := twitch.NewPublisherBridge(client, publisher)
+	bridge.Topic = func(twitch.NotificationMessage, any) string { return DefaultStream }
+	return bridge
+}
+
+// NewEntry builds the entry added for a notification.
+func NewEntry(stream string, message twitch.NotificationMessage, event any) (Entry, error) {
+	notification, err := json.Marshal(message)
+	if err != nil {
+		return Entry{}, fmt.Errorf("could not marshal notification: %w", err)
+	}
+	return newEntry(stream, notification, message, event), nil
+}
+
+func newEntry(stream string, notification []byte, message twitch.NotificationMessage, event any) Entry {
+	subscription := message.Payload.Subscription
+	return Entry{
+		Stream: stream,
+		Values: map[string]any{
+			FieldMessageID:           message.Metadata.MessageID,
+			FieldMessageTimestamp:    message.Metadata.MessageTimestamp.Format(time.RFC3339Nano),
+			FieldSubscriptionID:      subscription.ID,
+			FieldSubscriptionType:    string(subscription.Type),
+			FieldSubscriptionVersion: subscription.Version,
+			FieldBroadcasterUserID:   twitch.BroadcasterUserID(message, event),
+			FieldNotification:        string(notification),
+		},
+	}
+}
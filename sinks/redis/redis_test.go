@@ -0,0 +1,100 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBridge(t *testing.T) {
+	var entries []redis.Entry
+	writer := redis.WriterFunc(func(ctx context.Context, entry redis.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnError(func(err error) { t.Error(err) })
+	redis.NewBridge(client, redis.Publisher{Writer: writer, MaxLen: 1000})
+
+	message, err := twitch.NewNotification(twitch.EventChannelFollow{Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+
+	if assert.Len(t, entries, 1) {
+		entry := entries[0]
+		assert.Equal(t, redis.DefaultStream, entry.Stream)
+		assert.Equal(t, int64(1000), entry.MaxLen)
+		assert.Equal(t, message.Metadata.MessageID, entry.Values[redis.FieldMessageID])
+		assert.Equal(t, "channel.follow", entry.Values[redis.FieldSubscriptionType])
+		assert.Equal(t, "1337", entry.Values[redis.FieldBroadcasterUserID])
+
+		_, event, err := twitch.DecodeMessage([]byte(entry.Values[redis.FieldNotification].(string)))
+		assert.NoError(t, err)
+		assert.IsType(t, twitch.EventChannelFollow{}, event)
+	}
+}
+
+func TestBridgeStreamPerType(t *testing.T) {
+	var streams []string
+	writer := redis.WriterFunc(func(ctx context.Context, entry redis.Entry) error {
+		streams = append(streams, entry.Stream)
+		return errors.New("connection refused")
+	})
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	bridge := redis.NewBridge(client, redis.Publisher{Writer: writer})
+	bridge.Topic = redis.StreamPerType
+
+	var errs []error
+	client.OnError(func(err error) { errs = append(errs, err) })
+
+	data, err := twitch.EncodeNotification(twitch.EventStreamOnline{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+
+	assert.Equal(t, []string{"twitch:eventsub:stream.online"}, streams)
+	assert.Len(t, errs, 1)
+}
+
+func TestBridgeOrder(t *testing.T) {
+	ids := make(chan string, 100)
+	writer := redis.WriterFunc(func(ctx context.Context, entry redis.Entry) error {
+		_, event, err := twitch.DecodeMessage([]byte(entry.Values[redis.FieldNotification].(string)))
+		if err != nil {
+			return err
+		}
+		ids <- event.(twitch.EventStreamOnline).Id
+		return nil
+	})
+
+	client := twitch.NewClient()
+	client.OnError(func(err error) { t.Error(err) })
+	redis.NewBridge(client, redis.Publisher{Writer: writer})
+
+	for i := 0; i < 100; i++ {
+		data, err := twitch.EncodeNotification(twitch.EventStreamOnline{Id: strconv.Itoa(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, strconv.Itoa(i), <-ids, "entries are added in order")
+	}
+}
@@ -0,0 +1,207 @@
+// Package webhook forwards EventSub notifications as JSON POST requests to an
+// HTTP endpoint, like an n8n or Zapier webhook or an internal service:
+//
+//	sink := webhook.NewSink(client, "https://example.com/hooks/twitch")
+//	sink.Secret = []byte("shared secret")
+//	sink.Types = []twitch.EventSubscription{twitch.SubChannelFollow, twitch.SubChannelCheer}
+//
+// With a Secret, requests carry the same kind of signature Twitch sends with
+// its webhooks: the Eventsub-Signature header is sha256= and the hex HMAC-SHA256
+// of the message ID, the timestamp, and the body.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const (
+	HeaderMessageID        = "Eventsub-Message-Id"
+	HeaderTimestamp        = "Eventsub-Message-Timestamp"
+	HeaderSubscriptionType = "Eventsub-Subscription-Type"
+	HeaderSignature        = "Eventsub-Signature"
+
+	signaturePrefix = "sha256="
+	maxBackoff      = 30 * time.Second
+)
+
+var ErrRejected = fmt.Errorf("endpoint rejected the notification")
+
+// Payload is the body of a forwarded notification.
+type Payload struct {
+	MessageID         string                   `json:"message_id"`
+	Timestamp         time.Time                `json:"timestamp"`
+	SubscriptionType  twitch.EventSubscription `json:"subscription_type"`
+	Version           string                   `json:"version"`
+	BroadcasterUserID string                   `json:"broadcaster_user_id,omitempty"`
+	Event             any                      `json:"event"`
+}
+
+// NewPayload builds the body forwarded for a notification.
+func NewPayload(message twitch.NotificationMessage, event any) Payload {
+	return Payload{
+		MessageID:         message.Metadata.MessageID,
+		Timestamp:         message.Metadata.MessageTimestamp,
+		SubscriptionType:  message.Payload.Subscription.Type,
+		Version:           message.Payload.Subscription.Version,
+		BroadcasterUserID: twitch.BroadcasterUserID(message, event),
+		Event:             event,
+	}
+}
+
+// Sign returns the signature header value of a body.
+func Sign(secret []byte, messageID, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(messageID))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sink posts notifications to URL. Types selects the subscription types to
+// forward, all when empty. Failed requests are retried up to MaxAttempts
+// times, waiting Backoff between attempts, when the endpoint could not be
+// reached or answered 429 or a 5xx status. Other statuses are not retried.
+type Sink struct {
+	URL    string
+	Secret []byte
+	Types  []twitch.EventSubscription
+
+	HTTPClient  *http.Client
+	MaxAttempts int
+	// Backoff returns how long to wait before the retry after attempt
+	// failed. It defaults to doubling from a second up to 30 seconds.
+	Backoff func(attempt int) time.Duration
+
+	onError func(err error)
+}
+
+// NewSink forwards every notification of the client to url. Deliveries run
+// in the background so slow endpoints don't hold up the client, which also
+// means they may arrive out of order.
+func NewSink(client *twitch.Client, url string) *Sink {
+	s := &Sink{
+		URL:         url,
+		MaxAttempts: 5,
+		Backoff:     defaultBackoff,
+		onError:     func(err error) { fmt.Printf("ERROR: %v\n", err) },
+	}
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		if !s.selected(message.Payload.Subscription.Type) {
+			return
+		}
+
+		go func() {
+			err := s.Send(context.Background(), message, event)
+			if err != nil {
+				s.onError(fmt.Errorf("could not forward %s: %w", message.Metadata.MessageID, err))
+			}
+		}()
+	})
+	return s
+}
+
+func (s *Sink) OnError(callback func(err error)) {
+	s.onError = callback
+}
+
+func (s *Sink) selected(subType twitch.EventSubscription) bool {
+	if len(s.Types) == 0 {
+		return true
+	}
+	for _, selected := range s.Types {
+		if selected == subType {
+			return true
+		}
+	}
+	return false
+}
+
+// Send posts one notification, retrying as configured, and returns the error
+// of the last attempt.
+func (s *Sink) Send(ctx context.Context, message twitch.NotificationMessage, event any) error {
+	body, err := json.Marshal(NewPayload(message, event))
+	if err != nil {
+		return fmt.Errorf("could not marshal payload: %w", err)
+	}
+
+	attempts := s.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, message, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= attempts {
+			return err
+		}
+
+		backoff := defaultBackoff
+		if s.Backoff != nil {
+			backoff = s.Backoff
+		}
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// post returns whether a failed request should be retried.
+func (s *Sink) post(ctx context.Context, message twitch.NotificationMessage, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("could not create request: %w", err)
+	}
+
+	timestamp := message.Metadata.MessageTimestamp.UTC().Format(time.RFC3339Nano)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderMessageID, message.Metadata.MessageID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSubscriptionType, string(message.Payload.Subscription.Type))
+	if len(s.Secret) > 0 {
+		req.Header.Set(HeaderSignature, Sign(s.Secret, message.Metadata.MessageID, timestamp, body))
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("could not post to %s: %w", s.URL, err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("%w: %s answered %s", ErrRejected, s.URL, res.Status)
+}
+
+func defaultBackoff(attempt int) time.Duration {
+	backoff := time.Second << (attempt - 1)
+	if backoff <= 0 || backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
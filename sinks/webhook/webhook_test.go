@@ -0,0 +1,118 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sinks/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinkForwards(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	requests := make(chan *http.Request, 2)
+	bodies := make(chan []byte, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	sink := webhook.NewSink(client, server.URL)
+	sink.Secret = secret
+	sink.Types = []twitch.EventSubscription{twitch.SubChannelFollow}
+	sink.OnError(func(err error) { t.Error(err) })
+
+	for _, event := range []any{
+		twitch.EventStreamOnline{},
+		twitch.EventChannelFollow{User: twitch.User{UserLogin: "alice"}, Broadcaster: twitch.Broadcaster{BroadcasterUserId: "1337"}},
+	} {
+		data, err := twitch.EncodeNotification(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	select {
+	case r := <-requests:
+		body := <-bodies
+		assert.Equal(t, string(twitch.SubChannelFollow), r.Header.Get(webhook.HeaderSubscriptionType))
+		assert.Equal(t, webhook.Sign(secret, r.Header.Get(webhook.HeaderMessageID), r.Header.Get(webhook.HeaderTimestamp), body), r.Header.Get(webhook.HeaderSignature))
+
+		var payload struct {
+			SubscriptionType  string         `json:"subscription_type"`
+			BroadcasterUserID string         `json:"broadcaster_user_id"`
+			Event             map[string]any `json:"event"`
+		}
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "channel.follow", payload.SubscriptionType)
+		assert.Equal(t, "1337", payload.BroadcasterUserID)
+		assert.Equal(t, "alice", payload.Event["user_login"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not forwarded")
+	}
+
+	select {
+	case r := <-requests:
+		t.Errorf("unselected %s was forwarded", r.Header.Get(webhook.HeaderSubscriptionType))
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSinkRetries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Statuses []int
+		Attempts int32
+		Err      bool
+	}{
+		{"Success", []int{http.StatusOK}, 1, false},
+		{"RetryServerError", []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusNoContent}, 3, false},
+		{"GiveUp", []int{500, 500, 500, 500}, 3, true},
+		{"ClientError", []int{http.StatusBadRequest, http.StatusOK}, 1, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.Statuses[attempts.Add(1)-1])
+			}))
+			defer server.Close()
+
+			sink := webhook.NewSink(twitch.NewClient(), server.URL)
+			sink.MaxAttempts = 3
+			sink.Backoff = func(attempt int) time.Duration { return time.Millisecond }
+
+			message, err := twitch.NewNotification(twitch.EventStreamOnline{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = sink.Send(context.Background(), message, twitch.EventStreamOnline{})
+			assert.Equal(t, tc.Attempts, attempts.Load())
+			if tc.Err {
+				assert.ErrorIs(t, err, webhook.ErrRejected)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
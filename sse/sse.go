@@ -0,0 +1,131 @@
+// Package sse re-broadcasts EventSub events to browsers as Server-Sent Events.
+//
+// Each event is written with the message ID as the SSE id, the subscription
+// type as the SSE event name, and the decoded event as JSON data. Browsers can
+// narrow the stream further with one or more type query parameters:
+//
+//	new EventSource("/events?type=channel.follow&type=channel.raid")
+package sse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+)
+
+const defaultBufferSize = 16
+
+type frame struct {
+	event twitch.EventSubscription
+	data  []byte
+}
+
+type Handler struct {
+	events  map[twitch.EventSubscription]bool
+	clients map[chan frame]map[twitch.EventSubscription]bool
+	mu      sync.Mutex
+
+	// BufferSize is how many events are queued for a slow browser before
+	// newer events are dropped for it.
+	BufferSize int
+}
+
+// NewHandler creates a handler that streams the given events from the client,
+// or every event if none are given.
+func NewHandler(client *twitch.Client, events ...twitch.EventSubscription) *Handler {
+	h := &Handler{
+		events:     map[twitch.EventSubscription]bool{},
+		clients:    map[chan frame]map[twitch.EventSubscription]bool{},
+		BufferSize: defaultBufferSize,
+	}
+
+	for _, event := range events {
+		h.events[event] = true
+	}
+
+	if client != nil {
+		client.AddListener(h.Send)
+	}
+	return h
+}
+
+// Send broadcasts an event to every connected browser.
+func (h *Handler) Send(message twitch.NotificationMessage, event any) {
+	eventType := message.Payload.Subscription.Type
+	if len(h.events) > 0 && !h.events[eventType] {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "id: %s\nevent: %s\n", message.Metadata.MessageID, eventType)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch, filter := range h.clients {
+		if len(filter) > 0 && !filter[eventType] {
+			continue
+		}
+
+		select {
+		case ch <- frame{eventType, buf.Bytes()}:
+		default:
+		}
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter := map[twitch.EventSubscription]bool{}
+	for _, event := range r.URL.Query()["type"] {
+		filter[twitch.EventSubscription(event)] = true
+	}
+
+	ch := make(chan frame, h.BufferSize)
+	h.mu.Lock()
+	h.clients[ch] = filter
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, ch)
+		h.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case f := <-ch:
+			_, err := w.Write(f.data)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sse_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/sse"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMessage(event twitch.EventSubscription) twitch.NotificationMessage {
+	var message twitch.NotificationMessage
+	message.Metadata.MessageID = "befa7b53-d79d-478f-86b9-120f112b044e"
+	message.Payload.Subscription.Type = event
+	return message
+}
+
+func TestHandler(t *testing.T) {
+	handler := sse.NewHandler(nil, twitch.SubStreamOnline, twitch.SubStreamOffline)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?type=stream.online")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	handler.Send(newMessage(twitch.SubStreamOffline), twitch.EventStreamOffline{})
+	handler.Send(newMessage(twitch.SubChannelFollow), twitch.EventChannelFollow{})
+	handler.Send(newMessage(twitch.SubStreamOnline), twitch.EventStreamOnline{Id: "9001"})
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "\n" {
+			break
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	assert.Equal(t, "id: befa7b53-d79d-478f-86b9-120f112b044e", lines[0])
+	assert.Equal(t, "event: stream.online", lines[1])
+	assert.Contains(t, lines[2], `"id":"9001"`)
+}
@@ -0,0 +1,148 @@
+package twitch
+
+import (
+	"sync"
+	"time"
+)
+
+// ChannelState is what the events of a broadcaster tell about their channel
+// right now. Fields stay at their zero value until an event sets them.
+type ChannelState struct {
+	BroadcasterUserID string
+	// Online is set by stream.online and cleared by stream.offline, along
+	// with Stream
+	Online bool
+	Stream *EventStreamOnline
+	// Poll is the running poll, updated by its progress and cleared when it
+	// ends
+	Poll *EventChannelPollBegin
+	// HypeTrain is the running hype train, updated by its progress and
+	// cleared when it ends
+	HypeTrain *EventChannelHypeTrainBegin
+	// ShieldMode is set while shield mode is on
+	ShieldMode   *EventChannelShieldModeBegin
+	ChatSettings ChatSettings
+	// UpdatedAt is the timestamp of the last message that changed the state
+	UpdatedAt time.Time
+}
+
+// ChatSettings are the chat modes moderators changed in channel.moderate
+// events.
+type ChatSettings struct {
+	// FollowersOnly is set while only followers can chat
+	FollowersOnly *Followers
+	// Slow is set while slow mode is on
+	Slow            *SlowMode
+	EmoteOnly       bool
+	SubscribersOnly bool
+	UniqueChat      bool
+}
+
+// WithStateCache keeps the state of every broadcaster's channel as their
+// events arrive, which State returns. It is updated before the callbacks run,
+// so they see the state after their event.
+func WithStateCache() ClientOption {
+	return func(c *Client) {
+		c.state = &stateCache{channels: map[string]ChannelState{}}
+		c.addListener(c.state.apply, true)
+	}
+}
+
+// State returns the state of the channels WithStateCache kept, by broadcaster
+// user ID, or nil without WithStateCache.
+func (c *Client) State() map[string]ChannelState {
+	if c.state == nil {
+		return nil
+	}
+
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+
+	channels := make(map[string]ChannelState, len(c.state.channels))
+	for broadcasterUserID, state := range c.state.channels {
+		channels[broadcasterUserID] = state
+	}
+	return channels
+}
+
+type stateCache struct {
+	channels map[string]ChannelState
+	mu       sync.Mutex
+}
+
+func (s *stateCache) apply(message NotificationMessage, event any) {
+	broadcasterUserID := BroadcasterUserID(message, event)
+	if broadcasterUserID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.channels[broadcasterUserID]
+	if !state.update(event) {
+		return
+	}
+	state.BroadcasterUserID = broadcasterUserID
+	state.UpdatedAt = message.Metadata.MessageTimestamp
+	s.channels[broadcasterUserID] = state
+}
+
+// update applies event to the state and reports whether it was a stateful
+// event.
+func (s *ChannelState) update(event any) bool {
+	switch event := event.(type) {
+	case EventStreamOnline:
+		s.Online = true
+		s.Stream = &event
+	case EventStreamOffline:
+		s.Online = false
+		s.Stream = nil
+	case EventChannelPollBegin:
+		s.Poll = &event
+	case EventChannelPollProgress:
+		poll := EventChannelPollBegin(event)
+		s.Poll = &poll
+	case EventChannelPollEnd:
+		s.Poll = nil
+	case EventChannelHypeTrainBegin:
+		s.HypeTrain = &event
+	case EventChannelHypeTrainProgress:
+		hypeTrain := event.EventChannelHypeTrainBegin
+		hypeTrain.Level = event.Level
+		s.HypeTrain = &hypeTrain
+	case EventChannelHypeTrainEnd:
+		s.HypeTrain = nil
+	case EventChannelShieldModeBegin:
+		s.ShieldMode = &event
+	case EventChannelShieldModeEnd:
+		s.ShieldMode = nil
+	case EventChannelModerate:
+		return s.ChatSettings.update(event)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *ChatSettings) update(event EventChannelModerate) bool {
+	switch event.Action {
+	case "followers":
+		s.FollowersOnly = event.Followers
+	case "followersoff":
+		s.FollowersOnly = nil
+	case "slow":
+		s.Slow = event.Slow
+	case "slowoff":
+		s.Slow = nil
+	case "emoteonly", "emoteonlyoff":
+		s.EmoteOnly = event.Action == "emoteonly"
+	case "subscribers", "subscribersoff":
+		s.SubscribersOnly = event.Action == "subscribers"
+	case "uniquechat", "uniquechatoff":
+		s.UniqueChat = event.Action == "uniquechat"
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package twitch_test
+
+import (
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateCache(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithStateCache())
+	assert.Empty(t, client.State())
+
+	broadcaster := twitch.Broadcaster{BroadcasterUserId: "1337", BroadcasterUserLogin: "bob"}
+	handle := func(event any) {
+		t.Helper()
+
+		data, err := twitch.EncodeNotification(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data))
+	}
+
+	var online bool
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		online = client.State()["1337"].Online
+	})
+
+	handle(twitch.EventStreamOnline{Broadcaster: broadcaster, Type: twitch.StreamTypeLive})
+	handle(twitch.EventChannelPollBegin{Broadcaster: broadcaster, Title: "best map"})
+	handle(twitch.EventChannelPollProgress{Broadcaster: broadcaster, Title: "best map", Choices: []twitch.PollChoice{{Title: "dust2"}}})
+	handle(twitch.EventChannelHypeTrainBegin{Broadcaster: broadcaster, Level: 1})
+	handle(twitch.EventChannelHypeTrainProgress{EventChannelHypeTrainBegin: twitch.EventChannelHypeTrainBegin{Broadcaster: broadcaster}, Level: 3})
+	handle(twitch.EventChannelShieldModeBegin{Broadcaster: broadcaster})
+	handle(twitch.EventChannelModerate{Broadcaster: broadcaster, Action: "slow", Slow: &twitch.SlowMode{WaitTimeSeconds: 30}})
+	handle(twitch.EventChannelModerate{Broadcaster: broadcaster, Action: "emoteonly"})
+	assert.True(t, online)
+
+	state := client.State()["1337"]
+	assert.Equal(t, "1337", state.BroadcasterUserID)
+	assert.True(t, state.Online)
+	assert.Equal(t, twitch.StreamTypeLive, state.Stream.Type)
+	assert.Equal(t, []twitch.PollChoice{{Title: "dust2"}}, state.Poll.Choices)
+	assert.Equal(t, 3, state.HypeTrain.Level)
+	assert.NotNil(t, state.ShieldMode)
+	assert.Equal(t, twitch.ChatSettings{Slow: &twitch.SlowMode{WaitTimeSeconds: 30}, EmoteOnly: true}, state.ChatSettings)
+	assert.False(t, state.UpdatedAt.IsZero())
+
+	handle(twitch.EventStreamOffline(broadcaster))
+	handle(twitch.EventChannelPollEnd{EventChannelPollBegin: twitch.EventChannelPollBegin{Broadcaster: broadcaster}})
+	handle(twitch.EventChannelHypeTrainEnd{Broadcaster: broadcaster})
+	handle(twitch.EventChannelShieldModeEnd{Broadcaster: broadcaster})
+	handle(twitch.EventChannelModerate{Broadcaster: broadcaster, Action: "slowoff"})
+
+	state = client.State()["1337"]
+	assert.False(t, state.Online)
+	assert.Nil(t, state.Stream)
+	assert.Nil(t, state.Poll)
+	assert.Nil(t, state.HypeTrain)
+	assert.Nil(t, state.ShieldMode)
+	assert.Equal(t, twitch.ChatSettings{EmoteOnly: true}, state.ChatSettings)
+}
+
+func TestStateWithoutCache(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, twitch.NewClient().State())
+}
@@ -0,0 +1,134 @@
+package twitch
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of what a client has received since it was created.
+type Stats struct {
+	// Messages counts received messages by message type
+	Messages map[string]int
+	// Events counts decoded notification events by subscription type
+	Events map[EventSubscription]int
+	// DecodeErrors counts messages and events that could not be decoded
+	DecodeErrors int
+	// Errors counts the errors of handling read messages, whatever the
+	// error policy did with them
+	Errors int
+	// Reconnects counts session_reconnect handovers and reconnects done by Run
+	Reconnects int
+	// LastMessage is when the last message was read, zero if none was
+	LastMessage time.Time
+	// QueueDepth is the number of events waiting in dispatch queues
+	QueueDepth int
+
+	// Latency is the time from a message's message_timestamp until it was
+	// read, for the last message read from the connection. It includes the
+	// skew between Twitch's clock and the local one, so it can be negative.
+	Latency time.Duration
+	// AverageLatency and MaxLatency are over every message read
+	AverageLatency time.Duration
+	MaxLatency     time.Duration
+}
+
+type statsCounter struct {
+	mu           sync.Mutex
+	messages     map[string]int
+	events       map[EventSubscription]int
+	decodeErrors int
+	errors       int
+	reconnects   int
+
+	lastLatency    time.Duration
+	maxLatency     time.Duration
+	totalLatency   time.Duration
+	latencySamples int
+}
+
+func (s *statsCounter) message(messageType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.messages == nil {
+		s.messages = map[string]int{}
+	}
+	s.messages[messageType]++
+}
+
+func (s *statsCounter) event(eventType EventSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.events == nil {
+		s.events = map[EventSubscription]int{}
+	}
+	s.events[eventType]++
+}
+
+func (s *statsCounter) decodeError() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decodeErrors++
+}
+
+func (s *statsCounter) error() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors++
+}
+
+func (s *statsCounter) reconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reconnects++
+}
+
+func (s *statsCounter) latency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.latencySamples == 0 || latency > s.maxLatency {
+		s.maxLatency = latency
+	}
+	s.lastLatency = latency
+	s.totalLatency += latency
+	s.latencySamples++
+}
+
+// Stats returns a snapshot of the client's message counters, safe to call
+// while the client is running.
+func (c *Client) Stats() Stats {
+	c.stats.mu.Lock()
+	stats := Stats{
+		Messages:     make(map[string]int, len(c.stats.messages)),
+		Events:       make(map[EventSubscription]int, len(c.stats.events)),
+		DecodeErrors: c.stats.decodeErrors,
+		Errors:       c.stats.errors,
+		Reconnects:   c.stats.reconnects,
+		Latency:      c.stats.lastLatency,
+		MaxLatency:   c.stats.maxLatency,
+	}
+	if c.stats.latencySamples > 0 {
+		stats.AverageLatency = c.stats.totalLatency / time.Duration(c.stats.latencySamples)
+	}
+	for messageType, count := range c.stats.messages {
+		stats.Messages[messageType] = count
+	}
+	for eventType, count := range c.stats.events {
+		stats.Events[eventType] = count
+	}
+	c.stats.mu.Unlock()
+
+	if lastMessage := c.lastMessage.Load(); lastMessage != 0 {
+		stats.LastMessage = time.Unix(0, lastMessage)
+	}
+
+	c.queuesMu.Lock()
+	for _, queue := range c.queues {
+		stats.QueueDepth += queue.len()
+	}
+	c.queuesMu.Unlock()
+
+	return stats
+}
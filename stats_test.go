@@ -0,0 +1,50 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithDispatchQueue(10, twitch.BackpressureBlock))
+
+	release := make(chan struct{})
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		<-release
+	})
+	defer close(release)
+
+	messages, _, err := getTestEventData(twitch.SubStreamOnline)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid twitch.NotificationMessage
+	json.Unmarshal(messages[0], &invalid)
+	event := json.RawMessage(`{"broadcaster_user_id":1}`)
+	invalid.Payload.Event = &event
+	invalidEvent, err := json.Marshal(invalid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range [][]byte{messages[0], messages[0], invalidEvent, []byte(`{`)} {
+		client.HandleMessage(data)
+	}
+
+	stats := client.Stats()
+	assert.Equal(t, map[string]int{"notification": 3}, stats.Messages)
+	assert.Equal(t, map[twitch.EventSubscription]int{twitch.SubStreamOnline: 2}, stats.Events)
+	assert.Equal(t, 2, stats.DecodeErrors)
+	assert.Equal(t, 0, stats.Reconnects)
+	assert.True(t, stats.LastMessage.IsZero())
+	// The listener holds the first event so the second one waits in the queue
+	assert.Eventually(t, func() bool {
+		return client.Stats().QueueDepth == 1
+	}, time.Second, time.Millisecond)
+}
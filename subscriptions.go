@@ -7,274 +7,134 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"sort"
+
+	"golang.org/x/oauth2"
 )
 
 const twitchEventSubUrl = "https://api.twitch.tv/helix/eventsub/subscriptions"
 
-type EventSubscription string
-
-var (
-	SubChannelUpdate EventSubscription = "channel.update"
-	SubChannelFollow EventSubscription = "channel.follow"
-
-	SubChannelSubscribe           EventSubscription = "channel.subscribe"
-	SubChannelSubscriptionEnd     EventSubscription = "channel.subscription.end"
-	SubChannelSubscriptionGift    EventSubscription = "channel.subscription.gift"
-	SubChannelSubscriptionMessage EventSubscription = "channel.subscription.message"
-
-	SubChannelCheer EventSubscription = "channel.cheer"
-	SubChannelRaid  EventSubscription = "channel.raid"
-	SubChannelBan   EventSubscription = "channel.ban"
-	SubChannelUnban EventSubscription = "channel.unban"
-
-	SubChannelModeratorAdd    EventSubscription = "channel.moderator.add"
-	SubChannelModeratorRemove EventSubscription = "channel.moderator.remove"
-
-	SubChannelChannelPointsCustomRewardAdd              EventSubscription = "channel.channel_points_custom_reward.add"
-	SubChannelChannelPointsCustomRewardUpdate           EventSubscription = "channel.channel_points_custom_reward.update"
-	SubChannelChannelPointsCustomRewardRemove           EventSubscription = "channel.channel_points_custom_reward.remove"
-	SubChannelChannelPointsCustomRewardRedemptionAdd    EventSubscription = "channel.channel_points_custom_reward_redemption.add"
-	SubChannelChannelPointsCustomRewardRedemptionUpdate EventSubscription = "channel.channel_points_custom_reward_redemption.update"
-
-	SubChannelPollBegin    EventSubscription = "channel.poll.begin"
-	SubChannelPollProgress EventSubscription = "channel.poll.progress"
-	SubChannelPollEnd      EventSubscription = "channel.poll.end"
-
-	SubChannelPredictionBegin    EventSubscription = "channel.prediction.begin"
-	SubChannelPredictionProgress EventSubscription = "channel.prediction.progress"
-	SubChannelPredictionLock     EventSubscription = "channel.prediction.lock"
-	SubChannelPredictionEnd      EventSubscription = "channel.prediction.end"
+//go:generate go run ./internal/generate
 
-	SubDropEntitlementGrant           EventSubscription = "drop.entitlement.grant"
-	SubExtensionBitsTransactionCreate EventSubscription = "extension.bits_transaction.create"
-
-	SubChannelGoalBegin    EventSubscription = "channel.goal.begin"
-	SubChannelGoalProgress EventSubscription = "channel.goal.progress"
-	SubChannelGoalEnd      EventSubscription = "channel.goal.end"
-
-	SubChannelHypeTrainBegin    EventSubscription = "channel.hype_train.begin"
-	SubChannelHypeTrainProgress EventSubscription = "channel.hype_train.progress"
-	SubChannelHypeTrainEnd      EventSubscription = "channel.hype_train.end"
+type EventSubscription string
 
-	SubStreamOnline  EventSubscription = "stream.online"
-	SubStreamOffline EventSubscription = "stream.offline"
+// SubscriptionTypes returns every subscription type the package decodes,
+// sorted by name.
+func SubscriptionTypes() []EventSubscription {
+	subTypes := make([]EventSubscription, 0, len(subMetadata))
+	for subType := range subMetadata {
+		subTypes = append(subTypes, subType)
+	}
+	sort.Slice(subTypes, func(i, j int) bool { return subTypes[i] < subTypes[j] })
+	return subTypes
+}
 
-	SubUserAuthorizationGrant  EventSubscription = "user.authorization.grant"
-	SubUserAuthorizationRevoke EventSubscription = "user.authorization.revoke"
-	SubUserUpdate              EventSubscription = "user.update"
+type subscriptionMetadata struct {
+	Version string
+	// Condition lists the required condition keys, with alternatives of
+	// which one is needed separated by |
+	Condition []string
+	// Scopes lists the scopes a token needs, with alternatives of which one
+	// is needed separated by |
+	Scopes []string
+	// Type is the Event type the subscription type decodes into
+	Type   reflect.Type
+	Decode func(data []byte, decode Decoder) (any, error)
+	// Handler returns the client's callback for the event, or nil if none is set
+	Handler func(c *Client) func(event any)
+	// Upgrade converts an event of a version other than the default one into
+	// the Event type of the default version. It is nil for default versions.
+	Upgrade func(event any) any
+}
 
-	SubChannelCharityCampaignDonate   EventSubscription = "channel.charity_campaign.donate"
-	SubChannelCharityCampaignStart    EventSubscription = "channel.charity_campaign.start"
-	SubChannelCharityCampaignProgress EventSubscription = "channel.charity_campaign.progress"
-	SubChannelCharityCampaignStop     EventSubscription = "channel.charity_campaign.stop"
+func newSubscriptionMetadata[T any](version string, condition, scopes []string, handler func(h *eventHandlers) func(T)) subscriptionMetadata {
+	return subscriptionMetadata{
+		Version:   version,
+		Condition: condition,
+		Scopes:    scopes,
+		Type:      reflect.TypeOf((*T)(nil)).Elem(),
+		Decode: func(data []byte, decode Decoder) (any, error) {
+			var event T
+			err := decode(data, &event)
+			if err != nil {
+				return nil, fmt.Errorf("could not unmarshal into %T: %w", event, err)
+			}
+			return event, nil
+		},
+		Handler: func(c *Client) func(event any) {
+			callback := handler(&c.eventHandlers)
+			if callback == nil {
+				return nil
+			}
+			return func(event any) { callback(event.(T)) }
+		},
+	}
+}
 
-	SubChannelShieldModeBegin EventSubscription = "channel.shield_mode.begin"
-	SubChannelShieldModeEnd   EventSubscription = "channel.shield_mode.end"
+// newVersionMetadata is the metadata of a subscription version other than
+// the default one. Its events reach listeners as their own Event type, and
+// the OnEvent callbacks of the default version after upgrade converts them.
+func newVersionMetadata[T, D any](version string, condition, scopes []string, handler func(h *eventHandlers) func(D), upgrade func(T) D) subscriptionMetadata {
+	metadata := newSubscriptionMetadata(version, condition, scopes, func(h *eventHandlers) func(T) {
+		callback := handler(h)
+		if callback == nil {
+			return nil
+		}
+		return func(event T) { callback(upgrade(event)) }
+	})
+	metadata.Upgrade = func(event any) any { return upgrade(event.(T)) }
+	return metadata
+}
 
-	SubChannelShoutoutCreate  EventSubscription = "channel.shoutout.create"
-	SubChannelShoutoutReceive EventSubscription = "channel.shoutout.receive"
+// subscriptionVersion returns the metadata of version of a subscription
+// type, falling back to the default version for versions without their own
+// Event type.
+func subscriptionVersion(subType EventSubscription, version string) (subscriptionMetadata, bool) {
+	if metadata, ok := subVersions[subType][version]; ok {
+		return metadata, true
+	}
+	metadata, ok := subMetadata[subType]
+	return metadata, ok
+}
 
-	SubChannelModerate EventSubscription = "channel.moderate"
+// SubscriptionVersions returns the versions of a subscription type that
+// decode into their own Event type, starting with the default version.
+func SubscriptionVersions(subType EventSubscription) []string {
+	metadata, ok := subMetadata[subType]
+	if !ok {
+		return nil
+	}
 
-	subMetadata = map[EventSubscription]subscriptionMetadata{
-		SubChannelUpdate: {
-			Version:  "2",
-			EventGen: zeroPtrGen[EventChannelUpdate](),
-		},
-		SubChannelFollow: {
-			Version:  "2",
-			EventGen: zeroPtrGen[EventChannelFollow](),
-		},
-		SubChannelSubscribe: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscribe](),
-		},
-		SubChannelSubscriptionEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscriptionEnd](),
-		},
-		SubChannelSubscriptionGift: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscriptionGift](),
-		},
-		SubChannelSubscriptionMessage: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelSubscriptionMessage](),
-		},
-		SubChannelCheer: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCheer](),
-		},
-		SubChannelRaid: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelRaid](),
-		},
-		SubChannelBan: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelBan](),
-		},
-		SubChannelUnban: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelUnban](),
-		},
-		SubChannelModeratorAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelModeratorAdd](),
-		},
-		SubChannelModeratorRemove: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelModeratorRemove](),
-		},
-		SubChannelChannelPointsCustomRewardAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardAdd](),
-		},
-		SubChannelChannelPointsCustomRewardUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardUpdate](),
-		},
-		SubChannelChannelPointsCustomRewardRemove: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardRemove](),
-		},
-		SubChannelChannelPointsCustomRewardRedemptionAdd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardRedemptionAdd](),
-		},
-		SubChannelChannelPointsCustomRewardRedemptionUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelChannelPointsCustomRewardRedemptionUpdate](),
-		},
-		SubChannelPollBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPollBegin](),
-		},
-		SubChannelPollProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPollProgress](),
-		},
-		SubChannelPollEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPollEnd](),
-		},
-		SubChannelPredictionBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionBegin](),
-		},
-		SubChannelPredictionProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionProgress](),
-		},
-		SubChannelPredictionLock: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionLock](),
-		},
-		SubChannelPredictionEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelPredictionEnd](),
-		},
-		SubDropEntitlementGrant: {
-			Version:  "1",
-			EventGen: zeroPtrGen[[]EventDropEntitlementGrant](), //func() any { return &[]EventDropEntitlementGrant{} },
-		},
-		SubExtensionBitsTransactionCreate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventExtensionBitsTransactionCreate](),
-		},
-		SubChannelGoalBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelGoalBegin](),
-		},
-		SubChannelGoalProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelGoalProgress](),
-		},
-		SubChannelGoalEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelGoalEnd](),
-		},
-		SubChannelHypeTrainBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelHypeTrainBegin](),
-		},
-		SubChannelHypeTrainProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelHypeTrainProgress](),
-		},
-		SubChannelHypeTrainEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelHypeTrainEnd](),
-		},
-		SubStreamOnline: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventStreamOnline](),
-		},
-		SubStreamOffline: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventStreamOffline](),
-		},
-		SubUserAuthorizationGrant: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserAuthorizationGrant](),
-		},
-		SubUserAuthorizationRevoke: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserAuthorizationRevoke](),
-		},
-		SubUserUpdate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventUserUpdate](),
-		},
-		SubChannelCharityCampaignDonate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignDonate](),
-		},
-		SubChannelCharityCampaignStart: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignStart](),
-		},
-		SubChannelCharityCampaignProgress: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignProgress](),
-		},
-		SubChannelCharityCampaignStop: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelCharityCampaignStop](),
-		},
-		SubChannelShieldModeBegin: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShieldModeBegin](),
-		},
-		SubChannelShieldModeEnd: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShieldModeEnd](),
-		},
-		SubChannelShoutoutCreate: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShoutoutCreate](),
-		},
-		SubChannelShoutoutReceive: {
-			Version:  "1",
-			EventGen: zeroPtrGen[EventChannelShoutoutReceive](),
-		},
-		SubChannelModerate: {
-			Version:  "2",
-			EventGen: zeroPtrGen[EventChannelModerate](),
-		},
+	versions := []string{metadata.Version}
+	for version := range subVersions[subType] {
+		versions = append(versions, version)
 	}
-)
+	sort.Strings(versions[1:])
+	return versions
+}
 
-type subscriptionMetadata struct {
-	Version  string
-	EventGen func() interface{}
+// TokenSource supplies access tokens for Helix requests. Any
+// golang.org/x/oauth2 TokenSource satisfies it, so expiring user tokens
+// are refreshed before each request instead of failing mid-run.
+type TokenSource interface {
+	Token() (*oauth2.Token, error)
 }
 
 type SubscribeRequest struct {
 	SessionID       string
 	ClientID        string
 	AccessToken     string
+	TokenSource     TokenSource
 	VersionOverride string
 
+	// Transport overrides the default websocket transport using SessionID,
+	// for example to create webhook subscriptions.
+	Transport *SubscriptionTransport
+	// IsBatchingEnabled lets Twitch send several events in one notification.
+	// Only drop.entitlement.grant supports it.
+	IsBatchingEnabled bool
+
 	Event     EventSubscription
 	Condition map[string]string
 }
@@ -304,14 +164,27 @@ func SubscribeEventUrlWithContext(ctx context.Context, request SubscribeRequest,
 		version = request.VersionOverride
 	}
 
+	if version == subMetadata[request.Event].Version {
+		err := ValidateCondition(request.Event, request.Condition)
+		if err != nil {
+			return SubscribeResponse{}, err
+		}
+	}
+
+	transport := SubscriptionTransport{
+		Method:    "websocket",
+		SessionID: request.SessionID,
+	}
+	if request.Transport != nil {
+		transport = *request.Transport
+	}
+
 	b, err := json.Marshal(SubscriptionRequest{
-		Type:      request.Event,
-		Version:   version,
-		Condition: request.Condition,
-		Transport: SubscriptionTransport{
-			Method:    "websocket",
-			SessionID: request.SessionID,
-		},
+		Type:              request.Event,
+		Version:           version,
+		Condition:         request.Condition,
+		Transport:         transport,
+		IsBatchingEnabled: request.IsBatchingEnabled,
 	})
 	if err != nil {
 		return SubscribeResponse{}, fmt.Errorf("could not convert request to json: %w", err)
@@ -323,8 +196,13 @@ func SubscribeEventUrlWithContext(ctx context.Context, request SubscribeRequest,
 		return SubscribeResponse{}, fmt.Errorf("could not create new request: %w", err)
 	}
 
+	accessToken, err := request.accessToken()
+	if err != nil {
+		return SubscribeResponse{}, err
+	}
+
 	req.Header.Set("Client-Id", request.ClientID)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", request.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := http.DefaultClient.Do(req)
@@ -347,3 +225,161 @@ func SubscribeEventUrlWithContext(ctx context.Context, request SubscribeRequest,
 
 	return subscription, nil
 }
+
+func (r SubscribeRequest) accessToken() (string, error) {
+	return helixAccessToken(r.AccessToken, r.TokenSource)
+}
+
+func helixAccessToken(accessToken string, source TokenSource) (string, error) {
+	if source == nil {
+		return accessToken, nil
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		return "", fmt.Errorf("could not get access token: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+type DeleteSubscriptionRequest struct {
+	ClientID    string
+	AccessToken string
+	TokenSource TokenSource
+
+	ID string
+}
+
+func DeleteSubscription(request DeleteSubscriptionRequest) error {
+	return DeleteSubscriptionUrlWithContext(context.Background(), request, twitchEventSubUrl)
+}
+
+func DeleteSubscriptionUrl(request DeleteSubscriptionRequest, url string) error {
+	return DeleteSubscriptionUrlWithContext(context.Background(), request, url)
+}
+
+func DeleteSubscriptionWithContext(ctx context.Context, request DeleteSubscriptionRequest) error {
+	return DeleteSubscriptionUrlWithContext(ctx, request, twitchEventSubUrl)
+}
+
+func DeleteSubscriptionUrlWithContext(ctx context.Context, request DeleteSubscriptionRequest, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("could not create new request: %w", err)
+	}
+
+	query := req.URL.Query()
+	query.Set("id", request.ID)
+	req.URL.RawQuery = query.Encode()
+
+	accessToken, err := helixAccessToken(request.AccessToken, request.TokenSource)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Client-Id", request.ClientID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("could not delete subscription: %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// ListSubscriptionsRequest filters the subscriptions returned by
+// ListSubscriptions. At most one of Status, Type, and UserID can be set.
+type ListSubscriptionsRequest struct {
+	ClientID    string
+	AccessToken string
+	TokenSource TokenSource
+
+	Status SubscriptionStatus
+	Type   EventSubscription
+	UserID string
+}
+
+type listSubscriptionsResponse struct {
+	Data       []PayloadSubscription `json:"data"`
+	Pagination struct {
+		Cursor string `json:"cursor"`
+	} `json:"pagination"`
+}
+
+func ListSubscriptions(request ListSubscriptionsRequest) ([]PayloadSubscription, error) {
+	return ListSubscriptionsUrlWithContext(context.Background(), request, twitchEventSubUrl)
+}
+
+func ListSubscriptionsUrl(request ListSubscriptionsRequest, url string) ([]PayloadSubscription, error) {
+	return ListSubscriptionsUrlWithContext(context.Background(), request, url)
+}
+
+func ListSubscriptionsWithContext(ctx context.Context, request ListSubscriptionsRequest) ([]PayloadSubscription, error) {
+	return ListSubscriptionsUrlWithContext(ctx, request, twitchEventSubUrl)
+}
+
+// ListSubscriptionsUrlWithContext returns every subscription matching the
+// request, following pagination.
+func ListSubscriptionsUrlWithContext(ctx context.Context, request ListSubscriptionsRequest, url string) ([]PayloadSubscription, error) {
+	var subscriptions []PayloadSubscription
+	cursor := ""
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not create new request: %w", err)
+		}
+
+		query := req.URL.Query()
+		if request.Status != "" {
+			query.Set("status", string(request.Status))
+		}
+		if request.Type != "" {
+			query.Set("type", string(request.Type))
+		}
+		if request.UserID != "" {
+			query.Set("user_id", request.UserID)
+		}
+		if cursor != "" {
+			query.Set("after", cursor)
+		}
+		req.URL.RawQuery = query.Encode()
+
+		accessToken, err := helixAccessToken(request.AccessToken, request.TokenSource)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Client-Id", request.ClientID)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("could not list subscriptions: %w", err)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("could not list subscriptions: %s: %s", resp.Status, string(body))
+		}
+
+		var page listSubscriptionsResponse
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return nil, fmt.Errorf("could not unmarshal subscriptions response: %w", err)
+		}
+
+		subscriptions = append(subscriptions, page.Data...)
+		cursor = page.Pagination.Cursor
+		if cursor == "" {
+			return subscriptions, nil
+		}
+	}
+}
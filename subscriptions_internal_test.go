@@ -0,0 +1,46 @@
+package twitch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionRegistry(t *testing.T) {
+	data, err := os.ReadFile("testEvents.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events map[string]json.RawMessage
+	err = json.Unmarshal(data, &events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for subType, metadata := range subMetadata {
+		t.Run(string(subType), func(t *testing.T) {
+			eventData, ok := events[string(subType)]
+			if !ok {
+				t.Fatalf("no test event for %s", subType)
+			}
+
+			event, err := metadata.Decode(eventData, defaultDecoder)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if events, ok := event.([]EventDropEntitlementGrant); ok {
+				event = events[0]
+			}
+			if assert.Implements(t, (*fmt.Stringer)(nil), event) {
+				assert.NotContains(t, event.(fmt.Stringer).String(), "%!")
+			}
+
+			assert.Nil(t, metadata.Handler(&Client{}))
+		})
+	}
+}
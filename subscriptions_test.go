@@ -6,9 +6,13 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"sort"
 	"testing"
 
 	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
 )
 
 func TestEventVersion(t *testing.T) {
@@ -55,8 +59,120 @@ func TestEventVersion(t *testing.T) {
 				twitch.SubscribeEventUrl(twitch.SubscribeRequest{
 					Event:           twitch.SubChannelUpdate,
 					VersionOverride: tc.Version,
+					Condition:       testCondition,
 				}, fmt.Sprintf("http://%s", listener.Addr().String()))
 			})
 		})
 	}
 }
+
+func TestTokenSource(t *testing.T) {
+	assertEventOccured(t, func(ch chan struct{}) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Error(err)
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer refreshed" {
+				t.Errorf("token source was not used: %s", r.Header.Get("Authorization"))
+			}
+
+			close(ch)
+		})
+
+		go http.Serve(listener, mux)
+
+		twitch.SubscribeEventUrl(twitch.SubscribeRequest{
+			Event:       twitch.SubChannelUpdate,
+			Condition:   testCondition,
+			AccessToken: "stale",
+			TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "refreshed"}),
+		}, fmt.Sprintf("http://%s", listener.Addr().String()))
+	})
+}
+
+func TestSubscriptionTypes(t *testing.T) {
+	subTypes := twitch.SubscriptionTypes()
+	if !sort.SliceIsSorted(subTypes, func(i, j int) bool { return subTypes[i] < subTypes[j] }) {
+		t.Error("subscription types are not sorted")
+	}
+
+	for _, subType := range []twitch.EventSubscription{twitch.SubChannelFollow, twitch.SubStreamOnline} {
+		i := sort.Search(len(subTypes), func(i int) bool { return subTypes[i] >= subType })
+		if i == len(subTypes) || subTypes[i] != subType {
+			t.Errorf("%s is missing", subType)
+		}
+	}
+}
+
+func TestSubscribeBatching(t *testing.T) {
+	t.Parallel()
+
+	var subscription twitch.SubscriptionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&subscription)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	_, err := twitch.SubscribeEventUrl(twitch.SubscribeRequest{
+		Event:             twitch.SubDropEntitlementGrant,
+		Condition:         map[string]string{"organization_id": "9001"},
+		IsBatchingEnabled: true,
+	}, server.URL)
+	assert.NoError(t, err)
+	assert.True(t, subscription.IsBatchingEnabled)
+}
+
+func TestSubscriptionVersions(t *testing.T) {
+	assert.Equal(t, []string{"2", "1"}, twitch.SubscriptionVersions(twitch.SubChannelUpdate))
+	assert.Equal(t, []string{"1"}, twitch.SubscriptionVersions(twitch.SubStreamOnline))
+	assert.Nil(t, twitch.SubscriptionVersions("unknown"))
+}
+
+func TestWithSubscriptionVersion(t *testing.T) {
+	t.Parallel()
+
+	var versions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var subscription twitch.SubscriptionRequest
+		json.NewDecoder(r.Body).Decode(&subscription)
+		versions = append(versions, subscription.Version)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithSubscriptionVersion(twitch.SubChannelUpdate, "1"))
+	client.SubscriptionUrl = server.URL
+
+	for _, request := range []twitch.SubscribeRequest{
+		{Event: twitch.SubChannelUpdate, Condition: testCondition},
+		{Event: twitch.SubChannelUpdate, Condition: testCondition, VersionOverride: "2"},
+		{Event: twitch.SubStreamOnline, Condition: testCondition},
+	} {
+		_, err := client.Subscribe(request)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"1", "2", "1"}, versions)
+
+	var events []any
+	client.AddListener(func(message twitch.NotificationMessage, event any) {
+		events = append(events, event)
+	})
+	var updates []twitch.EventChannelUpdate
+	client.OnEventChannelUpdate(func(event twitch.EventChannelUpdate) {
+		updates = append(updates, event)
+	})
+
+	data, err := twitch.EncodeNotification(twitch.EventChannelUpdateV1{Title: "chill", IsMature: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, client.HandleMessage(data))
+	assert.Equal(t, []any{twitch.EventChannelUpdateV1{Title: "chill", IsMature: true}}, events)
+	assert.Equal(t, []twitch.EventChannelUpdate{{Title: "chill"}}, updates)
+}
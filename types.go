@@ -21,23 +21,26 @@ type PayloadSession struct {
 
 type SubscriptionTransport struct {
 	Method    string `json:"method"`
-	SessionID string `json:"session_id"`
+	SessionID string `json:"session_id,omitempty"`
+	Callback  string `json:"callback,omitempty"`
+	Secret    string `json:"secret,omitempty"`
 }
 
 type SubscriptionRequest struct {
-	Type      EventSubscription     `json:"type"`
-	Version   string                `json:"version"`
-	Condition map[string]string     `json:"condition"`
-	Transport SubscriptionTransport `json:"transport"`
+	Type              EventSubscription     `json:"type"`
+	Version           string                `json:"version"`
+	Condition         map[string]string     `json:"condition"`
+	Transport         SubscriptionTransport `json:"transport"`
+	IsBatchingEnabled bool                  `json:"is_batching_enabled,omitempty"`
 }
 
 type PayloadSubscription struct {
 	SubscriptionRequest
 
-	ID       string    `json:"id"`
-	Status   string    `json:"status"`
-	Cost     int       `json:"cost"`
-	CreateAt time.Time `json:"created_at"`
+	ID       string             `json:"id"`
+	Status   SubscriptionStatus `json:"status"`
+	Cost     int                `json:"cost"`
+	CreateAt time.Time          `json:"created_at"`
 }
 
 type WelcomeMessage struct {
@@ -57,9 +60,21 @@ type NotificationMessage struct {
 	Payload  struct {
 		Subscription PayloadSubscription `json:"subscription"`
 		Event        *json.RawMessage    `json:"event"`
+		// Events holds the event array of batched subscription types like
+		// drop.entitlement.grant, which have no event.
+		Events *json.RawMessage `json:"events,omitempty"`
 	} `json:"payload"`
 }
 
+// RawEvent returns the event of the notification, or the event array of a
+// batched notification.
+func (m NotificationMessage) RawEvent() *json.RawMessage {
+	if m.Payload.Event == nil {
+		return m.Payload.Events
+	}
+	return m.Payload.Event
+}
+
 type ReconnectMessage struct {
 	Metadata MessageMetadata `json:"metadata"`
 	Payload  struct {
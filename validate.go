@@ -0,0 +1,95 @@
+package twitch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEvent = fmt.Errorf("invalid event")
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	broadcasterIDKeys = map[string]bool{
+		"broadcaster_user_id":      true,
+		"from_broadcaster_user_id": true,
+		"to_broadcaster_user_id":   true,
+	}
+)
+
+// validateEvent checks the decoded event for signs of a mangled payload:
+// empty broadcaster IDs, timestamps that decoded to the zero time, and bit
+// amounts that are not positive.
+func validateEvent(data []byte, event any) error {
+	v := reflect.Indirect(reflect.ValueOf(event))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	var problems []string
+	for name, field := range jsonValues(v) {
+		switch {
+		case broadcasterIDKeys[name] && field.Kind() == reflect.String:
+			if field.String() == "" {
+				problems = append(problems, fmt.Sprintf("%s is empty", name))
+			}
+		case field.Type() == timeType:
+			value, ok := raw[name]
+			if ok && string(value) != "null" && field.Interface().(time.Time).IsZero() {
+				problems = append(problems, fmt.Sprintf("%s is not a valid timestamp", name))
+			}
+		case name == "bits" && field.Kind() == reflect.Int:
+			if field.Int() <= 0 {
+				problems = append(problems, fmt.Sprintf("bits is %d", field.Int()))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w: %s", ErrInvalidEvent, strings.Join(problems, ", "))
+}
+
+// jsonValues maps the json names of a struct value's fields, including the
+// ones promoted from embedded structs, to their values.
+func jsonValues(v reflect.Value) map[string]reflect.Value {
+	values := map[string]reflect.Value{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedValue := range jsonValues(v.Field(i)) {
+				values[embeddedName] = embeddedValue
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		values[name] = v.Field(i)
+	}
+	return values
+}
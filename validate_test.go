@@ -0,0 +1,109 @@
+package twitch_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnInvalidEventFixtures(t *testing.T) {
+	t.Parallel()
+
+	// Charity campaign payloads other than donate name the broadcaster
+	// broadcaster_id, which BaseCharity does not map yet
+	known := map[twitch.EventSubscription]bool{
+		twitch.SubChannelCharityCampaignStart:    true,
+		twitch.SubChannelCharityCampaignProgress: true,
+		twitch.SubChannelCharityCampaignStop:     true,
+	}
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	// The test events are version 1, which was removed for some types
+	client.OnDeprecation(func(deprecation twitch.Deprecation) {})
+	client.OnInvalidEvent(func(raw string, err error) {
+		t.Errorf("fixture was invalid: %v", err)
+	})
+
+	var events map[string]json.RawMessage
+	err := json.Unmarshal(testEvents, &events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := map[twitch.EventSubscription]bool{}
+	for _, subType := range twitch.SubscriptionTypes() {
+		types[subType] = !known[subType]
+	}
+
+	for key := range events {
+		parts := strings.Split(key, "-")
+		if !types[twitch.EventSubscription(parts[0])] {
+			continue
+		}
+
+		data, _, err := getTestEventData(twitch.EventSubscription(parts[0]), parts[1:]...)()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, client.HandleMessage(data[0]))
+	}
+}
+
+func TestOnInvalidEvent(t *testing.T) {
+	t.Parallel()
+
+	var message twitch.NotificationMessage
+	message.Metadata.MessageType = "notification"
+	message.Payload.Subscription.Type = twitch.SubChannelCheer
+	event := json.RawMessage(`{"broadcaster_user_id":"","bits":0,"message":"cheer0"}`)
+	message.Payload.Event = &event
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnEventChannelCheer(func(event twitch.EventChannelCheer) {
+		t.Error("invalid event was delivered")
+	})
+
+	var invalid []error
+	var raws []string
+	client.OnInvalidEvent(func(raw string, err error) {
+		raws = append(raws, raw)
+		invalid = append(invalid, err)
+	})
+
+	assert.NoError(t, client.HandleMessage(data))
+	if assert.Len(t, invalid, 1) {
+		assert.ErrorIs(t, invalid[0], twitch.ErrInvalidEvent)
+		assert.EqualError(t, invalid[0], "channel.cheer: invalid event: bits is 0, broadcaster_user_id is empty")
+		assert.JSONEq(t, string(event), raws[0])
+	}
+}
+
+func TestOnInvalidEventTimestamp(t *testing.T) {
+	t.Parallel()
+
+	var message twitch.NotificationMessage
+	message.Metadata.MessageType = "notification"
+	message.Payload.Subscription.Type = twitch.SubStreamOnline
+	event := json.RawMessage(`{"broadcaster_user_id":"1337","started_at":"0001-01-01T00:00:00Z"}`)
+	message.Payload.Event = &event
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	var invalid error
+	client.OnInvalidEvent(func(raw string, err error) {
+		invalid = err
+	})
+
+	assert.NoError(t, client.HandleMessage(data))
+	assert.EqualError(t, invalid, "stream.online: invalid event: started_at is not a valid timestamp")
+}
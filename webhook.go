@@ -0,0 +1,416 @@
+package twitch
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	webhookHeaderMessageID        = "Twitch-Eventsub-Message-Id"
+	webhookHeaderMessageType      = "Twitch-Eventsub-Message-Type"
+	webhookHeaderMessageSignature = "Twitch-Eventsub-Message-Signature"
+	webhookHeaderMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+
+	webhookSignaturePrefix = "sha256="
+)
+
+const (
+	webhookMaxAge       = 10 * time.Minute
+	webhookReplayWindow = 10 * time.Minute
+	webhookPruneEvery   = time.Minute
+	webhookMaxBodySize  = 512 * 1024
+)
+
+var (
+	ErrInvalidSignature = fmt.Errorf("invalid webhook message signature")
+	ErrStaleMessage     = fmt.Errorf("webhook message is too old")
+)
+
+// WebhookReplayStore persists the IDs of the messages a WebhookHandler has
+// seen with their timestamps, so replays are also rejected after a restart.
+type WebhookReplayStore interface {
+	// Load returns the saved IDs, or nil if nothing was saved.
+	Load() (map[string]time.Time, error)
+	// Add saves a newly seen ID.
+	Add(id string, timestamp time.Time) error
+	// Remove deletes an ID whose message failed to be handled.
+	Remove(id string) error
+	// Prune deletes the IDs with timestamps before the given time.
+	Prune(before time.Time) error
+}
+
+// WebhookHandler receives EventSub messages over the webhook transport and
+// dispatches them through the callbacks registered on a client, so the same
+// handler code works for both transports.
+//
+// Messages are accepted when signed with any active secret, so during a
+// secret rotation both the old and the new secret can be active until every
+// subscription uses the new one.
+//
+// Messages older than MaxAge are rejected, and messages with an ID seen within
+// ReplayWindow are acknowledged without being dispatched again.
+type WebhookHandler struct {
+	// MaxAge defaults to 10 minutes as recommended by Twitch. Zero accepts
+	// messages of any age.
+	MaxAge time.Duration
+	// ReplayWindow is how long message IDs are remembered and defaults to 10
+	// minutes. Zero turns off deduplication.
+	ReplayWindow time.Duration
+	// ReplayStore optionally persists the remembered message IDs.
+	ReplayStore WebhookReplayStore
+	// MaxBodySize limits the bytes read of a request before its signature is
+	// checked and defaults to 512 KiB. Larger requests get 413.
+	MaxBodySize int64
+
+	client         *Client
+	onVerification func(subscription PayloadSubscription)
+
+	mu      sync.RWMutex
+	secrets [][]byte
+
+	seenMu      sync.Mutex
+	seen        map[string]time.Time
+	seenPruned  time.Time
+	seenLoad    sync.Once
+	seenLoadErr error
+}
+
+func NewWebhookHandler(client *Client, secret string) *WebhookHandler {
+	return &WebhookHandler{
+		MaxAge:       webhookMaxAge,
+		ReplayWindow: webhookReplayWindow,
+		MaxBodySize:  webhookMaxBodySize,
+		client:       client,
+		secrets:      [][]byte{[]byte(secret)},
+	}
+}
+
+// OnVerification is called after the handler answered the verification
+// challenge of a subscription, which becomes enabled once Twitch receives the
+// answer. Revocations go to the client's OnRevoke and OnRevocation callbacks.
+func (h *WebhookHandler) OnVerification(callback func(subscription PayloadSubscription)) {
+	h.onVerification = callback
+}
+
+// Secret returns the primary secret, which is the one new subscriptions
+// should be created with.
+func (h *WebhookHandler) Secret() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.secrets) == 0 {
+		return ""
+	}
+	return string(h.secrets[0])
+}
+
+// Secrets returns all active secrets, starting with the primary one.
+func (h *WebhookHandler) Secrets() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	secrets := make([]string, len(h.secrets))
+	for i, secret := range h.secrets {
+		secrets[i] = string(secret)
+	}
+	return secrets
+}
+
+// AddSecret activates an additional secret without changing the primary one.
+func (h *WebhookHandler) AddSecret(secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.indexOf(secret) < 0 {
+		h.secrets = append(h.secrets, []byte(secret))
+	}
+}
+
+// PromoteSecret makes secret the primary secret, activating it if needed.
+// The previous primary secret stays active until it is retired.
+func (h *WebhookHandler) PromoteSecret(secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if i := h.indexOf(secret); i >= 0 {
+		h.secrets = append(h.secrets[:i], h.secrets[i+1:]...)
+	}
+	h.secrets = append([][]byte{[]byte(secret)}, h.secrets...)
+}
+
+// RetireSecret deactivates secret, so messages signed with it are rejected.
+func (h *WebhookHandler) RetireSecret(secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if i := h.indexOf(secret); i >= 0 {
+		h.secrets = append(h.secrets[:i], h.secrets[i+1:]...)
+	}
+}
+
+func (h *WebhookHandler) indexOf(secret string) int {
+	for i, s := range h.secrets {
+		if string(s) == secret {
+			return i
+		}
+	}
+	return -1
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.MaxBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.MaxBodySize)
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "could not read body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	err = h.verify(r.Header, body)
+	if err != nil {
+		h.client.onError(err)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	metadata, err := parseWebhookMetadata(r.Header)
+	if err != nil {
+		h.client.onError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := h.client.clock.Now()
+	if h.MaxAge > 0 && now.Sub(metadata.MessageTimestamp) > h.MaxAge {
+		err = fmt.Errorf("%s: %w", metadata.MessageID, ErrStaleMessage)
+		h.client.onError(err)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	replayed, err := h.replayed(metadata, now)
+	if err != nil {
+		h.client.onError(err)
+	}
+	if replayed {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch metadata.MessageType {
+	case "webhook_callback_verification":
+		err = h.handleVerification(w, body)
+	case "notification":
+		err = h.handleNotification(w, metadata, body)
+	case "revocation":
+		err = h.handleRevocation(w, metadata, body)
+	default:
+		err = fmt.Errorf("unknown webhook message type %s", metadata.MessageType)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+
+	if err != nil {
+		// Twitch retries failed messages with the same ID
+		h.forget(metadata)
+		h.client.onError(err)
+	}
+}
+
+func (h *WebhookHandler) verify(header http.Header, body []byte) error {
+	signature := header.Get(webhookHeaderMessageSignature)
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, secret := range h.secrets {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(header.Get(webhookHeaderMessageID)))
+		mac.Write([]byte(header.Get(webhookHeaderMessageTimestamp)))
+		mac.Write(body)
+
+		if hmac.Equal(mac.Sum(nil), expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// replayed reports whether a message with the same ID was seen within the
+// replay window, and remembers the message otherwise.
+func (h *WebhookHandler) replayed(metadata MessageMetadata, now time.Time) (bool, error) {
+	if h.ReplayWindow <= 0 {
+		return false, nil
+	}
+
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	h.seenLoad.Do(func() {
+		h.seen = map[string]time.Time{}
+		if h.ReplayStore == nil {
+			return
+		}
+
+		seen, err := h.ReplayStore.Load()
+		if err != nil {
+			h.seenLoadErr = fmt.Errorf("could not load seen webhook messages: %w", err)
+			return
+		}
+		for id, timestamp := range seen {
+			h.seen[id] = timestamp
+		}
+	})
+	if h.seenLoadErr != nil {
+		return false, h.seenLoadErr
+	}
+
+	if now.Sub(h.seenPruned) >= webhookPruneEvery {
+		h.prune(now)
+	}
+
+	// IDs are only pruned every so often, so they can be outside the window
+	if timestamp, ok := h.seen[metadata.MessageID]; ok && now.Sub(timestamp) <= h.ReplayWindow {
+		return true, nil
+	}
+	h.seen[metadata.MessageID] = metadata.MessageTimestamp
+
+	if h.ReplayStore != nil {
+		err := h.ReplayStore.Add(metadata.MessageID, metadata.MessageTimestamp)
+		if err != nil {
+			return false, fmt.Errorf("could not save seen webhook message: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// prune drops the IDs that left the replay window. It has to be called with
+// seenMu held.
+func (h *WebhookHandler) prune(now time.Time) {
+	h.seenPruned = now
+
+	before := now.Add(-h.ReplayWindow)
+	for id, timestamp := range h.seen {
+		if timestamp.Before(before) {
+			delete(h.seen, id)
+		}
+	}
+
+	if h.ReplayStore != nil {
+		err := h.ReplayStore.Prune(before)
+		if err != nil {
+			h.client.onError(fmt.Errorf("could not prune seen webhook messages: %w", err))
+		}
+	}
+}
+
+// forget removes a message remembered by replayed, so it is handled again
+// when Twitch retries it.
+func (h *WebhookHandler) forget(metadata MessageMetadata) {
+	if h.ReplayWindow <= 0 {
+		return
+	}
+
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	if _, ok := h.seen[metadata.MessageID]; !ok {
+		return
+	}
+	delete(h.seen, metadata.MessageID)
+
+	if h.ReplayStore != nil {
+		err := h.ReplayStore.Remove(metadata.MessageID)
+		if err != nil {
+			h.client.onError(fmt.Errorf("could not remove seen webhook message: %w", err))
+		}
+	}
+}
+
+func parseWebhookMetadata(header http.Header) (MessageMetadata, error) {
+	timestamp, err := time.Parse(time.RFC3339Nano, header.Get(webhookHeaderMessageTimestamp))
+	if err != nil {
+		return MessageMetadata{}, fmt.Errorf("could not parse webhook message timestamp: %w", err)
+	}
+
+	return MessageMetadata{
+		MessageID:        header.Get(webhookHeaderMessageID),
+		MessageType:      header.Get(webhookHeaderMessageType),
+		MessageTimestamp: timestamp,
+	}, nil
+}
+
+func (h *WebhookHandler) handleVerification(w http.ResponseWriter, body []byte) error {
+	var verification struct {
+		Challenge    string              `json:"challenge"`
+		Subscription PayloadSubscription `json:"subscription"`
+	}
+	err := h.client.decode(body, &verification)
+	if err != nil {
+		http.Error(w, "could not parse challenge", http.StatusBadRequest)
+		return fmt.Errorf("could not unmarshal webhook verification: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(verification.Challenge))
+
+	callFunc(h.onVerification, verification.Subscription)
+	return nil
+}
+
+func (h *WebhookHandler) handleNotification(w http.ResponseWriter, metadata MessageMetadata, body []byte) error {
+	message := NotificationMessage{Metadata: metadata}
+	err := h.client.decode(body, &message.Payload)
+	if err != nil {
+		http.Error(w, "could not parse notification", http.StatusBadRequest)
+		return fmt.Errorf("could not unmarshal webhook notification: %w", err)
+	}
+
+	// Twitch only retries messages that were not acknowledged
+	if !h.client.redelivered(message) {
+		err = h.client.dispatchNotification(message)
+		if err != nil {
+			http.Error(w, "could not handle notification", http.StatusInternalServerError)
+			return err
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (h *WebhookHandler) handleRevocation(w http.ResponseWriter, metadata MessageMetadata, body []byte) error {
+	message := RevokeMessage{Metadata: metadata}
+	err := h.client.decode(body, &message.Payload)
+	if err != nil {
+		http.Error(w, "could not parse revocation", http.StatusBadRequest)
+		return fmt.Errorf("could not unmarshal webhook revocation: %w", err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	callFunc(h.client.onRevoke, message)
+	h.client.handleRevocation(message)
+	return nil
+}
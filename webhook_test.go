@@ -0,0 +1,501 @@
+package twitch_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joeyak/go-twitch-eventsub/v2"
+	"github.com/joeyak/go-twitch-eventsub/v2/clocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+const webhookSecret = "s3cre7s3cre7"
+
+func newWebhookRequest(t *testing.T, url, messageType, secret string, body []byte) *http.Request {
+	return newWebhookRequestWithID(t, url, messageType, secret, body, uuid.NewString(), time.Now())
+}
+
+func newWebhookRequestWithID(t *testing.T, url, messageType, secret string, body []byte, messageID string, sent time.Time) *http.Request {
+	timestamp := sent.UTC().Format(time.RFC3339Nano)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID + timestamp))
+	mac.Write(body)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Twitch-Eventsub-Message-Id", messageID)
+	req.Header.Set("Twitch-Eventsub-Message-Timestamp", timestamp)
+	req.Header.Set("Twitch-Eventsub-Message-Type", messageType)
+	req.Header.Set("Twitch-Eventsub-Message-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newWebhookNotification(t *testing.T, eventType twitch.EventSubscription) []byte {
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(testEvents, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	event := events[string(eventType)]
+	body, err := json.Marshal(map[string]any{
+		"subscription": twitch.PayloadSubscription{
+			SubscriptionRequest: twitch.SubscriptionRequest{Type: eventType, Version: "1"},
+		},
+		"event": &event,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestWebhookNotification(t *testing.T) {
+	t.Parallel()
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client := twitch.NewClient()
+		client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+			assert.Equal(t, "1337", event.BroadcasterUserId)
+			close(ch)
+		})
+
+		server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+		defer server.Close()
+
+		body := newWebhookNotification(t, twitch.SubStreamOnline)
+		resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "notification", webhookSecret, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestWebhookInvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+	client.OnError(func(err error) {
+		assert.ErrorIs(t, err, twitch.ErrInvalidSignature)
+	})
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		t.Error("event with an invalid signature was dispatched")
+	})
+
+	server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+	defer server.Close()
+
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "notification", "wrong", body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestWebhookVerification(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(twitch.NewWebhookHandler(twitch.NewClient(), webhookSecret))
+	defer server.Close()
+
+	body := []byte(`{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{}}`)
+	resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "webhook_callback_verification", webhookSecret, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	challenge, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "pogchamp-kappa-360noscope-vohiyo", string(challenge))
+}
+
+func TestWebhookSecretRotation(t *testing.T) {
+	t.Parallel()
+
+	var rejected atomic.Int32
+	client := twitch.NewClient()
+	client.OnError(func(err error) {
+		assert.ErrorIs(t, err, twitch.ErrInvalidSignature)
+		rejected.Add(1)
+	})
+
+	handler := twitch.NewWebhookHandler(client, webhookSecret)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	status := func(secret string) int {
+		body := []byte(`{"challenge":"kappa","subscription":{}}`)
+		resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "webhook_callback_verification", secret, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	handler.AddSecret("n3ws3cre7")
+	assert.Equal(t, webhookSecret, handler.Secret())
+	assert.Equal(t, http.StatusOK, status(webhookSecret))
+	assert.Equal(t, http.StatusOK, status("n3ws3cre7"))
+
+	handler.PromoteSecret("n3ws3cre7")
+	assert.Equal(t, []string{"n3ws3cre7", webhookSecret}, handler.Secrets())
+
+	handler.RetireSecret(webhookSecret)
+	assert.Equal(t, []string{"n3ws3cre7"}, handler.Secrets())
+	assert.Equal(t, http.StatusForbidden, status(webhookSecret))
+	assert.Equal(t, http.StatusOK, status("n3ws3cre7"))
+	assert.Equal(t, int32(1), rejected.Load())
+}
+
+type mapReplayStore map[string]time.Time
+
+func (s mapReplayStore) Load() (map[string]time.Time, error) {
+	return s, nil
+}
+
+func (s mapReplayStore) Add(id string, timestamp time.Time) error {
+	s[id] = timestamp
+	return nil
+}
+
+func (s mapReplayStore) Remove(id string) error {
+	delete(s, id)
+	return nil
+}
+
+func (s mapReplayStore) Prune(before time.Time) error {
+	for id, timestamp := range s {
+		if timestamp.Before(before) {
+			delete(s, id)
+		}
+	}
+	return nil
+}
+
+func TestWebhookStaleMessage(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+	client.OnError(func(err error) {
+		assert.ErrorIs(t, err, twitch.ErrStaleMessage)
+	})
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		t.Error("stale event was dispatched")
+	})
+
+	server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+	defer server.Close()
+
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	req := newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, body, uuid.NewString(), time.Now().Add(-11*time.Minute))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestWebhookReplay(t *testing.T) {
+	t.Parallel()
+
+	var count atomic.Int32
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		count.Add(1)
+	})
+
+	store := mapReplayStore{"loaded": time.Now()}
+	handler := twitch.NewWebhookHandler(client, webhookSecret)
+	handler.ReplayStore = store
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	sent := time.Now()
+	for _, id := range []string{"first", "first", "loaded"} {
+		resp, err := http.DefaultClient.Do(newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, body, id, sent))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+	server.Close()
+
+	assert.Equal(t, int32(1), count.Load())
+	assert.Contains(t, store, "first")
+}
+
+func TestWebhookReplayAfterError(t *testing.T) {
+	t.Parallel()
+
+	var count atomic.Int32
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnError(func(err error) {})
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		count.Add(1)
+	})
+
+	server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+	defer server.Close()
+
+	sent := time.Now()
+	resp, err := http.DefaultClient.Do(newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, []byte("{"), "retried", sent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	resp, err = http.DefaultClient.Do(newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, body, "retried", sent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestWebhookRetryAfterDispatchError(t *testing.T) {
+	t.Parallel()
+
+	var count atomic.Int32
+	client := twitch.NewClient(twitch.WithSyncDispatch())
+	client.OnError(func(err error) {
+		var decodeErr twitch.DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+	})
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		count.Add(1)
+	})
+
+	store := mapReplayStore{}
+	handler := twitch.NewWebhookHandler(client, webhookSecret)
+	handler.ReplayStore = store
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	sent := time.Now()
+	body := []byte(`{"subscription":{"type":"stream.online","version":"1"},"event":[]}`)
+	resp, err := http.DefaultClient.Do(newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, body, "retried", sent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.NotContains(t, store, "retried")
+
+	body = newWebhookNotification(t, twitch.SubStreamOnline)
+	resp, err = http.DefaultClient.Do(newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, body, "retried", sent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, int32(1), count.Load())
+	assert.Contains(t, store, "retried")
+}
+
+func TestWebhookReplayPrune(t *testing.T) {
+	t.Parallel()
+
+	clock := clocktest.NewClock(time.Now())
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithClock(clock))
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {})
+
+	store := mapReplayStore{}
+	handler := twitch.NewWebhookHandler(client, webhookSecret)
+	handler.ReplayStore = store
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	send := func(id string) {
+		body := newWebhookNotification(t, twitch.SubStreamOnline)
+		resp, err := http.DefaultClient.Do(newWebhookRequestWithID(t, server.URL, "notification", webhookSecret, body, id, clock.Now()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	send("old")
+	clock.Advance(5 * time.Minute)
+	send("new")
+	assert.Contains(t, store, "old")
+
+	clock.Advance(6 * time.Minute)
+	send("newer")
+	assert.NotContains(t, store, "old")
+	assert.Contains(t, store, "new")
+	assert.Contains(t, store, "newer")
+}
+
+func TestWebhookOnVerification(t *testing.T) {
+	t.Parallel()
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		handler := twitch.NewWebhookHandler(twitch.NewClient(), webhookSecret)
+		handler.OnVerification(func(subscription twitch.PayloadSubscription) {
+			assert.Equal(t, "f1c2a387-161a-49f9-a165-0f21d7a4e1c4", subscription.ID)
+			assert.Equal(t, twitch.SubStreamOnline, subscription.Type)
+			close(ch)
+		})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		body := []byte(`{"challenge":"kappa","subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"stream.online","status":"webhook_callback_verification_pending"}}`)
+		resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "webhook_callback_verification", webhookSecret, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestWebhookRevocation(t *testing.T) {
+	t.Parallel()
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client := twitch.NewClient()
+		client.OnRevocation(func(revocation twitch.Revocation) {
+			assert.Equal(t, twitch.SubscriptionStatusAuthorizationRevoked, revocation.Reason)
+			assert.Equal(t, "1337", revocation.UserID)
+			close(ch)
+		})
+
+		server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+		defer server.Close()
+
+		body := []byte(`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"stream.online","version":"1","status":"authorization_revoked","condition":{"broadcaster_user_id":"1337"}}}`)
+		resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "revocation", webhookSecret, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestWebhookBatchedNotification(t *testing.T) {
+	t.Parallel()
+
+	assertEventOccured(t, func(ch chan struct{}) {
+		client := twitch.NewClient()
+		client.OnEventDropEntitlementGrant(func(events []twitch.EventDropEntitlementGrant) {
+			if assert.Len(t, events, 2) {
+				assert.Equal(t, "cool_user", events[0].Data.UserLogin)
+				assert.Equal(t, "cooler_user", events[1].Data.UserLogin)
+			}
+			close(ch)
+		})
+
+		server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+		defer server.Close()
+
+		var events map[string]json.RawMessage
+		if err := json.Unmarshal(testEvents, &events); err != nil {
+			t.Fatal(err)
+		}
+		batch := events[string(twitch.SubDropEntitlementGrant)]
+		body, err := json.Marshal(map[string]any{
+			"subscription": twitch.PayloadSubscription{
+				SubscriptionRequest: twitch.SubscriptionRequest{Type: twitch.SubDropEntitlementGrant, Version: "1", IsBatchingEnabled: true},
+			},
+			"events": &batch,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "notification", webhookSecret, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
+func TestWebhookHoldUntilReady(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithHoldUntilReady(0))
+	var count atomic.Int32
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		count.Add(1)
+	})
+
+	server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+	defer server.Close()
+
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "notification", webhookSecret, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Zero(t, count.Load())
+
+	client.Ready()
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestWebhookPause(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient(twitch.WithSyncDispatch(), twitch.WithPauseBuffer(0))
+	var count atomic.Int32
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		count.Add(1)
+	})
+
+	server := httptest.NewServer(twitch.NewWebhookHandler(client, webhookSecret))
+	defer server.Close()
+
+	client.Pause()
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "notification", webhookSecret, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Zero(t, count.Load())
+
+	delivered, dropped := client.Resume()
+	assert.Equal(t, 1, delivered)
+	assert.Zero(t, dropped)
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestWebhookBodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	client := twitch.NewClient()
+	client.OnEventStreamOnline(func(event twitch.EventStreamOnline) {
+		t.Error("oversized request was dispatched")
+	})
+
+	handler := twitch.NewWebhookHandler(client, webhookSecret)
+	handler.MaxBodySize = 16
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := newWebhookNotification(t, twitch.SubStreamOnline)
+	resp, err := http.DefaultClient.Do(newWebhookRequest(t, server.URL, "notification", webhookSecret, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}